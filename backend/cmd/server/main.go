@@ -18,20 +18,33 @@
 //	@securityDefinitions.apikey	BearerAuth
 //	@in							header
 //	@name						Authorization
-//	@description				Type "Bearer" followed by a space and JWT token.
+//	@description				Type "Bearer" followed by a space and a JWT or personal access token.
 package main
 
 import (
 	"backend/docs"
+	"backend/internal/activity"
+	"backend/internal/attachments"
 	"backend/internal/auth"
 	"backend/internal/boards"
 	"backend/internal/cards"
+	"backend/internal/changes"
+	"backend/internal/checklists"
+	"backend/internal/collab"
+	"backend/internal/commands"
+	"backend/internal/comments"
 	"backend/internal/config"
 	db "backend/internal/db/sqlc"
 	"backend/internal/jobs"
+	"backend/internal/labels"
 	"backend/internal/lists"
+	"backend/internal/locks"
 	"backend/internal/logger"
+	"backend/internal/mail"
 	"backend/internal/middleware"
+	"backend/internal/oidc"
+	"backend/internal/snapshot"
+	"backend/internal/tokens"
 	"backend/internal/websocket"
 	"context"
 	"log"
@@ -64,7 +77,9 @@ func main() {
 
 	queries := db.New(pool)
 
-	r := gin.Default()
+	// gin.New rather than gin.Default: its logger would print the query
+	// strings that RequestLogging redacts.
+	r := gin.New()
 
 	// Global middleware
 	r.Use(logger.RequestLogging())
@@ -92,28 +107,115 @@ func main() {
 	docs.SwaggerInfo.BasePath = "/"
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	var mailer mail.Mailer
+	switch cfg.Mail.Backend {
+	case "smtp":
+		mailer = mail.NewSMTPMailer(cfg.Mail.SMTPHost, cfg.Mail.SMTPPort, cfg.Mail.SMTPUsername, cfg.Mail.SMTPPassword, cfg.Mail.From)
+	case "outbox":
+		outbox, err := mail.NewOutbox(cfg.Mail.OutboxDir, cfg.Mail.From)
+		if err != nil {
+			logger.Fatal("cannot initialize mail outbox", "dir", cfg.Mail.OutboxDir, "error", err)
+		}
+		mailer = outbox
+	default:
+		logger.Fatal("unknown MAIL_BACKEND", "value", cfg.Mail.Backend)
+	}
+
 	// Auth routes (public + /auth/me)
-	authSvc := auth.NewService(queries, cfg.JWTSecret)
+	authSvc := auth.NewService(queries, cfg.JWTSecret, cfg.AccessTokenTTL, cfg.RefreshTokenTTL, mailer, cfg.AppURL)
+	if cfg.OIDC.Issuer != "" {
+		oidcCtx, oidcCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		provider, err := oidc.Discover(oidcCtx, oidc.Config{
+			Issuer:       cfg.OIDC.Issuer,
+			ClientID:     cfg.OIDC.ClientID,
+			ClientSecret: cfg.OIDC.ClientSecret,
+			RedirectURL:  cfg.OIDC.RedirectURL,
+			Scopes:       cfg.OIDC.Scopes,
+		})
+		oidcCancel()
+		if err != nil {
+			logger.Fatal("cannot initialize OIDC", "issuer", cfg.OIDC.Issuer, "error", err)
+		}
+		authSvc.UseOIDC(provider)
+		logger.Info("OIDC sign-in enabled", "issuer", provider.Issuer())
+	}
 	auth.RegisterRoutes(r, authSvc, cfg.JWTSecret)
 
+	// Personal access tokens for scripts and CI, accepted in place of a JWT
+	tokensSvc := tokens.NewService(tokens.NewRepository(queries), queries)
+
 	// Protected API routes
 	api := r.Group("/api")
-	api.Use(middleware.Auth(cfg.JWTSecret))
+	api.Use(middleware.Auth(cfg.JWTSecret, tokensSvc.Authenticate))
+	tokens.RegisterRoutes(api, tokensSvc)
 
-	hub := websocket.NewHub()
+	var wsBackend websocket.Backend
+	switch cfg.WSBackend {
+	case "postgres":
+		b, err := websocket.NewPGBackend(pool)
+		if err != nil {
+			logger.Fatal("cannot initialize WebSocket fan-out", "error", err)
+		}
+		wsBackend = b
+	case "local":
+	default:
+		logger.Fatal("unknown WS_BACKEND", "value", cfg.WSBackend)
+	}
+	hub := websocket.NewHub(changes.NewJournal(queries), wsBackend)
+	websocket.AllowOrigins(cfg.WSAllowedOrigins)
 	go hub.Run()
+	websocket.RegisterRoutes(api, hub, queries)
+	authSvc.OnSessionsRevoked(hub.RevokeUser)
+
+	activityRec := activity.NewRecorder(queries)
 
-	boardsRepo := boards.NewRepository(queries)
-	boardsSvc := boards.NewService(boardsRepo, hub)
+	boardsRepo := boards.NewRepository(queries, pool)
+	boardsSvc := boards.NewService(boardsRepo, hub, activityRec)
 	boards.RegisterRoutes(api, boardsSvc)
 
 	listsRepo := lists.NewRepository(queries)
-	listsSvc := lists.NewService(listsRepo, queries, hub)
+	listsSvc := lists.NewService(listsRepo, queries, hub, activityRec)
 	lists.RegisterRoutes(api, listsSvc)
 
 	cardsRepo := cards.NewRepository(queries)
-	cardsSvc := cards.NewService(cardsRepo, queries, hub)
+	cardsSvc := cards.NewService(cardsRepo, queries, hub, activityRec)
 	cards.RegisterRoutes(api, cardsSvc)
+	locksSvc := locks.NewService(queries, hub)
+	hub.OnLeave(locksSvc.ReleaseAll)
+	collabSvc := collab.NewService(queries, hub, cardsSvc)
+	cardsSvc.OnDescriptionChange(collabSvc.Replace)
+	boardsSvc.OnCardsUnassigned(cardsSvc.RefreshCards)
+	commands.Register(hub, cardsSvc, listsSvc, locksSvc, collabSvc)
+
+	labelsRepo := labels.NewRepository(queries)
+	labelsSvc := labels.NewService(labelsRepo, queries, hub)
+	labels.RegisterRoutes(api, labelsSvc)
+
+	commentsRepo := comments.NewRepository(queries, pool)
+	commentsSvc := comments.NewService(commentsRepo, queries, hub)
+	comments.RegisterRoutes(api, commentsSvc)
+
+	checklistsRepo := checklists.NewRepository(queries)
+	checklistsSvc := checklists.NewService(checklistsRepo, queries, hub, cardsSvc)
+	checklists.RegisterRoutes(api, checklistsSvc)
+
+	storage, err := attachments.NewLocalStorage(cfg.Storage.Dir)
+	if err != nil {
+		logger.Fatal("cannot initialize attachment storage", "dir", cfg.Storage.Dir, "error", err)
+	}
+	attachmentsRepo := attachments.NewRepository(queries)
+	attachmentsSvc := attachments.NewService(attachmentsRepo, queries, hub, storage, cfg.Storage.MaxUploadSize)
+	attachments.RegisterRoutes(api, attachmentsSvc)
+
+	activityRepo := activity.NewRepository(queries)
+	activitySvc := activity.NewService(activityRepo, queries)
+	activity.RegisterRoutes(api, activitySvc)
+
+	snapshotSvc := snapshot.NewService(pool)
+	snapshot.RegisterRoutes(api, snapshotSvc)
+
+	changesSvc := changes.NewService(queries)
+	changes.RegisterRoutes(api, changesSvc)
 
 	// User profile endpoint
 	// getUserProfile gets the current user's profile
@@ -140,6 +242,13 @@ func main() {
 	r.GET("/ws/board/:id", func(c *gin.Context) {
 		websocket.ServeBoardWS(c, hub, queries, cfg.JWTSecret)
 	})
+	// SSE fallback for clients that cannot open a WebSocket
+	r.GET("/sse/board/:id", func(c *gin.Context) {
+		websocket.ServeBoardSSE(c, hub, queries, cfg.JWTSecret)
+	})
+	r.GET("/ws/user", func(c *gin.Context) {
+		websocket.ServeUserWS(c, hub, queries, cfg.JWTSecret)
+	})
 
 	// Start the position normalizer background job
 	// Run every 30 minutes to check and fix any position conflicts
@@ -148,6 +257,16 @@ func main() {
 	defer positionNormalizer.Stop()
 	logger.Info("Position normalizer background job started", "interval", "30m")
 
+	// Permanently delete whatever has been in the trash longer than the retention period
+	trashPurger := jobs.NewTrashPurger(queries, storage, cfg.TrashRetention, time.Hour)
+	trashPurger.Start()
+	defer trashPurger.Stop()
+
+	// Save descriptions edited together over the WebSocket
+	descriptionSaver := jobs.NewDescriptionSaver(collabSvc, 10*time.Second)
+	descriptionSaver.Start()
+	defer descriptionSaver.Stop()
+
 	logger.Info("Starting HTTP server", "port", cfg.Port)
 	if err := r.Run(":" + cfg.Port); err != nil {
 		logger.Fatal("server failed", "error", err)
@@ -0,0 +1,64 @@
+// internal/activity/diff_test.go
+package activity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type card struct {
+	ID       int32
+	ListID   int32
+	Title    string
+	Position int32
+}
+
+func TestDiff_KeepsOnlyChangedFields(t *testing.T) {
+	before, after, err := diff(
+		card{ID: 7, ListID: 1, Title: "Fix login", Position: 2},
+		card{ID: 7, ListID: 3, Title: "Fix login", Position: 1},
+	)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ListID":1,"Position":2}`, string(before))
+	assert.JSONEq(t, `{"ListID":3,"Position":1}`, string(after))
+}
+
+func TestDiff_CreateAndDelete(t *testing.T) {
+	c := card{ID: 7, ListID: 1, Title: "Fix login", Position: 2}
+
+	before, after, err := diff(nil, c)
+	require.NoError(t, err)
+	assert.Nil(t, before)
+	assert.JSONEq(t, `{"ID":7,"ListID":1,"Title":"Fix login","Position":2}`, string(after))
+
+	before, after, err = diff(c, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ID":7,"ListID":1,"Title":"Fix login","Position":2}`, string(before))
+	assert.Nil(t, after)
+}
+
+func TestDiff_Unchanged(t *testing.T) {
+	c := card{ID: 7, Title: "Fix login"}
+	before, after, err := diff(c, c)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(before))
+	assert.JSONEq(t, `{}`, string(after))
+}
+
+func TestPage(t *testing.T) {
+	items := []Activity{{ID: 9}, {ID: 8}, {ID: 7}}
+
+	p := page(items, 2)
+	assert.Len(t, p.Items, 2)
+	require.NotNil(t, p.NextCursor)
+	assert.Equal(t, int32(8), *p.NextCursor)
+
+	p = page(items, 3)
+	assert.Len(t, p.Items, 3)
+	assert.Nil(t, p.NextCursor)
+
+	assert.Equal(t, int32(DefaultPageSize), pageSize(0))
+	assert.Equal(t, int32(MaxPageSize), pageSize(1000))
+}
@@ -0,0 +1,30 @@
+package activity
+
+import "time"
+
+// ActivityResponse documents an activity entry in API responses. Handlers
+// return Activity, which has no JSON tags, so fields keep their Go names on
+// the wire.
+type ActivityResponse struct {
+	ID         int32          `json:"ID" example:"42"`
+	BoardID    int32          `json:"BoardID" example:"1"`
+	ActorID    *int32         `json:"ActorID" example:"1"`
+	ActorName  *string        `json:"ActorName" example:"John Doe"`
+	Action     string         `json:"Action" example:"card.moved"`
+	EntityType string         `json:"EntityType" example:"card"`
+	EntityID   int32          `json:"EntityID" example:"7"`
+	Before     map[string]any `json:"Before"`
+	After      map[string]any `json:"After"`
+	CreatedAt  time.Time      `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+}
+
+// ActivityPage documents a page of the activity feed
+type ActivityPage struct {
+	Items      []ActivityResponse `json:"Items"`
+	NextCursor *int32             `json:"NextCursor" example:"17"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error" example:"not a member"`
+}
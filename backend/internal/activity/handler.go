@@ -0,0 +1,114 @@
+package activity
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup, svc *Service) {
+	r.GET("/boards/:boardId/activity", boardActivityHandler(svc))
+	r.GET("/cards/:id/activity", cardActivityHandler(svc))
+}
+
+// errorStatus maps service errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotMember):
+		return http.StatusForbidden
+	case errors.Is(err, ErrCardNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// pageParams reads the "before" cursor and "limit" query parameters
+func pageParams(c *gin.Context) (int32, int32, error) {
+	var before, limit int
+	var err error
+	if v := c.Query("before"); v != "" {
+		if before, err = strconv.Atoi(v); err != nil || before < 0 {
+			return 0, 0, errors.New("invalid before cursor")
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			return 0, 0, errors.New("invalid limit")
+		}
+	}
+	return int32(before), int32(limit), nil
+}
+
+// boardActivityHandler gets the activity feed of a board
+//
+//	@Summary		Get board activity
+//	@Description	Get the audit trail of a board, newest first. Pass NextCursor as "before" to load the next page
+//	@Tags			Activity
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId	path		int				true	"Board ID"
+//	@Param			before	query		int				false	"Return entries older than this activity ID"
+//	@Param			limit	query		int				false	"Page size (default 50, max 100)"
+//	@Success		200		{object}	ActivityPage	"Page of activity entries"
+//	@Failure		400		{object}	ErrorResponse	"Invalid request"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/boards/{boardId}/activity [get]
+func boardActivityHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		boardID, _ := strconv.Atoi(c.Param("boardId"))
+		userID := int32(c.GetInt("userID"))
+		before, limit, err := pageParams(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		p, err := svc.BoardFeed(c.Request.Context(), userID, int32(boardID), before, limit)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, p)
+	}
+}
+
+// cardActivityHandler gets the history of a card
+//
+//	@Summary		Get card history
+//	@Description	Get the audit trail of a single card (including deleted cards), newest first
+//	@Tags			Activity
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int				true	"Card ID"
+//	@Param			before	query		int				false	"Return entries older than this activity ID"
+//	@Param			limit	query		int				false	"Page size (default 50, max 100)"
+//	@Success		200		{object}	ActivityPage	"Page of activity entries"
+//	@Failure		400		{object}	ErrorResponse	"Invalid request"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		404		{object}	ErrorResponse	"Card not found"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/cards/{id}/activity [get]
+func cardActivityHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+		before, limit, err := pageParams(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		p, err := svc.CardHistory(c.Request.Context(), userID, int32(cardID), before, limit)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, p)
+	}
+}
@@ -0,0 +1,143 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+)
+
+// Entity types stored in activities.entity_type.
+const (
+	EntityBoard  = "board"
+	EntityList   = "list"
+	EntityCard   = "card"
+	EntityMember = "member"
+)
+
+// Actions recorded by the services.
+const (
+	BoardCreated  = "board.created"
+	BoardUpdated  = "board.updated"
+	BoardArchived = "board.archived"
+	BoardRestored = "board.restored"
+
+	MemberAdded   = "member.added"
+	MemberRemoved = "member.removed"
+	MemberLeft    = "member.left"
+
+	ListCreated  = "list.created"
+	ListUpdated  = "list.updated"
+	ListMoved    = "list.moved"
+	ListArchived = "list.archived"
+	ListRestored = "list.restored"
+
+	CardCreated      = "card.created"
+	CardUpdated      = "card.updated"
+	CardMoved        = "card.moved"
+	CardArchived     = "card.archived"
+	CardRestored     = "card.restored"
+	CardDuplicated   = "card.duplicated"
+	CardLabelAdded   = "card.label_added"
+	CardLabelRemoved = "card.label_removed"
+	CardAssigned     = "card.assigned"
+	CardUnassigned   = "card.unassigned"
+)
+
+// Entry describes a single mutation. Before and After are the entity state
+// around the change (nil for creations and deletions respectively); only the
+// fields that differ are stored.
+type Entry struct {
+	BoardID    int32
+	ActorID    int32
+	Action     string
+	EntityType string
+	EntityID   int32
+	Before     any
+	After      any
+}
+
+// Recorder writes activity entries. A nil *Recorder records nothing, so
+// services can be used without an audit trail.
+type Recorder struct {
+	q *db.Queries
+}
+
+func NewRecorder(q *db.Queries) *Recorder { return &Recorder{q: q} }
+
+// Record stores e. Failures are logged but never returned: the mutation it
+// describes has already happened and must not be reported as failed.
+func (r *Recorder) Record(ctx context.Context, e Entry) {
+	if r == nil {
+		return
+	}
+	before, after, err := diff(e.Before, e.After)
+	if err == nil {
+		_, err = r.q.CreateActivity(ctx, db.CreateActivityParams{
+			BoardID:    e.BoardID,
+			ActorID:    pgtype.Int4{Int32: e.ActorID, Valid: e.ActorID != 0},
+			Action:     e.Action,
+			EntityType: e.EntityType,
+			EntityID:   e.EntityID,
+			Before:     before,
+			After:      after,
+		})
+	}
+	if err != nil {
+		logger.WithContext(ctx).Error("Failed to record activity",
+			"board_id", e.BoardID,
+			"action", e.Action,
+			"entity_id", e.EntityID,
+			"error", err,
+		)
+	}
+}
+
+// diff encodes before and after as JSON objects, dropping the fields that
+// are equal in both. A nil side stays NULL.
+func diff(before, after any) ([]byte, []byte, error) {
+	b, err := toMap(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := toMap(after)
+	if err != nil {
+		return nil, nil, err
+	}
+	if b != nil && a != nil {
+		for k, v := range b {
+			if av, ok := a[k]; ok && reflect.DeepEqual(v, av) {
+				delete(b, k)
+				delete(a, k)
+			}
+		}
+	}
+	return marshal(b), marshal(a), nil
+}
+
+func toMap(v any) (map[string]any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func marshal(m map[string]any) []byte {
+	if m == nil {
+		return nil
+	}
+	raw, _ := json.Marshal(m) // values came from json.Unmarshal
+	return raw
+}
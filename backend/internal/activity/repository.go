@@ -0,0 +1,21 @@
+package activity
+
+import (
+	"context"
+
+	db "backend/internal/db/sqlc"
+)
+
+type Repository struct{ q *db.Queries }
+
+func NewRepository(q *db.Queries) *Repository { return &Repository{q: q} }
+
+func (r *Repository) ListByBoard(ctx context.Context, arg db.ListBoardActivityParams) ([]db.ListBoardActivityRow, error) {
+	return r.q.ListBoardActivity(ctx, arg)
+}
+func (r *Repository) ListByEntity(ctx context.Context, arg db.ListEntityActivityParams) ([]db.ListEntityActivityRow, error) {
+	return r.q.ListEntityActivity(ctx, arg)
+}
+func (r *Repository) EntityBoard(ctx context.Context, entityType string, entityID int32) (int32, error) {
+	return r.q.GetEntityActivityBoard(ctx, db.GetEntityActivityBoardParams{EntityType: entityType, EntityID: entityID})
+}
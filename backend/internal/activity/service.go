@@ -0,0 +1,139 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "backend/internal/db/sqlc"
+)
+
+type Service struct {
+	repo *Repository
+	q    *db.Queries // for cross‑repo checks
+}
+
+func NewService(repo *Repository, q *db.Queries) *Service {
+	return &Service{repo: repo, q: q}
+}
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+var (
+	ErrNotMember    = errors.New("not a member")
+	ErrCardNotFound = errors.New("card not found")
+)
+
+// Activity is an activity entry as returned to clients.
+type Activity struct {
+	ID         int32
+	BoardID    int32
+	ActorID    pgtype.Int4
+	ActorName  pgtype.Text
+	Action     string
+	EntityType string
+	EntityID   int32
+	Before     json.RawMessage
+	After      json.RawMessage
+	CreatedAt  pgtype.Timestamp
+}
+
+// Page is one page of an activity feed, newest first. NextCursor is passed
+// as "before" to get the following page and is nil on the last one.
+type Page struct {
+	Items      []Activity
+	NextCursor *int32
+}
+
+func fromRow(r db.ListBoardActivityRow) Activity {
+	return Activity{
+		ID:         r.ID,
+		BoardID:    r.BoardID,
+		ActorID:    r.ActorID,
+		ActorName:  r.ActorName,
+		Action:     r.Action,
+		EntityType: r.EntityType,
+		EntityID:   r.EntityID,
+		Before:     r.Before,
+		After:      r.After,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+func pageSize(limit int32) int32 {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	return min(limit, MaxPageSize)
+}
+
+// page trims the extra row fetched to detect whether more entries follow.
+func page(items []Activity, size int32) Page {
+	p := Page{Items: items}
+	if int32(len(items)) > size {
+		p.Items = items[:size]
+		next := p.Items[size-1].ID
+		p.NextCursor = &next
+	}
+	return p
+}
+
+// BoardFeed returns the activity of a board, newest first, starting below
+// the before cursor (0 for the first page).
+func (s *Service) BoardFeed(ctx context.Context, userID, boardID, before, limit int32) (Page, error) {
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: boardID, UserID: userID,
+	}); err != nil {
+		return Page{}, ErrNotMember
+	}
+	size := pageSize(limit)
+	rows, err := s.repo.ListByBoard(ctx, db.ListBoardActivityParams{
+		BoardID: boardID, BeforeID: before, PageSize: size + 1,
+	})
+	if err != nil {
+		return Page{}, err
+	}
+	items := make([]Activity, len(rows))
+	for i, r := range rows {
+		items[i] = fromRow(r)
+	}
+	return page(items, size), nil
+}
+
+// CardHistory returns the activity of a single card. It also works for
+// deleted cards, using the board recorded in their history.
+func (s *Service) CardHistory(ctx context.Context, userID, cardID, before, limit int32) (Page, error) {
+	var boardID int32
+	if card, err := s.q.GetCardByID(ctx, cardID); err == nil {
+		lst, err := s.q.GetListByID(ctx, card.ListID)
+		if err != nil {
+			return Page{}, err
+		}
+		boardID = lst.BoardID
+	} else if boardID, err = s.repo.EntityBoard(ctx, EntityCard, cardID); err != nil {
+		return Page{}, ErrCardNotFound
+	}
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: boardID, UserID: userID,
+	}); err != nil {
+		return Page{}, ErrNotMember
+	}
+
+	size := pageSize(limit)
+	rows, err := s.repo.ListByEntity(ctx, db.ListEntityActivityParams{
+		EntityType: EntityCard, EntityID: cardID, BeforeID: before, PageSize: size + 1,
+	})
+	if err != nil {
+		return Page{}, err
+	}
+	items := make([]Activity, len(rows))
+	for i, r := range rows {
+		items[i] = fromRow(db.ListBoardActivityRow(r))
+	}
+	return page(items, size), nil
+}
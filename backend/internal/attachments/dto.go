@@ -0,0 +1,26 @@
+package attachments
+
+import "time"
+
+// AttachmentResponse documents attachment metadata in API responses.
+// Handlers return Attachment, which has no JSON tags, so fields keep their
+// Go names on the wire.
+type AttachmentResponse struct {
+	ID          int32     `json:"ID" example:"1"`
+	CardID      int32     `json:"CardID" example:"1"`
+	UploaderID  int32     `json:"UploaderID" example:"1"`
+	Filename    string    `json:"Filename" example:"screenshot.png"`
+	ContentType string    `json:"ContentType" example:"image/png"`
+	Size        int64     `json:"Size" example:"48213"`
+	CreatedAt   time.Time `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error" example:"Attachment not found"`
+}
+
+// MessageResponse represents a simple message response
+type MessageResponse struct {
+	Message string `json:"message" example:"attachment deleted"`
+}
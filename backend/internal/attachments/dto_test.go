@@ -0,0 +1,26 @@
+// internal/attachments/dto_test.go
+package attachments
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keys returns the top-level JSON keys of v.
+func keys(t *testing.T, v any) []string {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	var m map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(b, &m))
+	return slices.Sorted(maps.Keys(m))
+}
+
+// The Swagger DTOs must describe what the handlers actually send.
+func TestAttachmentResponse_MatchesPayload(t *testing.T) {
+	assert.Equal(t, keys(t, Attachment{}), keys(t, AttachmentResponse{}))
+}
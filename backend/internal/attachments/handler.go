@@ -0,0 +1,181 @@
+package attachments
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left for multipart headers and boundaries on
+// top of the file size limit.
+const multipartOverhead = 1 << 20
+
+func RegisterRoutes(r *gin.RouterGroup, svc *Service) {
+	g := r.Group("/cards/:id/attachments")
+	g.GET("", listAttachmentsHandler(svc))
+	g.POST("", uploadAttachmentHandler(svc))
+	g.GET("/:attachmentId", downloadAttachmentHandler(svc))
+	g.DELETE("/:attachmentId", deleteAttachmentHandler(svc))
+}
+
+// errorStatus maps service errors to HTTP status codes
+func errorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTooLarge), errors.As(err, &maxBytesErr):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrEmptyFile), errors.Is(err, ErrNoFilename):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// listAttachmentsHandler gets the attachments of a card
+//
+//	@Summary		Get card attachments
+//	@Description	Get metadata of all files attached to a card
+//	@Tags			Attachments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int						true	"Card ID"
+//	@Success		200	{array}		AttachmentResponse		"List of attachments"
+//	@Failure		401	{object}	ErrorResponse			"Unauthorized"
+//	@Failure		404	{object}	ErrorResponse			"Card not found"
+//	@Failure		500	{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/cards/{id}/attachments [get]
+func listAttachmentsHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		as, err := svc.ListByCard(c.Request.Context(), userID, int32(cardID))
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, as)
+	}
+}
+
+// uploadAttachmentHandler attaches a file to a card
+//
+//	@Summary		Upload attachment
+//	@Description	Upload a file as multipart/form-data field "file". The content type is detected from the file content
+//	@Tags			Attachments
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Card ID"
+//	@Param			file	formData	file				true	"File to attach"
+//	@Success		201		{object}	AttachmentResponse	"Attachment uploaded successfully"
+//	@Failure		400		{object}	ErrorResponse		"Invalid request"
+//	@Failure		401		{object}	ErrorResponse		"Unauthorized"
+//	@Failure		404		{object}	ErrorResponse		"Card not found"
+//	@Failure		413		{object}	ErrorResponse		"File is too large"
+//	@Failure		500		{object}	ErrorResponse		"Internal server error"
+//	@Router			/api/cards/{id}/attachments [post]
+func uploadAttachmentHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, svc.MaxSize()+multipartOverhead)
+		mr, err := c.Request.MultipartReader()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		// Stream the "file" part straight into storage instead of buffering
+		// the whole form.
+		for {
+			part, err := mr.NextPart()
+			if errors.Is(err, io.EOF) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "file field is required"})
+				return
+			}
+			if err != nil {
+				c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			if part.FormName() != "file" {
+				continue
+			}
+			a, err := svc.Upload(c.Request.Context(), userID, int32(cardID), part.FileName(), part)
+			if err != nil {
+				c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusCreated, a)
+			return
+		}
+	}
+}
+
+// downloadAttachmentHandler downloads an attachment
+//
+//	@Summary		Download attachment
+//	@Description	Download the content of a file attached to a card
+//	@Tags			Attachments
+//	@Produce		octet-stream
+//	@Security		BearerAuth
+//	@Param			id				path		int				true	"Card ID"
+//	@Param			attachmentId	path		int				true	"Attachment ID"
+//	@Success		200				{file}		file			"File content"
+//	@Failure		401				{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404				{object}	ErrorResponse	"Attachment not found"
+//	@Failure		500				{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/cards/{id}/attachments/{attachmentId} [get]
+func downloadAttachmentHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		attachmentID, _ := strconv.Atoi(c.Param("attachmentId"))
+		userID := int32(c.GetInt("userID"))
+
+		a, rc, err := svc.Open(c.Request.Context(), userID, int32(cardID), int32(attachmentID))
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		defer rc.Close()
+
+		c.DataFromReader(http.StatusOK, a.Size, a.ContentType, rc, map[string]string{
+			"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename}),
+			"X-Content-Type-Options": "nosniff",
+		})
+	}
+}
+
+// deleteAttachmentHandler deletes an attachment
+//
+//	@Summary		Delete attachment
+//	@Description	Remove a file from a card and from storage
+//	@Tags			Attachments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		int				true	"Card ID"
+//	@Param			attachmentId	path		int				true	"Attachment ID"
+//	@Success		200				{object}	MessageResponse	"Attachment deleted successfully"
+//	@Failure		401				{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404				{object}	ErrorResponse	"Attachment not found"
+//	@Failure		500				{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/cards/{id}/attachments/{attachmentId} [delete]
+func deleteAttachmentHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		attachmentID, _ := strconv.Atoi(c.Param("attachmentId"))
+		userID := int32(c.GetInt("userID"))
+
+		if err := svc.Delete(c.Request.Context(), userID, int32(cardID), int32(attachmentID)); err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "attachment deleted"})
+	}
+}
@@ -0,0 +1,24 @@
+package attachments
+
+import (
+	"context"
+
+	db "backend/internal/db/sqlc"
+)
+
+type Repository struct{ q *db.Queries }
+
+func NewRepository(q *db.Queries) *Repository { return &Repository{q: q} }
+
+func (r *Repository) Create(ctx context.Context, arg db.CreateAttachmentParams) (db.CardAttachment, error) {
+	return r.q.CreateAttachment(ctx, arg)
+}
+func (r *Repository) Get(ctx context.Context, id int32) (db.CardAttachment, error) {
+	return r.q.GetAttachmentByID(ctx, id)
+}
+func (r *Repository) ListByCard(ctx context.Context, cardID int32) ([]db.CardAttachment, error) {
+	return r.q.ListAttachmentsByCard(ctx, cardID)
+}
+func (r *Repository) Delete(ctx context.Context, id int32) error {
+	return r.q.DeleteAttachment(ctx, id)
+}
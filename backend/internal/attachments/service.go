@@ -0,0 +1,250 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/websocket"
+)
+
+type Service struct {
+	repo    *Repository
+	q       *db.Queries // for cross‑repo checks
+	hub     *websocket.Hub
+	storage Storage
+	maxSize int64
+}
+
+func NewService(repo *Repository, q *db.Queries, hub *websocket.Hub, storage Storage, maxSize int64) *Service {
+	return &Service{repo: repo, q: q, hub: hub, storage: storage, maxSize: maxSize}
+}
+
+var (
+	// ErrNotFound is returned both for missing attachments and for callers
+	// that are not members of the board, so IDs cannot be probed.
+	ErrNotFound   = errors.New("attachment not found")
+	ErrTooLarge   = errors.New("file is too large")
+	ErrEmptyFile  = errors.New("file is empty")
+	ErrNoFilename = errors.New("file name is required")
+)
+
+const (
+	maxFilenameLen = 255
+	// sniffLen is the number of bytes http.DetectContentType looks at.
+	sniffLen = 512
+)
+
+// Attachment is the attachment metadata exposed to clients; the storage key
+// stays internal.
+type Attachment struct {
+	ID          int32
+	CardID      int32
+	UploaderID  int32
+	Filename    string
+	ContentType string
+	Size        int64
+	CreatedAt   pgtype.Timestamp
+}
+
+func view(a db.CardAttachment) Attachment {
+	return Attachment{
+		ID:          a.ID,
+		CardID:      a.CardID,
+		UploaderID:  a.UploaderID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// MaxSize is the per-file upload limit in bytes.
+func (s *Service) MaxSize() int64 { return s.maxSize }
+
+// sanitizeFilename keeps only the base name of a client supplied file name
+// and strips control characters so it is safe to echo in headers.
+func sanitizeFilename(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = filepath.Base(name)
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == ".." || name == "/" {
+		return ""
+	}
+	if r := []rune(name); len(r) > maxFilenameLen {
+		name = string(r[:maxFilenameLen])
+	}
+	return name
+}
+
+// newKey returns a random storage key.
+func newKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// cardBoard returns the board of a card if userID is a member of it.
+// Missing cards and foreign boards are both reported as ErrNotFound.
+func (s *Service) cardBoard(ctx context.Context, userID, cardID int32) (int32, error) {
+	card, err := s.q.GetCardByID(ctx, cardID)
+	if err != nil {
+		return 0, ErrNotFound
+	}
+	lst, err := s.q.GetListByID(ctx, card.ListID)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: lst.BoardID, UserID: userID,
+	}); err != nil {
+		return 0, ErrNotFound
+	}
+	return lst.BoardID, nil
+}
+
+// attachment loads an attachment of cardID visible to userID.
+func (s *Service) attachment(ctx context.Context, userID, cardID, attachmentID int32) (db.CardAttachment, int32, error) {
+	boardID, err := s.cardBoard(ctx, userID, cardID)
+	if err != nil {
+		return db.CardAttachment{}, 0, err
+	}
+	a, err := s.repo.Get(ctx, attachmentID)
+	if err != nil || a.CardID != cardID {
+		return db.CardAttachment{}, 0, ErrNotFound
+	}
+	return a, boardID, nil
+}
+
+// Upload stores the content of r as a new attachment of the card. The
+// content type is sniffed from the data rather than trusted from the client.
+func (s *Service) Upload(ctx context.Context, userID, cardID int32, filename string, r io.Reader) (Attachment, error) {
+	boardID, err := s.cardBoard(ctx, userID, cardID)
+	if err != nil {
+		return Attachment{}, err
+	}
+	filename = sanitizeFilename(filename)
+	if filename == "" {
+		return Attachment{}, ErrNoFilename
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return Attachment{}, err
+	}
+	if n == 0 {
+		return Attachment{}, ErrEmptyFile
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+
+	key, err := newKey()
+	if err != nil {
+		return Attachment{}, err
+	}
+	// Read one byte past the limit to tell "exactly at the limit" from "over".
+	body := io.LimitReader(io.MultiReader(bytes.NewReader(head), r), s.maxSize+1)
+	size, err := s.storage.Put(ctx, key, body)
+	if err != nil {
+		logger.WithContext(ctx).Error("Failed to store attachment",
+			"user_id", userID,
+			"card_id", cardID,
+			"error", err,
+		)
+		return Attachment{}, err
+	}
+	if size > s.maxSize {
+		_ = s.storage.Delete(ctx, key)
+		return Attachment{}, ErrTooLarge
+	}
+
+	a, err := s.repo.Create(ctx, db.CreateAttachmentParams{
+		CardID:      cardID,
+		UploaderID:  userID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  key,
+	})
+	if err != nil {
+		_ = s.storage.Delete(ctx, key)
+		return Attachment{}, err
+	}
+	out := view(a)
+	s.hub.Broadcast(boardID, websocket.EventMessage{Event: "attachment_created", Data: out})
+	return out, nil
+}
+
+func (s *Service) ListByCard(ctx context.Context, userID, cardID int32) ([]Attachment, error) {
+	if _, err := s.cardBoard(ctx, userID, cardID); err != nil {
+		return nil, err
+	}
+	as, err := s.repo.ListByCard(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Attachment, len(as))
+	for i, a := range as {
+		out[i] = view(a)
+	}
+	return out, nil
+}
+
+// Open returns the attachment metadata and its content. The caller must
+// close the reader.
+func (s *Service) Open(ctx context.Context, userID, cardID, attachmentID int32) (Attachment, io.ReadCloser, error) {
+	a, _, err := s.attachment(ctx, userID, cardID, attachmentID)
+	if err != nil {
+		return Attachment{}, nil, err
+	}
+	rc, err := s.storage.Open(ctx, a.StorageKey)
+	if err != nil {
+		logger.WithContext(ctx).Error("Attachment content is missing",
+			"attachment_id", a.ID,
+			"error", err,
+		)
+		return Attachment{}, nil, err
+	}
+	return view(a), rc, nil
+}
+
+func (s *Service) Delete(ctx context.Context, userID, cardID, attachmentID int32) error {
+	a, boardID, err := s.attachment(ctx, userID, cardID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, a.ID); err != nil {
+		return err
+	}
+	if err := s.storage.Delete(ctx, a.StorageKey); err != nil {
+		// The row is gone, so the file is unreachable; just log the leftover.
+		logger.WithContext(ctx).Error("Failed to remove attachment content",
+			"attachment_id", a.ID,
+			"error", err,
+		)
+	}
+	s.hub.Broadcast(boardID, websocket.EventMessage{
+		Event: "attachment_deleted", Data: map[string]int32{"id": a.ID, "cardId": a.CardID},
+	})
+	return nil
+}
@@ -0,0 +1,86 @@
+package attachments
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Storage keeps the content of uploaded files. Keys are generated by the
+// service and never come from the client.
+type Storage interface {
+	// Put writes the content of r under key and returns the number of bytes
+	// written.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Open returns a reader for the content stored under key.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key. Deleting a missing key
+	// is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// LocalStorage stores files in a directory on the local filesystem.
+type LocalStorage struct {
+	dir string
+}
+
+// NewLocalStorage creates the directory if needed.
+func NewLocalStorage(dir string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &LocalStorage{dir: dir}, nil
+}
+
+// path resolves key inside the storage directory, rejecting anything that
+// could escape it.
+func (s *LocalStorage) path(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || strings.HasPrefix(key, ".") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.dir, key), nil
+}
+
+func (s *LocalStorage) Put(_ context.Context, key string, r io.Reader) (int64, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(p)
+		return 0, err
+	}
+	return n, nil
+}
+
+func (s *LocalStorage) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(p)
+}
+
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// internal/attachments/storage_test.go
+package attachments
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocalStorage(filepath.Join(t.TempDir(), "uploads"))
+	require.NoError(t, err)
+
+	n, err := s.Put(ctx, "abc123", strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+
+	rc, err := s.Open(ctx, "abc123")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, "hello", string(data))
+
+	_, err = s.Put(ctx, "abc123", strings.NewReader("again"))
+	assert.Error(t, err, "existing keys are never overwritten")
+
+	require.NoError(t, s.Delete(ctx, "abc123"))
+	_, err = s.Open(ctx, "abc123")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.NoError(t, s.Delete(ctx, "abc123"), "deleting twice is fine")
+}
+
+func TestLocalStorage_RejectsEscapingKeys(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../secret", "a/b", ".hidden", ".."} {
+		_, err := s.Put(ctx, key, strings.NewReader("x"))
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+		_, err = s.Open(ctx, key)
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\Users\me\shot.png`, "shot.png"},
+		{"evil\r\nname\".txt", "evilname.txt"},
+		{"  spaced.log ", "spaced.log"},
+		{"..", ""},
+		{"", ""},
+		{strings.Repeat("a", 300), strings.Repeat("a", maxFilenameLen)},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, sanitizeFilename(tc.in), tc.in)
+	}
+}
@@ -1,5 +1,7 @@
 package auth
 
+import "time"
+
 // RegisterRequest represents the request body for user registration
 type RegisterRequest struct {
 	Name     string `json:"name" binding:"required" example:"John Doe"`
@@ -19,17 +21,56 @@ type ChangePasswordRequest struct {
 	NewPassword     string `json:"newPassword" binding:"required,min=6" example:"newpassword123"`
 }
 
+// ForgotPasswordRequest represents the request body for requesting a password reset
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email" example:"john@example.com"`
+}
+
+// ResetPasswordRequest represents the request body for setting a new password with a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required" example:"Xc1Vd9k2..."`
+	NewPassword string `json:"newPassword" binding:"required,min=6" example:"newpassword123"`
+}
+
+// VerifyEmailRequest represents the request body for confirming an email address
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required" example:"Xc1Vd9k2..."`
+}
+
+// RefreshRequest represents the request body for refreshing tokens and logging out
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required" example:"q3Zb0Yl2..."`
+}
+
 // AuthResponse represents the response body for authentication endpoints
 type AuthResponse struct {
-	Token string     `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	User  UserPublic `json:"user"`
+	TokenResponse
+	User UserPublic `json:"user"`
+}
+
+// TokenResponse represents a freshly issued access and refresh token pair
+type TokenResponse struct {
+	Token        string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refreshToken" example:"q3Zb0Yl2..."`
+	ExpiresIn    int    `json:"expiresIn" example:"900"`
+}
+
+// SessionResponse represents a signed-in device of the user
+type SessionResponse struct {
+	ID         int32     `json:"id" example:"1"`
+	UserAgent  string    `json:"userAgent" example:"Mozilla/5.0 (X11; Linux x86_64)"`
+	IPAddress  string    `json:"ipAddress" example:"203.0.113.7"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	Current    bool      `json:"current" example:"true"`
 }
 
 // UserPublic represents public user information
 type UserPublic struct {
-	ID    int32  `json:"id" example:"1"`
-	Name  string `json:"name" example:"John Doe"`
-	Email string `json:"email" example:"john@example.com"`
+	ID            int32  `json:"id" example:"1"`
+	Name          string `json:"name" example:"John Doe"`
+	Email         string `json:"email" example:"john@example.com"`
+	EmailVerified bool   `json:"emailVerified" example:"true"`
 }
 
 // ErrorResponse represents an error response
@@ -0,0 +1,325 @@
+// internal/auth/fakedb_test.go
+package auth
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "backend/internal/db/sqlc"
+)
+
+// fakeDB keeps the tables the auth service uses in memory and answers its
+// queries by name, the way Postgres would. NOW() is a clock the test can
+// move forward.
+type fakeDB struct {
+	mu         sync.Mutex
+	now        time.Time
+	nextID     int32
+	users      map[int32]db.User
+	identities []db.UserIdentity
+	sessions   map[int32]db.Session
+	// tokens maps personal access token IDs to their user.
+	tokens map[int32]int32
+	// resets and verifications are the password reset and email
+	// verification links by token hash.
+	resets        map[string]emailLink
+	verifications map[string]emailLink
+}
+
+// emailLink is a row of a table of tokens mailed to users.
+type emailLink struct {
+	userID    int32
+	expiresAt time.Time
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{
+		now:           time.Now(),
+		users:         map[int32]db.User{},
+		sessions:      map[int32]db.Session{},
+		tokens:        map[int32]int32{},
+		resets:        map[string]emailLink{},
+		verifications: map[string]emailLink{},
+	}
+}
+
+// advance moves NOW() forward.
+func (f *fakeDB) advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func (f *fakeDB) ts(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t, Valid: true}
+}
+
+func (f *fakeDB) id() int32 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *fakeDB) addUser(email string, verified bool) db.User {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u := db.User{ID: f.id(), Name: "user", Email: email, PasswordHash: "old-hash"}
+	if verified {
+		u.EmailVerifiedAt = f.ts(f.now)
+	}
+	f.users[u.ID] = u
+	return u
+}
+
+func (f *fakeDB) addSession(userID int32) int32 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.id()
+	f.sessions[id] = db.Session{ID: id, UserID: userID, ExpiresAt: f.ts(f.now.Add(time.Hour))}
+	return id
+}
+
+func (f *fakeDB) addToken(userID int32) int32 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.id()
+	f.tokens[id] = userID
+	return id
+}
+
+func (f *fakeDB) user(id int32) db.User {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.users[id]
+}
+
+// sessionCount returns how many sessions the user has.
+func (f *fakeDB) sessionCount(userID int32) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, s := range f.sessions {
+		if s.UserID == userID {
+			n++
+		}
+	}
+	return n
+}
+
+// tokenCount returns how many personal access tokens the user has.
+func (f *fakeDB) tokenCount(userID int32) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, u := range f.tokens {
+		if u == userID {
+			n++
+		}
+	}
+	return n
+}
+
+func queryName(sql string) string {
+	name, _, _ := strings.Cut(strings.TrimPrefix(sql, "-- name: "), " ")
+	return name
+}
+
+func (f *fakeDB) Exec(_ context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	switch queryName(sql) {
+	case "MarkEmailVerified":
+		u := f.users[args[0].(int32)]
+		if !u.EmailVerifiedAt.Valid {
+			u.EmailVerifiedAt = f.ts(f.now)
+			f.users[u.ID] = u
+		}
+	case "DeleteOtherSessions":
+		n = f.deleteSessions(func(s db.Session) bool {
+			return s.UserID == args[0].(int32) && s.ID != args[1].(int32)
+		})
+	case "DeleteExpiredSessions":
+		n = f.deleteSessions(func(s db.Session) bool {
+			return s.UserID == args[0].(int32) && !s.ExpiresAt.Time.After(f.now)
+		})
+	case "DeleteSessionByPreviousToken":
+		grace := time.Duration(args[1].(int32)) * time.Second
+		n = f.deleteSessions(func(s db.Session) bool {
+			return s.PreviousTokenHash == args[0].(pgtype.Text) && s.LastSeenAt.Time.Before(f.now.Add(-grace))
+		})
+	case "DeletePersonalAccessTokensByUser":
+		for id, userID := range f.tokens {
+			if userID == args[0].(int32) {
+				delete(f.tokens, id)
+			}
+		}
+	case "CreatePasswordReset":
+		f.createLink(f.resets, args)
+	case "DeletePasswordResetsByUser":
+		f.deleteLinks(f.resets, args[0].(int32))
+	case "CreateEmailVerification":
+		f.createLink(f.verifications, args)
+	case "DeleteEmailVerificationsByUser":
+		f.deleteLinks(f.verifications, args[0].(int32))
+	default:
+		return pgconn.CommandTag{}, errors.New("unexpected exec " + queryName(sql))
+	}
+	return pgconn.NewCommandTag("DELETE " + strconv.Itoa(n)), nil
+}
+
+// deleteSessions deletes the sessions that match and returns how many.
+// Caller holds mu.
+func (f *fakeDB) deleteSessions(match func(db.Session) bool) int {
+	n := 0
+	for id, s := range f.sessions {
+		if match(s) {
+			delete(f.sessions, id)
+			n++
+		}
+	}
+	return n
+}
+
+// createLink inserts a mailed token from the arguments of its Create query:
+// token hash, user ID, TTL in seconds. Caller holds mu.
+func (f *fakeDB) createLink(links map[string]emailLink, args []interface{}) {
+	links[args[0].(string)] = emailLink{
+		userID:    args[1].(int32),
+		expiresAt: f.now.Add(time.Duration(args[2].(int32)) * time.Second),
+	}
+}
+
+// redeemLink deletes an unexpired mailed token and returns its user.
+// Caller holds mu.
+func (f *fakeDB) redeemLink(links map[string]emailLink, hash string) row {
+	l, ok := links[hash]
+	if !ok || !l.expiresAt.After(f.now) {
+		return row{err: pgx.ErrNoRows}
+	}
+	delete(links, hash)
+	return row{vals: []any{l.userID}}
+}
+
+// deleteLinks deletes the mailed tokens of a user. Caller holds mu.
+func (f *fakeDB) deleteLinks(links map[string]emailLink, userID int32) {
+	for hash, l := range links {
+		if l.userID == userID {
+			delete(links, hash)
+		}
+	}
+}
+
+func (f *fakeDB) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query " + queryName(sql))
+}
+
+func (f *fakeDB) QueryRow(_ context.Context, sql string, args ...interface{}) pgx.Row {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch queryName(sql) {
+	case "GetUserByID":
+		if u, ok := f.users[args[0].(int32)]; ok {
+			return userRow(u)
+		}
+	case "GetUserByEmail":
+		for _, u := range f.users {
+			if u.Email == args[0].(string) {
+				return userRow(u)
+			}
+		}
+	case "CreateUser":
+		u := db.User{ID: f.id(), Name: args[0].(string), Email: args[1].(string), PasswordHash: args[2].(string)}
+		f.users[u.ID] = u
+		return userRow(u)
+	case "UpdatePasswordHash":
+		u, ok := f.users[args[0].(int32)]
+		if !ok {
+			break
+		}
+		u.PasswordHash = args[1].(string)
+		f.users[u.ID] = u
+		return userRow(u)
+	case "GetUserIdentity":
+		for _, i := range f.identities {
+			if i.Issuer == args[0].(string) && i.Subject == args[1].(string) {
+				return identityRow(i)
+			}
+		}
+	case "CreateUserIdentity":
+		i := db.UserIdentity{
+			ID: f.id(), UserID: args[0].(int32), Issuer: args[1].(string),
+			Subject: args[2].(string), Email: args[3].(string),
+		}
+		f.identities = append(f.identities, i)
+		return identityRow(i)
+	case "RedeemPasswordReset":
+		return f.redeemLink(f.resets, args[0].(string))
+	case "RedeemEmailVerification":
+		return f.redeemLink(f.verifications, args[0].(string))
+	case "CreateSession":
+		s := db.Session{
+			ID: f.id(), UserID: args[0].(int32), RefreshTokenHash: args[1].(string),
+			UserAgent: args[2].(string), IpAddress: args[3].(string),
+			CreatedAt: f.ts(f.now), LastSeenAt: f.ts(f.now),
+			ExpiresAt: f.ts(f.now.Add(time.Duration(args[4].(int32)) * time.Second)),
+		}
+		f.sessions[s.ID] = s
+		return sessionRow(s)
+	case "RotateSession":
+		for _, s := range f.sessions {
+			if s.RefreshTokenHash != args[0].(string) || !s.ExpiresAt.Time.After(f.now) {
+				continue
+			}
+			s.PreviousTokenHash = pgtype.Text{String: s.RefreshTokenHash, Valid: true}
+			s.RefreshTokenHash = args[1].(string)
+			s.UserAgent, s.IpAddress = args[2].(string), args[3].(string)
+			s.LastSeenAt = f.ts(f.now)
+			s.ExpiresAt = f.ts(f.now.Add(time.Duration(args[4].(int32)) * time.Second))
+			f.sessions[s.ID] = s
+			return sessionRow(s)
+		}
+	default:
+		return row{err: errors.New("unexpected query " + queryName(sql))}
+	}
+	return row{err: pgx.ErrNoRows}
+}
+
+// row scans its values into the destinations in order.
+type row struct {
+	vals []any
+	err  error
+}
+
+func (r row) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(r.vals[i]))
+	}
+	return nil
+}
+
+func userRow(u db.User) row {
+	return row{vals: []any{u.ID, u.Name, u.Email, u.PasswordHash, u.CreatedAt, u.EmailVerifiedAt}}
+}
+
+func identityRow(i db.UserIdentity) row {
+	return row{vals: []any{i.ID, i.UserID, i.Issuer, i.Subject, i.Email, i.CreatedAt}}
+}
+
+func sessionRow(s db.Session) row {
+	return row{vals: []any{
+		s.ID, s.UserID, s.RefreshTokenHash, s.PreviousTokenHash, s.UserAgent, s.IpAddress,
+		s.CreatedAt, s.LastSeenAt, s.ExpiresAt,
+	}}
+}
@@ -1,7 +1,12 @@
 package auth
 
 import (
+	"crypto/subtle"
+	"errors"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"backend/internal/logger"
 	"backend/internal/middleware"
@@ -13,8 +18,34 @@ func RegisterRoutes(r *gin.Engine, svc *Service, jwtSecret string) {
 	g := r.Group("/auth")
 	g.POST("/register", registerHandler(svc))
 	g.POST("/login", loginHandler(svc))
-	g.GET("/me", middleware.Auth(jwtSecret), meHandler(svc))
-	g.POST("/change-password", middleware.Auth(jwtSecret), changePasswordHandler(svc))
+	g.GET("/me", middleware.Auth(jwtSecret, nil), meHandler(svc))
+	g.POST("/change-password", middleware.Auth(jwtSecret, nil), changePasswordHandler(svc))
+	g.POST("/refresh", refreshHandler(svc))
+	g.POST("/logout", logoutHandler(svc))
+	g.POST("/forgot-password", forgotPasswordHandler(svc))
+	g.POST("/reset-password", resetPasswordHandler(svc))
+	g.POST("/verify-email", verifyEmailHandler(svc))
+	g.POST("/resend-verification", middleware.Auth(jwtSecret, nil), resendVerificationHandler(svc))
+	g.POST("/logout-all", middleware.Auth(jwtSecret, nil), logoutAllHandler(svc))
+	g.GET("/sessions", middleware.Auth(jwtSecret, nil), listSessionsHandler(svc))
+	g.DELETE("/sessions/:id", middleware.Auth(jwtSecret, nil), revokeSessionHandler(svc))
+
+	if svc.oidc != nil {
+		g.GET("/oidc/login", oidcLoginHandler(svc))
+		g.GET("/oidc/callback", oidcCallbackHandler(svc))
+	}
+}
+
+// maxUserAgent bounds the user agent stored with a session.
+const maxUserAgent = 512
+
+// clientOf describes the device a request comes from.
+func clientOf(c *gin.Context) Client {
+	ua := []rune(c.Request.UserAgent())
+	if len(ua) > maxUserAgent {
+		ua = ua[:maxUserAgent]
+	}
+	return Client{UserAgent: string(ua), IP: c.ClientIP()}
 }
 
 // registerHandler handles user registration
@@ -45,7 +76,7 @@ func registerHandler(svc *Service) gin.HandlerFunc {
 			"email", req.Email,
 			"remote_addr", c.ClientIP(),
 		)
-		token, user, err := svc.Register(c.Request.Context(), req.Name, req.Email, req.Password)
+		tokens, user, err := svc.Register(c.Request.Context(), req.Name, req.Email, req.Password, clientOf(c))
 		if err != nil {
 			logger.WithContext(c.Request.Context()).Warn("Registration failed",
 				"email", req.Email,
@@ -61,7 +92,7 @@ func registerHandler(svc *Service) gin.HandlerFunc {
 			"email", user.Email,
 			"remote_addr", c.ClientIP(),
 		)
-		c.JSON(http.StatusOK, AuthResponse{Token: token, User: user})
+		c.JSON(http.StatusOK, AuthResponse{TokenResponse: tokens.response(), User: user})
 	}
 }
 
@@ -93,7 +124,7 @@ func loginHandler(svc *Service) gin.HandlerFunc {
 			"email", req.Email,
 			"remote_addr", c.ClientIP(),
 		)
-		token, user, err := svc.Login(c.Request.Context(), req.Email, req.Password)
+		tokens, user, err := svc.Login(c.Request.Context(), req.Email, req.Password, clientOf(c))
 		if err != nil {
 			logger.WithContext(c.Request.Context()).Warn("Login failed",
 				"email", req.Email,
@@ -109,7 +140,7 @@ func loginHandler(svc *Service) gin.HandlerFunc {
 			"email", user.Email,
 			"remote_addr", c.ClientIP(),
 		)
-		c.JSON(http.StatusOK, AuthResponse{Token: token, User: user})
+		c.JSON(http.StatusOK, AuthResponse{TokenResponse: tokens.response(), User: user})
 	}
 }
 
@@ -146,7 +177,7 @@ func meHandler(svc *Service) gin.HandlerFunc {
 // changePasswordHandler handles password change
 //
 //	@Summary		Change password
-//	@Description	Change the password for the currently authenticated user
+//	@Description	Change the password for the currently authenticated user. All other sessions of the user are signed out.
 //	@Tags			Authentication
 //	@Accept			json
 //	@Produce		json
@@ -175,7 +206,8 @@ func changePasswordHandler(svc *Service) gin.HandlerFunc {
 			"remote_addr", c.ClientIP(),
 		)
 
-		err := svc.ChangePassword(c.Request.Context(), userID, req.CurrentPassword, req.NewPassword)
+		sessionID := int32(c.GetInt("sessionID"))
+		err := svc.ChangePassword(c.Request.Context(), userID, sessionID, req.CurrentPassword, req.NewPassword)
 		if err != nil {
 			status := http.StatusInternalServerError
 			if err == ErrInvalidPassword {
@@ -197,3 +229,434 @@ func changePasswordHandler(svc *Service) gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
 	}
 }
+
+// refreshHandler exchanges a refresh token for a new token pair
+//
+//	@Summary		Refresh tokens
+//	@Description	Exchange a refresh token for a new access token and a new refresh token. Each refresh token works once; reusing an already exchanged one signs the session out.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		RefreshRequest	true	"Refresh token"
+//	@Success		200		{object}	TokenResponse	"New tokens"
+//	@Failure		400		{object}	ErrorResponse	"Invalid request format"
+//	@Failure		401		{object}	ErrorResponse	"Invalid or expired refresh token"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/auth/refresh [post]
+func refreshHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req RefreshRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.WithContext(c.Request.Context()).Warn("Token refresh failed: invalid request format",
+				"error", err.Error(),
+				"remote_addr", c.ClientIP(),
+			)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		tokens, err := svc.Refresh(c.Request.Context(), req.RefreshToken, clientOf(c))
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrInvalidRefreshToken) {
+				status = http.StatusUnauthorized
+			}
+			logger.WithContext(c.Request.Context()).Warn("Token refresh failed",
+				"error", err.Error(),
+				"remote_addr", c.ClientIP(),
+			)
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, tokens.response())
+	}
+}
+
+// logoutHandler ends the session of a refresh token
+//
+//	@Summary		Logout
+//	@Description	End the session the refresh token belongs to. Unknown tokens are ignored. The access token stays valid until it expires, so clients should discard it.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		RefreshRequest	true	"Refresh token"
+//	@Success		200		{object}	MessageResponse	"Logged out"
+//	@Failure		400		{object}	ErrorResponse	"Invalid request format"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/auth/logout [post]
+func logoutHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req RefreshRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.WithContext(c.Request.Context()).Warn("Logout failed: invalid request format",
+				"error", err.Error(),
+				"remote_addr", c.ClientIP(),
+			)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := svc.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+			logger.WithContext(c.Request.Context()).Error("Logout failed",
+				"error", err.Error(),
+				"remote_addr", c.ClientIP(),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to logout"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+	}
+}
+
+// logoutAllHandler ends every session of the current user
+//
+//	@Summary		Logout everywhere
+//	@Description	End all sessions of the current user, including this one
+//	@Tags			Authentication
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	MessageResponse	"Logged out everywhere"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/auth/logout-all [post]
+func logoutAllHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := int32(c.GetInt("userID"))
+		if err := svc.LogoutAll(c.Request.Context(), userID); err != nil {
+			logger.WithContext(c.Request.Context()).Error("Logout everywhere failed",
+				"user_id", userID,
+				"error", err.Error(),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to logout"})
+			return
+		}
+		logger.WithContext(c.Request.Context()).Info("Logged out everywhere",
+			"user_id", userID,
+			"remote_addr", c.ClientIP(),
+		)
+		c.JSON(http.StatusOK, gin.H{"message": "Logged out everywhere"})
+	}
+}
+
+// listSessionsHandler lists the active sessions of the current user
+//
+//	@Summary		List sessions
+//	@Description	List the devices the current user is signed in on, most recently used first
+//	@Tags			Authentication
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		SessionResponse	"Active sessions"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/auth/sessions [get]
+func listSessionsHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := int32(c.GetInt("userID"))
+		sessions, err := svc.ListSessions(c.Request.Context(), userID, int32(c.GetInt("sessionID")))
+		if err != nil {
+			logger.WithContext(c.Request.Context()).Error("Failed to list sessions",
+				"user_id", userID,
+				"error", err.Error(),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list sessions"})
+			return
+		}
+		c.JSON(http.StatusOK, sessions)
+	}
+}
+
+// revokeSessionHandler ends one session of the current user
+//
+//	@Summary		Revoke session
+//	@Description	Sign the current user out on one device. Its access token stays valid until it expires.
+//	@Tags			Authentication
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int				true	"Session ID"
+//	@Success		204	"Session revoked"
+//	@Failure		400	{object}	ErrorResponse	"Invalid session ID"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404	{object}	ErrorResponse	"Session not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/auth/sessions/{id} [delete]
+func revokeSessionHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := int32(c.GetInt("userID"))
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+			return
+		}
+
+		if err := svc.RevokeSession(c.Request.Context(), userID, int32(id)); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrSessionNotFound) {
+				status = http.StatusNotFound
+			}
+			logger.WithContext(c.Request.Context()).Warn("Failed to revoke session",
+				"user_id", userID,
+				"session_id", id,
+				"error", err.Error(),
+			)
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		logger.WithContext(c.Request.Context()).Info("Session revoked",
+			"user_id", userID,
+			"session_id", id,
+		)
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// forgotPasswordHandler emails a password reset link
+//
+//	@Summary		Forgot password
+//	@Description	Email a single-use password reset link, valid for an hour, to the account with this email. The response is the same whether or not the account exists.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		ForgotPasswordRequest	true	"Account email"
+//	@Success		200		{object}	MessageResponse			"Reset link sent if the account exists"
+//	@Failure		400		{object}	ErrorResponse			"Invalid request format"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/auth/forgot-password [post]
+func forgotPasswordHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ForgotPasswordRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.WithContext(c.Request.Context()).Warn("Password reset request failed: invalid request format",
+				"error", err.Error(),
+				"remote_addr", c.ClientIP(),
+			)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		logger.WithContext(c.Request.Context()).Info("Password reset requested",
+			"email", req.Email,
+			"remote_addr", c.ClientIP(),
+		)
+		if err := svc.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+			logger.WithContext(c.Request.Context()).Error("Password reset request failed",
+				"email", req.Email,
+				"error", err.Error(),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to request password reset"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "If the account exists, a reset link has been sent"})
+	}
+}
+
+// resetPasswordHandler sets a new password with a reset token
+//
+//	@Summary		Reset password
+//	@Description	Set a new password with the token from a reset email. The token works once; all sessions of the user are signed out.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		ResetPasswordRequest	true	"Reset token and new password"
+//	@Success		200		{object}	MessageResponse			"Password reset"
+//	@Failure		400		{object}	ErrorResponse			"Invalid request or invalid or expired token"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/auth/reset-password [post]
+func resetPasswordHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ResetPasswordRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.WithContext(c.Request.Context()).Warn("Password reset failed: invalid request format",
+				"error", err.Error(),
+				"remote_addr", c.ClientIP(),
+			)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := svc.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrInvalidResetToken) {
+				status = http.StatusBadRequest
+			}
+			logger.WithContext(c.Request.Context()).Warn("Password reset failed",
+				"error", err.Error(),
+				"remote_addr", c.ClientIP(),
+			)
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		logger.WithContext(c.Request.Context()).Info("Password reset successful",
+			"remote_addr", c.ClientIP(),
+		)
+		c.JSON(http.StatusOK, gin.H{"message": "Password has been reset"})
+	}
+}
+
+// verifyEmailHandler confirms an email address
+//
+//	@Summary		Verify email
+//	@Description	Confirm the email address of an account with the token from a verification email. The token works once.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		VerifyEmailRequest	true	"Verification token"
+//	@Success		200		{object}	MessageResponse		"Email verified"
+//	@Failure		400		{object}	ErrorResponse		"Invalid request or invalid or expired token"
+//	@Failure		500		{object}	ErrorResponse		"Internal server error"
+//	@Router			/auth/verify-email [post]
+func verifyEmailHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req VerifyEmailRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.WithContext(c.Request.Context()).Warn("Email verification failed: invalid request format",
+				"error", err.Error(),
+				"remote_addr", c.ClientIP(),
+			)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := svc.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrInvalidVerifyToken) {
+				status = http.StatusBadRequest
+			}
+			logger.WithContext(c.Request.Context()).Warn("Email verification failed",
+				"error", err.Error(),
+				"remote_addr", c.ClientIP(),
+			)
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Email verified"})
+	}
+}
+
+// resendVerificationHandler emails a new verification link
+//
+//	@Summary		Resend verification email
+//	@Description	Email the current user a new verification link. Earlier links stop working.
+//	@Tags			Authentication
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	MessageResponse	"Verification email sent"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		409	{object}	ErrorResponse	"Email already verified"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/auth/resend-verification [post]
+func resendVerificationHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := int32(c.GetInt("userID"))
+		if err := svc.ResendVerification(c.Request.Context(), userID); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrAlreadyVerified) {
+				status = http.StatusConflict
+			}
+			logger.WithContext(c.Request.Context()).Warn("Failed to resend verification email",
+				"user_id", userID,
+				"error", err.Error(),
+			)
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Verification email sent"})
+	}
+}
+
+// oidcStateCookie ties an OIDC callback to the browser that started the
+// sign-in, so nobody can sign a victim into their own account by sending a
+// callback link.
+const oidcStateCookie = "oidc_state"
+
+// oidcLoginHandler starts a sign-in through the OIDC provider
+//
+//	@Summary		Sign in with SSO
+//	@Description	Redirect the browser to the OpenID Connect provider to sign in. Available when OIDC is configured.
+//	@Tags			Authentication
+//	@Success		302	"Redirect to the provider"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/auth/oidc/login [get]
+func oidcLoginHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authURL, state, err := svc.StartOIDCLogin(c.Request.Context())
+		if err != nil {
+			logger.WithContext(c.Request.Context()).Error("Failed to start OIDC login",
+				"error", err.Error(),
+				"remote_addr", c.ClientIP(),
+			)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start sign-in"})
+			return
+		}
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(oidcStateCookie, state, int(oidcLoginTTL.Seconds()), "/auth/oidc", "", secureRequest(c), true)
+		c.Redirect(http.StatusFound, authURL)
+	}
+}
+
+// oidcCallbackHandler finishes a sign-in through the OIDC provider
+//
+//	@Summary		SSO callback
+//	@Description	Redirect target of the OpenID Connect provider. Signs the user in, linking the identity to the account with the same verified email or creating one, and redirects to the web app at APP_URL/auth/callback with `token`, `refreshToken` and `expiresIn`, or `error`, in the URL fragment.
+//	@Tags			Authentication
+//	@Param			code	query	string	false	"Authorization code"
+//	@Param			state	query	string	true	"Sign-in state"
+//	@Param			error	query	string	false	"Error reported by the provider"
+//	@Success		302		"Redirect to the web app"
+//	@Router			/auth/oidc/callback [get]
+func oidcCallbackHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fail := func(code string, err error) {
+			logger.WithContext(c.Request.Context()).Warn("OIDC login failed",
+				"reason", code,
+				"error", err,
+				"remote_addr", c.ClientIP(),
+			)
+			c.Redirect(http.StatusFound, svc.oidcCompleteURL(url.Values{"error": {code}}))
+		}
+
+		cookie, _ := c.Cookie(oidcStateCookie)
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(oidcStateCookie, "", -1, "/auth/oidc", "", secureRequest(c), true)
+
+		if e := c.Query("error"); e != "" {
+			fail(e, errors.New(c.Query("error_description")))
+			return
+		}
+		state := c.Query("state")
+		if state == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(state)) != 1 {
+			fail("invalid_state", ErrInvalidOIDCState)
+			return
+		}
+
+		tokens, user, err := svc.FinishOIDCLogin(c.Request.Context(), state, c.Query("code"), clientOf(c))
+		if err != nil {
+			code := "login_failed"
+			switch {
+			case errors.Is(err, ErrInvalidOIDCState):
+				code = "invalid_state"
+			case errors.Is(err, ErrOIDCEmailNotVerified):
+				code = "email_not_verified"
+			}
+			fail(code, err)
+			return
+		}
+
+		logger.WithContext(c.Request.Context()).Info("OIDC login successful",
+			"user_id", user.ID,
+			"email", user.Email,
+			"remote_addr", c.ClientIP(),
+		)
+		c.Redirect(http.StatusFound, svc.oidcCompleteURL(url.Values{
+			"token":        {tokens.AccessToken},
+			"refreshToken": {tokens.RefreshToken},
+			"expiresIn":    {strconv.Itoa(tokens.ExpiresIn)},
+		}))
+	}
+}
+
+// secureRequest reports whether the request reached us, or the proxy in
+// front of us, over HTTPS.
+func secureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https")
+}
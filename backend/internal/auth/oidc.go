@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/oidc"
+)
+
+// oidcLoginTTL is how long a user has to sign in at the provider.
+const oidcLoginTTL = 10 * time.Minute
+
+// UseOIDC enables single sign-on through an OpenID Connect provider. Call it
+// before RegisterRoutes.
+func (s *Service) UseOIDC(p *oidc.Provider) {
+	s.oidc = p
+}
+
+// StartOIDCLogin begins a sign-in at the provider. It returns the URL to
+// send the browser to and the state the callback must come back with.
+func (s *Service) StartOIDCLogin(ctx context.Context) (authURL, state string, err error) {
+	if err := s.queries.DeleteExpiredOidcLogins(ctx); err != nil {
+		logger.WithContext(ctx).Warn("Failed to delete expired OIDC logins", "error", err)
+	}
+	var nonce, verifier string
+	for _, v := range []*string{&state, &nonce, &verifier} {
+		if *v, err = newToken(); err != nil {
+			return "", "", err
+		}
+	}
+	if err := s.queries.CreateOidcLogin(ctx, db.CreateOidcLoginParams{
+		StateHash:    hashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		TtlSeconds:   int32(oidcLoginTTL / time.Second),
+	}); err != nil {
+		return "", "", err
+	}
+	return s.oidc.AuthCodeURL(state, nonce, verifier), state, nil
+}
+
+// FinishOIDCLogin completes a sign-in with the code the provider returned,
+// and starts a session for the user the ID token names.
+func (s *Service) FinishOIDCLogin(ctx context.Context, state, code string, client Client) (Tokens, UserPublic, error) {
+	login, err := s.queries.RedeemOidcLogin(ctx, hashToken(state))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Tokens{}, UserPublic{}, ErrInvalidOIDCState
+	}
+	if err != nil {
+		return Tokens{}, UserPublic{}, err
+	}
+
+	raw, err := s.oidc.Exchange(ctx, code, login.CodeVerifier)
+	if err != nil {
+		return Tokens{}, UserPublic{}, err
+	}
+	id, err := s.oidc.Verify(ctx, raw, login.Nonce)
+	if err != nil {
+		return Tokens{}, UserPublic{}, err
+	}
+	u, err := s.oidcUser(ctx, id)
+	if err != nil {
+		return Tokens{}, UserPublic{}, err
+	}
+
+	tokens, err := s.startSession(ctx, u.ID, client)
+	if err != nil {
+		return Tokens{}, UserPublic{}, errors.New("failed to generate token")
+	}
+	return tokens, publicUser(u), nil
+}
+
+// oidcUser returns the user linked to an external identity. An identity
+// seen for the first time is linked to the user with the same email, or to
+// a new user, but only when the provider has verified the email. An
+// existing account whose email was never verified is taken over first.
+func (s *Service) oidcUser(ctx context.Context, id oidc.Identity) (db.User, error) {
+	link, err := s.queries.GetUserIdentity(ctx, db.GetUserIdentityParams{Issuer: id.Issuer, Subject: id.Subject})
+	if err == nil {
+		return s.queries.GetUserByID(ctx, link.UserID)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return db.User{}, err
+	}
+
+	if id.Email == "" || !id.EmailVerified {
+		return db.User{}, ErrOIDCEmailNotVerified
+	}
+	u, err := s.queries.GetUserByEmail(ctx, id.Email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		u, err = s.createOIDCUser(ctx, id)
+	}
+	if err != nil {
+		return db.User{}, err
+	}
+	if !u.EmailVerifiedAt.Valid {
+		if err := s.takeOverAccount(ctx, u.ID); err != nil {
+			return db.User{}, err
+		}
+	}
+
+	if _, err := s.queries.CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+		UserID: u.ID, Issuer: id.Issuer, Subject: id.Subject, Email: id.Email,
+	}); err != nil {
+		return db.User{}, err
+	}
+	// The provider vouched for the address
+	if err := s.queries.MarkEmailVerified(ctx, u.ID); err != nil {
+		return db.User{}, err
+	}
+	logger.WithContext(ctx).Info("OIDC identity linked",
+		"user_id", u.ID,
+		"issuer", id.Issuer,
+	)
+	return s.queries.GetUserByID(ctx, u.ID)
+}
+
+// takeOverAccount hands an account whose email was never verified over to
+// the owner of the address the provider vouched for. Whoever registered it
+// may not own the address, so their password, sessions and access tokens
+// stop working before the identity is linked.
+func (s *Service) takeOverAccount(ctx context.Context, userID int32) error {
+	hash, err := randomPasswordHash()
+	if err != nil {
+		return err
+	}
+	if _, err := s.queries.UpdatePasswordHash(ctx, db.UpdatePasswordHashParams{
+		ID:           userID,
+		PasswordHash: hash,
+	}); err != nil {
+		return err
+	}
+	if err := s.revokeSessions(ctx, userID, 0); err != nil {
+		return err
+	}
+	if err := s.queries.DeletePersonalAccessTokensByUser(ctx, userID); err != nil {
+		return err
+	}
+	logger.WithContext(ctx).Warn("Unverified account taken over by OIDC identity", "user_id", userID)
+	return nil
+}
+
+// createOIDCUser registers a user who signs in through the provider. The
+// account gets a random password; a password of its own can be set through
+// the password reset.
+func (s *Service) createOIDCUser(ctx context.Context, id oidc.Identity) (db.User, error) {
+	hash, err := randomPasswordHash()
+	if err != nil {
+		return db.User{}, err
+	}
+	name := id.Name
+	if name == "" {
+		name, _, _ = strings.Cut(id.Email, "@")
+	}
+	return s.queries.CreateUser(ctx, db.CreateUserParams{Name: name, Email: id.Email, PasswordHash: hash})
+}
+
+// randomPasswordHash hashes a password nobody knows.
+func randomPasswordHash() (string, error) {
+	password, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// oidcCompleteURL is the page of the web app that finishes an OIDC sign-in.
+// The result travels in the fragment, which browsers send neither to
+// servers nor in the Referer header.
+func (s *Service) oidcCompleteURL(result url.Values) string {
+	return s.appURL + "/auth/callback#" + result.Encode()
+}
@@ -0,0 +1,80 @@
+// internal/auth/oidc_test.go
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/oidc"
+)
+
+func TestOIDCUser_LinksByEmail(t *testing.T) {
+	identity := oidc.Identity{Issuer: "https://idp.example.com", Subject: "42", Email: "ivan@example.com", EmailVerified: true}
+
+	tests := []struct {
+		name     string
+		verified bool
+		// takenOver is true when the existing password, sessions and
+		// access tokens must stop working.
+		takenOver bool
+	}{
+		{"VerifiedAccount", true, false},
+		{"UnverifiedAccount", false, true},
+	}
+
+	for _, tc := range tests {
+		fake := newFakeDB()
+		u := fake.addUser(identity.Email, tc.verified)
+		fake.addSession(u.ID)
+		fake.addToken(u.ID)
+		svc := NewService(db.New(fake), "secret", 0, 0, nil, "")
+
+		got, err := svc.oidcUser(context.Background(), identity)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, u.ID, got.ID, tc.name)
+		assert.True(t, got.EmailVerifiedAt.Valid, tc.name)
+
+		assert.Equal(t, tc.takenOver, fake.user(u.ID).PasswordHash != "old-hash", tc.name)
+		want := 1
+		if tc.takenOver {
+			want = 0
+		}
+		assert.Equal(t, want, fake.sessionCount(u.ID), tc.name)
+		assert.Equal(t, want, fake.tokenCount(u.ID), tc.name)
+
+		// The identity is linked: the next sign-in finds the user by it
+		again, err := svc.oidcUser(context.Background(), identity)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, u.ID, again.ID, tc.name)
+	}
+}
+
+func TestOIDCUser_RequiresVerifiedEmail(t *testing.T) {
+	fake := newFakeDB()
+	fake.addUser("ivan@example.com", false)
+	svc := NewService(db.New(fake), "secret", 0, 0, nil, "")
+
+	_, err := svc.oidcUser(context.Background(), oidc.Identity{
+		Issuer: "https://idp.example.com", Subject: "42", Email: "ivan@example.com",
+	})
+	assert.ErrorIs(t, err, ErrOIDCEmailNotVerified)
+	assert.Empty(t, fake.identities)
+}
+
+func TestOIDCUser_CreatesUser(t *testing.T) {
+	fake := newFakeDB()
+	svc := NewService(db.New(fake), "secret", 0, 0, nil, "")
+
+	u, err := svc.oidcUser(context.Background(), oidc.Identity{
+		Issuer: "https://idp.example.com", Subject: "42", Email: "ivan@example.com", EmailVerified: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ivan", u.Name)
+	assert.True(t, u.EmailVerifiedAt.Valid)
+	require.Len(t, fake.identities, 1)
+	assert.Equal(t, u.ID, fake.identities[0].UserID)
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/mail"
+)
+
+// resetTTL is how long a password reset link works.
+const resetTTL = time.Hour
+
+// mailTimeout bounds the delivery of a single email.
+const mailTimeout = 30 * time.Second
+
+// RequestPasswordReset emails a reset link to the user with the given
+// email. Unknown emails are ignored, and the mail is sent in the
+// background, so callers cannot tell whether an account exists.
+func (s *Service) RequestPasswordReset(ctx context.Context, email string) error {
+	u, err := s.queries.GetUserByEmail(ctx, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+	// Only the latest link works
+	if err := s.queries.DeletePasswordResetsByUser(ctx, u.ID); err != nil {
+		return err
+	}
+	if err := s.queries.CreatePasswordReset(ctx, db.CreatePasswordResetParams{
+		TokenHash:  hashToken(token),
+		UserID:     u.ID,
+		TtlSeconds: int32(resetTTL / time.Second),
+	}); err != nil {
+		return err
+	}
+
+	link := s.appURL + "/reset-password?token=" + url.QueryEscape(token)
+	s.sendMail(ctx, mail.Message{
+		To:      u.Email,
+		Subject: "Reset your CollabBoard password",
+		Body: "Hi " + u.Name + ",\n\n" +
+			"Someone asked to reset the password of your CollabBoard account. " +
+			"To choose a new password, open this link within an hour:\n\n" +
+			link + "\n\n" +
+			"If it was not you, ignore this email; your password stays the same.\n",
+	})
+	return nil
+}
+
+// ResetPassword sets a new password with a token from a reset email. The
+// token works once, and every session of the user is signed out.
+func (s *Service) ResetPassword(ctx context.Context, token, newPassword string) error {
+	userID, err := s.queries.RedeemPasswordReset(ctx, hashToken(token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if _, err := s.queries.UpdatePasswordHash(ctx, db.UpdatePasswordHashParams{
+		ID:           userID,
+		PasswordHash: string(hash),
+	}); err != nil {
+		return err
+	}
+	if err := s.queries.DeletePasswordResetsByUser(ctx, userID); err != nil {
+		return err
+	}
+	// Following the link proved the user owns the address
+	if err := s.queries.MarkEmailVerified(ctx, userID); err != nil {
+		return err
+	}
+	return s.revokeSessions(ctx, userID, 0)
+}
+
+// sendMail delivers m in the background. Failures are logged, not returned.
+func (s *Service) sendMail(ctx context.Context, m mail.Message) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), mailTimeout)
+	go func() {
+		defer cancel()
+		if err := s.mailer.Send(ctx, m); err != nil {
+			logger.WithContext(ctx).Error("Failed to send mail",
+				"to", m.To,
+				"subject", m.Subject,
+				"error", err,
+			)
+		}
+	}()
+}
@@ -0,0 +1,120 @@
+// internal/auth/reset_test.go
+package auth
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/mail"
+)
+
+// newMailService returns a service that writes its mail to an outbox
+// directory, and that directory.
+func newMailService(t *testing.T) (*Service, *fakeDB, string) {
+	dir := t.TempDir()
+	outbox, err := mail.NewOutbox(dir, "no-reply@example.com")
+	require.NoError(t, err)
+	fake := newFakeDB()
+	return NewService(db.New(fake), "secret", time.Minute, time.Hour, outbox, "http://app.example.com"), fake, dir
+}
+
+var mailedToken = regexp.MustCompile(`\?token=(\S+)`)
+
+// waitForToken waits until the outbox holds n messages, mail being sent in
+// the background, and returns the token of the link in the newest.
+func waitForToken(t *testing.T, dir string, n int) string {
+	t.Helper()
+	var names []string
+	require.Eventually(t, func() bool {
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			return false
+		}
+		names = names[:0]
+		for _, f := range files {
+			names = append(names, f.Name())
+		}
+		return len(names) == n
+	}, time.Second, 10*time.Millisecond)
+
+	// Names start with the time they were written
+	sort.Strings(names)
+	data, err := os.ReadFile(filepath.Join(dir, names[n-1]))
+	require.NoError(t, err)
+	m := mailedToken.FindSubmatch(data)
+	require.NotNil(t, m, "no link in %s", data)
+	token, err := url.QueryUnescape(string(m[1]))
+	require.NoError(t, err)
+	return token
+}
+
+func TestResetPassword(t *testing.T) {
+	svc, fake, dir := newMailService(t)
+	ctx := context.Background()
+	u := fake.addUser("ivan@example.com", false)
+	fake.addSession(u.ID)
+	var revoked []int32
+	svc.OnSessionsRevoked(func(userID int32) { revoked = append(revoked, userID) })
+
+	require.NoError(t, svc.RequestPasswordReset(ctx, u.Email))
+	token := waitForToken(t, dir, 1)
+
+	require.NoError(t, svc.ResetPassword(ctx, token, "new-password"))
+	got := fake.user(u.ID)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(got.PasswordHash), []byte("new-password")))
+	assert.True(t, got.EmailVerifiedAt.Valid, "following the link verifies the email")
+	assert.Equal(t, 0, fake.sessionCount(u.ID), "sessions are signed out")
+	assert.Equal(t, []int32{u.ID}, revoked)
+
+	// The link works once
+	assert.ErrorIs(t, svc.ResetPassword(ctx, token, "other-password"), ErrInvalidResetToken)
+}
+
+func TestResetPassword_Expired(t *testing.T) {
+	svc, fake, dir := newMailService(t)
+	ctx := context.Background()
+	u := fake.addUser("ivan@example.com", true)
+
+	require.NoError(t, svc.RequestPasswordReset(ctx, u.Email))
+	token := waitForToken(t, dir, 1)
+
+	fake.advance(resetTTL)
+	assert.ErrorIs(t, svc.ResetPassword(ctx, token, "new-password"), ErrInvalidResetToken)
+	assert.Equal(t, "old-hash", fake.user(u.ID).PasswordHash)
+}
+
+func TestResetPassword_OnlyLatestLink(t *testing.T) {
+	svc, fake, dir := newMailService(t)
+	ctx := context.Background()
+	u := fake.addUser("ivan@example.com", true)
+
+	require.NoError(t, svc.RequestPasswordReset(ctx, u.Email))
+	first := waitForToken(t, dir, 1)
+	require.NoError(t, svc.RequestPasswordReset(ctx, u.Email))
+	second := waitForToken(t, dir, 2)
+	require.NotEqual(t, first, second)
+
+	assert.ErrorIs(t, svc.ResetPassword(ctx, first, "new-password"), ErrInvalidResetToken)
+	assert.NoError(t, svc.ResetPassword(ctx, second, "new-password"))
+}
+
+func TestRequestPasswordReset_UnknownEmail(t *testing.T) {
+	svc, _, dir := newMailService(t)
+
+	require.NoError(t, svc.RequestPasswordReset(context.Background(), "nobody@example.com"))
+	time.Sleep(50 * time.Millisecond)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
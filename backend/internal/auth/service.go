@@ -4,35 +4,80 @@ import (
 	"context"
 	"errors"
 	"strconv"
+	"strings"
 	"time"
 
 	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/mail"
+	"backend/internal/oidc"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type Service struct {
-	queries   *db.Queries
-	jwtSecret string
+	queries    *db.Queries
+	jwtSecret  string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	mailer     mail.Mailer
+	appURL     string
+	oidc       *oidc.Provider
+	onRevoked  []func(userID int32)
 }
 
-func NewService(q *db.Queries, secret string) *Service {
-	return &Service{queries: q, jwtSecret: secret}
+// NewService creates the auth service. Access tokens are valid for
+// accessTTL; a session ends when it has not been refreshed for refreshTTL.
+// Emails sent to users link to pages of the web app at appURL.
+func NewService(q *db.Queries, secret string, accessTTL, refreshTTL time.Duration, mailer mail.Mailer, appURL string) *Service {
+	return &Service{
+		queries: q, jwtSecret: secret, accessTTL: accessTTL, refreshTTL: refreshTTL,
+		mailer: mailer, appURL: strings.TrimRight(appURL, "/"),
+	}
+}
+
+// OnSessionsRevoked registers fn to run after the sessions of a user were
+// signed out together: by LogoutAll, a password change or reset, or an
+// account takeover through OIDC. Register callbacks before the server
+// starts.
+func (s *Service) OnSessionsRevoked(fn func(userID int32)) {
+	s.onRevoked = append(s.onRevoked, fn)
+}
+
+// revokeSessions signs out the sessions of a user except keepID, 0 for
+// none, and runs the OnSessionsRevoked callbacks.
+func (s *Service) revokeSessions(ctx context.Context, userID, keepID int32) error {
+	if err := s.queries.DeleteOtherSessions(ctx, db.DeleteOtherSessionsParams{UserID: userID, ID: keepID}); err != nil {
+		return err
+	}
+	for _, fn := range s.onRevoked {
+		fn(userID)
+	}
+	return nil
 }
 
 var (
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrInvalidPassword    = errors.New("current password is incorrect")
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrInvalidPassword     = errors.New("current password is incorrect")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrInvalidResetToken   = errors.New("invalid or expired reset token")
+	ErrInvalidVerifyToken  = errors.New("invalid or expired verification token")
+	ErrAlreadyVerified     = errors.New("email already verified")
+	ErrInvalidOIDCState    = errors.New("invalid or expired sign-in state")
+	// ErrOIDCEmailNotVerified is returned when an unknown identity comes
+	// without an email the provider has verified, so it cannot be linked.
+	ErrOIDCEmailNotVerified = errors.New("identity provider did not verify the email")
 )
 
-func (s *Service) Register(ctx context.Context, name, email, password string) (string, UserPublic, error) {
+func (s *Service) Register(ctx context.Context, name, email, password string, client Client) (Tokens, UserPublic, error) {
 	if _, err := s.queries.GetUserByEmail(ctx, email); err == nil {
-		return "", UserPublic{}, errors.New("email already registered")
+		return Tokens{}, UserPublic{}, errors.New("email already registered")
 	}
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		return "", UserPublic{}, errors.New("failed to hash password")
+		return Tokens{}, UserPublic{}, errors.New("failed to hash password")
 	}
 	u, err := s.queries.CreateUser(ctx, db.CreateUserParams{
 		Name:         name,
@@ -40,28 +85,33 @@ func (s *Service) Register(ctx context.Context, name, email, password string) (s
 		PasswordHash: string(hash),
 	})
 	if err != nil {
-		return "", UserPublic{}, err
+		return Tokens{}, UserPublic{}, err
+	}
+	// The account is usable right away, so a failed email is not fatal: the
+	// user can ask for another one.
+	if err := s.sendVerification(ctx, u); err != nil {
+		logger.WithContext(ctx).Warn("Failed to send verification email", "user_id", u.ID, "error", err)
 	}
-	token, err := s.generateToken(u.ID)
+	tokens, err := s.startSession(ctx, u.ID, client)
 	if err != nil {
-		return "", UserPublic{}, errors.New("failed to generate token")
+		return Tokens{}, UserPublic{}, errors.New("failed to generate token")
 	}
-	return token, UserPublic{ID: u.ID, Name: u.Name, Email: u.Email}, nil
+	return tokens, publicUser(u), nil
 }
 
-func (s *Service) Login(ctx context.Context, email, password string) (string, UserPublic, error) {
+func (s *Service) Login(ctx context.Context, email, password string, client Client) (Tokens, UserPublic, error) {
 	u, err := s.queries.GetUserByEmail(ctx, email)
 	if err != nil {
-		return "", UserPublic{}, ErrInvalidCredentials
+		return Tokens{}, UserPublic{}, ErrInvalidCredentials
 	}
 	if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) != nil {
-		return "", UserPublic{}, ErrInvalidCredentials
+		return Tokens{}, UserPublic{}, ErrInvalidCredentials
 	}
-	token, err := s.generateToken(u.ID)
+	tokens, err := s.startSession(ctx, u.ID, client)
 	if err != nil {
-		return "", UserPublic{}, errors.New("failed to generate token")
+		return Tokens{}, UserPublic{}, errors.New("failed to generate token")
 	}
-	return token, UserPublic{ID: u.ID, Name: u.Name, Email: u.Email}, nil
+	return tokens, publicUser(u), nil
 }
 
 func (s *Service) GetUserByID(ctx context.Context, id int32) (UserPublic, error) {
@@ -69,10 +119,17 @@ func (s *Service) GetUserByID(ctx context.Context, id int32) (UserPublic, error)
 	if err != nil {
 		return UserPublic{}, err
 	}
-	return UserPublic{ID: u.ID, Name: u.Name, Email: u.Email}, nil
+	return publicUser(u), nil
 }
 
-func (s *Service) ChangePassword(ctx context.Context, userID int32, currentPassword, newPassword string) error {
+func publicUser(u db.User) UserPublic {
+	return UserPublic{ID: u.ID, Name: u.Name, Email: u.Email, EmailVerified: u.EmailVerifiedAt.Valid}
+}
+
+// ChangePassword sets a new password and signs out every other session of
+// the user. sessionID is the session making the change, or 0 to sign out
+// all of them.
+func (s *Service) ChangePassword(ctx context.Context, userID, sessionID int32, currentPassword, newPassword string) error {
 	// Get the user to verify the current password
 	user, err := s.queries.GetUserByID(ctx, userID)
 	if err != nil {
@@ -91,17 +148,31 @@ func (s *Service) ChangePassword(ctx context.Context, userID int32, currentPassw
 	}
 
 	// Update the password hash
-	_, err = s.queries.UpdatePasswordHash(ctx, db.UpdatePasswordHashParams{
+	if _, err := s.queries.UpdatePasswordHash(ctx, db.UpdatePasswordHashParams{
 		ID:           userID,
 		PasswordHash: string(newHash),
-	})
-	return err
+	}); err != nil {
+		return err
+	}
+
+	// Whoever knew the old password must not stay signed in
+	return s.revokeSessions(ctx, userID, sessionID)
+}
+
+// accessClaims are the claims of an access token. SessionID ties the token
+// to the session it was issued for.
+type accessClaims struct {
+	jwt.RegisteredClaims
+	SessionID int32 `json:"sid,omitempty"`
 }
 
-func (s *Service) generateToken(userID int32) (string, error) {
-	claims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-		Subject:   strconv.Itoa(int(userID)),
+func (s *Service) generateToken(userID, sessionID int32) (string, error) {
+	claims := accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.accessTTL)),
+			Subject:   strconv.Itoa(int(userID)),
+		},
+		SessionID: sessionID,
 	}
 	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return t.SignedString([]byte(s.jwtSecret))
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+)
+
+// reuseGrace is how long after a rotation the replaced refresh token is
+// merely rejected rather than treated as stolen, so two tabs refreshing at
+// the same moment do not sign each other out.
+const reuseGrace = 30 * time.Second
+
+// Client describes the device a session is used from.
+type Client struct {
+	UserAgent string
+	IP        string
+}
+
+// Tokens are issued when a session starts or is refreshed.
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	// ExpiresIn is the lifetime of the access token in seconds.
+	ExpiresIn int
+}
+
+// startSession records a new session for the user and issues its tokens.
+func (s *Service) startSession(ctx context.Context, userID int32, client Client) (Tokens, error) {
+	if err := s.queries.DeleteExpiredSessions(ctx, userID); err != nil {
+		logger.WithContext(ctx).Warn("Failed to delete expired sessions", "user_id", userID, "error", err)
+	}
+	refresh, err := newToken()
+	if err != nil {
+		return Tokens{}, err
+	}
+	sess, err := s.queries.CreateSession(ctx, db.CreateSessionParams{
+		UserID:           userID,
+		RefreshTokenHash: hashToken(refresh),
+		UserAgent:        client.UserAgent,
+		IpAddress:        client.IP,
+		TtlSeconds:       int32(s.refreshTTL / time.Second),
+	})
+	if err != nil {
+		return Tokens{}, err
+	}
+	return s.tokens(sess, refresh)
+}
+
+// Refresh exchanges a refresh token for new tokens. The refresh token is
+// rotated, so each one works once. Presenting a token that was already
+// rotated away means it was copied, and ends the session.
+func (s *Service) Refresh(ctx context.Context, refreshToken string, client Client) (Tokens, error) {
+	next, err := newToken()
+	if err != nil {
+		return Tokens{}, err
+	}
+	sess, err := s.queries.RotateSession(ctx, db.RotateSessionParams{
+		RefreshTokenHash:   hashToken(refreshToken),
+		RefreshTokenHash_2: hashToken(next),
+		UserAgent:          client.UserAgent,
+		IpAddress:          client.IP,
+		TtlSeconds:         int32(s.refreshTTL / time.Second),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		n, err := s.queries.DeleteSessionByPreviousToken(ctx, db.DeleteSessionByPreviousTokenParams{
+			PreviousTokenHash: pgtype.Text{String: hashToken(refreshToken), Valid: true},
+			GraceSeconds:      int32(reuseGrace / time.Second),
+		})
+		if err == nil && n > 0 {
+			logger.WithContext(ctx).Warn("Refresh token reused, session revoked",
+				"remote_addr", client.IP,
+			)
+		}
+		return Tokens{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return Tokens{}, err
+	}
+	return s.tokens(sess, next)
+}
+
+// Logout ends the session of a refresh token. Unknown tokens are ignored.
+func (s *Service) Logout(ctx context.Context, refreshToken string) error {
+	_, err := s.queries.DeleteSessionByToken(ctx, hashToken(refreshToken))
+	return err
+}
+
+// LogoutAll ends every session of the user. Access tokens already issued
+// stay valid until they expire.
+func (s *Service) LogoutAll(ctx context.Context, userID int32) error {
+	return s.revokeSessions(ctx, userID, 0)
+}
+
+// ListSessions returns the active sessions of the user, most recently used
+// first. currentID marks the session of the caller.
+func (s *Service) ListSessions(ctx context.Context, userID, currentID int32) ([]SessionResponse, error) {
+	rows, err := s.queries.ListSessionsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SessionResponse, len(rows))
+	for i, r := range rows {
+		out[i] = SessionResponse{
+			ID:         r.ID,
+			UserAgent:  r.UserAgent,
+			IPAddress:  r.IpAddress,
+			CreatedAt:  r.CreatedAt.Time,
+			LastSeenAt: r.LastSeenAt.Time,
+			Current:    r.ID == currentID,
+		}
+	}
+	return out, nil
+}
+
+// RevokeSession ends one session of the user.
+func (s *Service) RevokeSession(ctx context.Context, userID, sessionID int32) error {
+	n, err := s.queries.DeleteSession(ctx, db.DeleteSessionParams{ID: sessionID, UserID: userID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+func (t Tokens) response() TokenResponse {
+	return TokenResponse{Token: t.AccessToken, RefreshToken: t.RefreshToken, ExpiresIn: t.ExpiresIn}
+}
+
+func (s *Service) tokens(sess db.Session, refresh string) (Tokens, error) {
+	access, err := s.generateToken(sess.UserID, sess.ID)
+	if err != nil {
+		return Tokens{}, err
+	}
+	return Tokens{AccessToken: access, RefreshToken: refresh, ExpiresIn: int(s.accessTTL / time.Second)}, nil
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken is how refresh and reset tokens are stored, so a database leak
+// does not hand out sessions or accounts.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,111 @@
+// internal/auth/session_test.go
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	db "backend/internal/db/sqlc"
+)
+
+func newSessionService(t *testing.T) (*Service, *fakeDB, db.User, Tokens) {
+	fake := newFakeDB()
+	u := fake.addUser("ivan@example.com", true)
+	svc := NewService(db.New(fake), "secret", time.Minute, time.Hour, nil, "")
+	tokens, err := svc.startSession(context.Background(), u.ID, Client{})
+	require.NoError(t, err)
+	return svc, fake, u, tokens
+}
+
+func TestRefresh_Rotates(t *testing.T) {
+	svc, fake, u, first := newSessionService(t)
+	ctx := context.Background()
+
+	second, err := svc.Refresh(ctx, first.RefreshToken, Client{})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	assert.NotEmpty(t, second.AccessToken)
+	assert.Equal(t, 1, fake.sessionCount(u.ID), "the session is rotated, not replaced")
+
+	// The new token works in turn
+	_, err = svc.Refresh(ctx, second.RefreshToken, Client{})
+	require.NoError(t, err)
+}
+
+func TestRefresh_ReuseWithinGrace(t *testing.T) {
+	svc, fake, u, first := newSessionService(t)
+	ctx := context.Background()
+
+	second, err := svc.Refresh(ctx, first.RefreshToken, Client{})
+	require.NoError(t, err)
+
+	// Another tab refreshing with the same token a moment later
+	fake.advance(reuseGrace / 2)
+	_, err = svc.Refresh(ctx, first.RefreshToken, Client{})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	assert.Equal(t, 1, fake.sessionCount(u.ID), "the session survives")
+
+	_, err = svc.Refresh(ctx, second.RefreshToken, Client{})
+	assert.NoError(t, err)
+}
+
+func TestRefresh_ReuseAfterGrace(t *testing.T) {
+	svc, fake, u, first := newSessionService(t)
+	ctx := context.Background()
+
+	second, err := svc.Refresh(ctx, first.RefreshToken, Client{})
+	require.NoError(t, err)
+
+	// The replaced token shows up again long after: it was copied
+	fake.advance(reuseGrace + time.Second)
+	_, err = svc.Refresh(ctx, first.RefreshToken, Client{})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	assert.Equal(t, 0, fake.sessionCount(u.ID), "the session is revoked")
+
+	_, err = svc.Refresh(ctx, second.RefreshToken, Client{})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestRefresh_Expired(t *testing.T) {
+	svc, fake, _, first := newSessionService(t)
+
+	fake.advance(time.Hour)
+	_, err := svc.Refresh(context.Background(), first.RefreshToken, Client{})
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+}
+
+func TestLogoutAll_RunsCallbacks(t *testing.T) {
+	svc, fake, u, _ := newSessionService(t)
+	var revoked []int32
+	svc.OnSessionsRevoked(func(userID int32) { revoked = append(revoked, userID) })
+
+	require.NoError(t, svc.LogoutAll(context.Background(), u.ID))
+	assert.Equal(t, 0, fake.sessionCount(u.ID))
+	assert.Equal(t, []int32{u.ID}, revoked)
+}
+
+func TestChangePassword_KeepsCurrentSession(t *testing.T) {
+	svc, fake, u, _ := newSessionService(t)
+	current := fake.addSession(u.ID)
+	hash, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	u.PasswordHash = string(hash)
+	fake.users[u.ID] = u
+	var revoked []int32
+	svc.OnSessionsRevoked(func(userID int32) { revoked = append(revoked, userID) })
+
+	err = svc.ChangePassword(context.Background(), u.ID, current, "wrong-password", "new-password")
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+	assert.Equal(t, 2, fake.sessionCount(u.ID))
+	assert.Empty(t, revoked)
+
+	require.NoError(t, svc.ChangePassword(context.Background(), u.ID, current, "old-password", "new-password"))
+	assert.Equal(t, 1, fake.sessionCount(u.ID))
+	assert.Contains(t, fake.sessions, current)
+	assert.Equal(t, []int32{u.ID}, revoked)
+}
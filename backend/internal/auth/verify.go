@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/mail"
+)
+
+// verifyTTL is how long an email verification link works.
+const verifyTTL = 24 * time.Hour
+
+// sendVerification emails u a link that confirms their address. Only the
+// latest link works.
+func (s *Service) sendVerification(ctx context.Context, u db.User) error {
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+	if err := s.queries.DeleteEmailVerificationsByUser(ctx, u.ID); err != nil {
+		return err
+	}
+	if err := s.queries.CreateEmailVerification(ctx, db.CreateEmailVerificationParams{
+		TokenHash:  hashToken(token),
+		UserID:     u.ID,
+		TtlSeconds: int32(verifyTTL / time.Second),
+	}); err != nil {
+		return err
+	}
+
+	link := s.appURL + "/verify-email?token=" + url.QueryEscape(token)
+	s.sendMail(ctx, mail.Message{
+		To:      u.Email,
+		Subject: "Confirm your email for CollabBoard",
+		Body: "Hi " + u.Name + ",\n\n" +
+			"Please confirm that this is your email address by opening this link within 24 hours:\n\n" +
+			link + "\n\n" +
+			"Until then, other users cannot invite you to their boards by email.\n",
+	})
+	return nil
+}
+
+// VerifyEmail confirms the address of the user a verification token was
+// sent to. The token works once.
+func (s *Service) VerifyEmail(ctx context.Context, token string) error {
+	userID, err := s.queries.RedeemEmailVerification(ctx, hashToken(token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrInvalidVerifyToken
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.queries.MarkEmailVerified(ctx, userID); err != nil {
+		return err
+	}
+	return s.queries.DeleteEmailVerificationsByUser(ctx, userID)
+}
+
+// ResendVerification emails the user a new verification link, replacing
+// any earlier one.
+func (s *Service) ResendVerification(ctx context.Context, userID int32) error {
+	u, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u.EmailVerifiedAt.Valid {
+		return ErrAlreadyVerified
+	}
+	return s.sendVerification(ctx, u)
+}
@@ -0,0 +1,60 @@
+// internal/auth/verify_test.go
+package auth
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	svc, fake, dir := newMailService(t)
+	ctx := context.Background()
+	u := fake.addUser("ivan@example.com", false)
+
+	require.NoError(t, svc.ResendVerification(ctx, u.ID))
+	token := waitForToken(t, dir, 1)
+
+	require.NoError(t, svc.VerifyEmail(ctx, token))
+	assert.True(t, fake.user(u.ID).EmailVerifiedAt.Valid)
+
+	// The link works once
+	assert.ErrorIs(t, svc.VerifyEmail(ctx, token), ErrInvalidVerifyToken)
+}
+
+func TestVerifyEmail_Expired(t *testing.T) {
+	svc, fake, dir := newMailService(t)
+	ctx := context.Background()
+	u := fake.addUser("ivan@example.com", false)
+
+	require.NoError(t, svc.ResendVerification(ctx, u.ID))
+	token := waitForToken(t, dir, 1)
+
+	fake.advance(verifyTTL)
+	assert.ErrorIs(t, svc.VerifyEmail(ctx, token), ErrInvalidVerifyToken)
+	assert.False(t, fake.user(u.ID).EmailVerifiedAt.Valid)
+}
+
+func TestResendVerification_ReplacesLink(t *testing.T) {
+	svc, fake, dir := newMailService(t)
+	ctx := context.Background()
+	u := fake.addUser("ivan@example.com", false)
+
+	require.NoError(t, svc.ResendVerification(ctx, u.ID))
+	first := waitForToken(t, dir, 1)
+	require.NoError(t, svc.ResendVerification(ctx, u.ID))
+	second := waitForToken(t, dir, 2)
+	require.NotEqual(t, first, second)
+
+	assert.ErrorIs(t, svc.VerifyEmail(ctx, first), ErrInvalidVerifyToken)
+	require.NoError(t, svc.VerifyEmail(ctx, second))
+
+	// Once verified, there is nothing to resend
+	assert.ErrorIs(t, svc.ResendVerification(ctx, u.ID), ErrAlreadyVerified)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
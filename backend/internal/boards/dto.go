@@ -10,6 +10,8 @@ type CreateBoardRequest struct {
 // UpdateBoardRequest represents the request body for updating a board
 type UpdateBoardRequest struct {
 	Name string `json:"name" binding:"required" example:"Updated Board Name"`
+	// Version the update is based on; may be sent as If-Match instead.
+	Version *int32 `json:"version" example:"3"`
 }
 
 // AddMemberRequest represents the request body for adding a member to a board
@@ -31,6 +33,41 @@ type BoardResponse struct {
 	OwnerID   int32     `json:"ownerId" example:"1"`
 	Role      string    `json:"role" example:"owner"`
 	CreatedAt time.Time `json:"createdAt" example:"2023-01-01T00:00:00Z"`
+	Version   int32     `json:"version" example:"3"`
+}
+
+// ArchivedBoardResponse represents a board in the trash
+type ArchivedBoardResponse struct {
+	ID         int32     `json:"id" example:"1"`
+	Name       string    `json:"name" example:"My Project Board"`
+	OwnerID    int32     `json:"ownerId" example:"1"`
+	CreatedAt  time.Time `json:"createdAt" example:"2023-01-01T00:00:00Z"`
+	ArchivedAt time.Time `json:"archivedAt" example:"2023-02-01T00:00:00Z"`
+}
+
+// TrashListResponse represents an archived list in API responses
+type TrashListResponse struct {
+	ID         int32     `json:"id" example:"3"`
+	BoardID    int32     `json:"boardId" example:"1"`
+	Title      string    `json:"title" example:"Backlog"`
+	Position   int32     `json:"position" example:"2"`
+	ArchivedAt time.Time `json:"archivedAt" example:"2023-02-01T00:00:00Z"`
+}
+
+// TrashCardResponse represents an archived card in API responses
+type TrashCardResponse struct {
+	ID         int32     `json:"id" example:"7"`
+	ListID     int32     `json:"listId" example:"3"`
+	ListTitle  string    `json:"listTitle" example:"Backlog"`
+	Title      string    `json:"title" example:"Old task"`
+	Position   int32     `json:"position" example:"1"`
+	ArchivedAt time.Time `json:"archivedAt" example:"2023-02-01T00:00:00Z"`
+}
+
+// TrashResponse represents the trash of a board
+type TrashResponse struct {
+	Lists []TrashListResponse `json:"lists"`
+	Cards []TrashCardResponse `json:"cards"`
 }
 
 // BoardMemberResponse represents a board member in API responses
@@ -2,6 +2,7 @@ package boards
 
 import (
 	db "backend/internal/db/sqlc"
+	"backend/internal/versioning"
 	"errors"
 	"net/http"
 	"strconv"
@@ -15,10 +16,13 @@ func RegisterRoutes(r *gin.RouterGroup, svc *Service) {
 	g.POST("", createBoardHandler(svc))
 	g.GET("", listBoardsHandler(svc))
 	g.GET("/by-role/:role", listBoardsByRoleHandler(svc))
+	g.GET("/archived", listArchivedBoardsHandler(svc))
 
 	g.GET("/:boardId", getBoardHandler(svc))
 	g.PUT("/:boardId", updateBoardHandler(svc))
 	g.DELETE("/:boardId", deleteBoardHandler(svc))
+	g.POST("/:boardId/restore", restoreBoardHandler(svc))
+	g.GET("/:boardId/trash", boardTrashHandler(svc))
 
 	g.GET("/:boardId/members", listMembersHandler(svc))
 	g.POST("/:boardId/members", addMemberHandler(svc))
@@ -63,6 +67,7 @@ func createBoardHandler(svc *Service) gin.HandlerFunc {
 			"Name":      board.Name,
 			"OwnerID":   board.OwnerID,
 			"CreatedAt": board.CreatedAt,
+			"Version":   board.Version,
 			"role":      "owner", // Creator is always the owner
 		}
 		c.JSON(http.StatusCreated, response)
@@ -95,31 +100,44 @@ func listBoardsHandler(svc *Service) gin.HandlerFunc {
 // updateBoardHandler updates a board
 //
 //	@Summary		Update board
-//	@Description	Update board information (only board owners can update)
+//	@Description	Update board information (only board owners can update). The expected version is taken from the body or the If-Match header
 //	@Tags			Boards
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			boardId	path		int					true	"Board ID"
-//	@Param			request	body		UpdateBoardRequest	true	"Board update details"
-//	@Success		200		{object}	BoardResponse		"Board updated successfully"
-//	@Failure		400		{object}	ErrorResponse		"Invalid request"
-//	@Failure		401		{object}	ErrorResponse		"Unauthorized"
-//	@Failure		403		{object}	ErrorResponse		"Forbidden - only owners can update"
-//	@Failure		500		{object}	ErrorResponse		"Internal server error"
+//	@Param			boardId		path		int							true	"Board ID"
+//	@Param			If-Match	header		string						false	"Expected board version, if not in the body"
+//	@Param			request		body		UpdateBoardRequest			true	"Board update details"
+//	@Success		200			{object}	BoardResponse				"Board updated successfully"
+//	@Failure		400			{object}	ErrorResponse				"Invalid request"
+//	@Failure		401			{object}	ErrorResponse				"Unauthorized"
+//	@Failure		403			{object}	ErrorResponse				"Forbidden - only owners can update"
+//	@Failure		409			{object}	versioning.ConflictResponse	"Board was changed by someone else"
+//	@Failure		428			{object}	ErrorResponse				"Version missing"
+//	@Failure		500			{object}	ErrorResponse				"Internal server error"
 //	@Router			/api/boards/{boardId} [put]
 func updateBoardHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		boardID, _ := strconv.Atoi(c.Param("boardId"))
 		var req struct {
-			Name string `json:"name" binding:"required"`
+			Name    string `json:"name" binding:"required"`
+			Version *int32 `json:"version"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		version, err := versioning.Expected(c, req.Version)
+		if err != nil {
+			c.JSON(versioning.Status(err), gin.H{"error": err.Error()})
+			return
+		}
 		userID := int32(c.GetInt("userID"))
-		board, err := svc.UpdateBoard(c.Request.Context(), userID, db.UpdateBoardParams{ID: int32(boardID), Name: req.Name})
+		board, err := svc.UpdateBoard(c.Request.Context(), userID, db.UpdateBoardParams{ID: int32(boardID), Name: req.Name, Version: version})
+		if errors.Is(err, versioning.ErrConflict) {
+			c.JSON(http.StatusConflict, versioning.ConflictResponse{Error: err.Error(), Current: board})
+			return
+		}
 		if err != nil {
 			if errors.Is(err, ErrForbidden) {
 				c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
@@ -128,6 +146,7 @@ func updateBoardHandler(svc *Service) gin.HandlerFunc {
 			}
 			return
 		}
+		versioning.SetETag(c, board.Version)
 		c.JSON(http.StatusOK, board)
 	}
 }
@@ -135,7 +154,7 @@ func updateBoardHandler(svc *Service) gin.HandlerFunc {
 // deleteBoardHandler deletes a board
 //
 //	@Summary		Delete board
-//	@Description	Delete a board (only board owners can delete)
+//	@Description	Move a board to the trash (only board owners can delete). It can be restored until the trash is purged
 //	@Tags			Boards
 //	@Produce		json
 //	@Security		BearerAuth
@@ -161,6 +180,93 @@ func deleteBoardHandler(svc *Service) gin.HandlerFunc {
 	}
 }
 
+// listArchivedBoardsHandler lists the boards in the user's trash
+//
+//	@Summary		Get archived boards
+//	@Description	Get the archived boards owned by the authenticated user
+//	@Tags			Boards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		ArchivedBoardResponse	"List of archived boards"
+//	@Failure		401	{object}	ErrorResponse			"Unauthorized"
+//	@Failure		500	{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/boards/archived [get]
+func listArchivedBoardsHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := int32(c.GetInt("userID"))
+		boards, err := svc.ListArchivedBoards(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, boards)
+	}
+}
+
+// restoreBoardHandler restores a board from the trash
+//
+//	@Summary		Restore board
+//	@Description	Take an archived board out of the trash (only board owners can restore)
+//	@Tags			Boards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId	path		int						true	"Board ID"
+//	@Success		200		{object}	ArchivedBoardResponse	"Board restored successfully"
+//	@Failure		401		{object}	ErrorResponse			"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse			"Forbidden - only owners can restore"
+//	@Failure		404		{object}	ErrorResponse			"Board is not in the trash"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/boards/{boardId}/restore [post]
+func restoreBoardHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		boardID, _ := strconv.Atoi(c.Param("boardId"))
+		userID := int32(c.GetInt("userID"))
+		board, err := svc.RestoreBoard(c.Request.Context(), userID, int32(boardID))
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrForbidden):
+				c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			case errors.Is(err, ErrNotArchived):
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			return
+		}
+		c.JSON(http.StatusOK, board)
+	}
+}
+
+// boardTrashHandler gets the trash of a board
+//
+//	@Summary		Get board trash
+//	@Description	Get the archived lists and cards of a board that can still be restored
+//	@Tags			Boards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId	path		int				true	"Board ID"
+//	@Success		200		{object}	TrashResponse	"Archived lists and cards"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/boards/{boardId}/trash [get]
+func boardTrashHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		boardID, _ := strconv.Atoi(c.Param("boardId"))
+		userID := int32(c.GetInt("userID"))
+		trash, err := svc.GetTrash(c.Request.Context(), userID, int32(boardID))
+		if err != nil {
+			if errors.Is(err, ErrForbidden) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			return
+		}
+		c.JSON(http.StatusOK, trash)
+	}
+}
+
 // getBoardHandler gets a specific board by ID
 //
 //	@Summary		Get board by ID
@@ -332,7 +438,7 @@ func listBoardsByRoleHandler(svc *Service) gin.HandlerFunc {
 // inviteMemberByEmailHandler invites a user to a board by email
 //
 //	@Summary		Invite member by email
-//	@Description	Invite a user to join a board using their email address
+//	@Description	Invite a user to join a board using their email address. The user must have verified that address.
 //	@Tags			Board Members
 //	@Accept			json
 //	@Produce		json
@@ -344,6 +450,7 @@ func listBoardsByRoleHandler(svc *Service) gin.HandlerFunc {
 //	@Failure		401		{object}	ErrorResponse			"Unauthorized"
 //	@Failure		403		{object}	ErrorResponse			"Forbidden - only owners can invite"
 //	@Failure		404		{object}	ErrorResponse			"User not found"
+//	@Failure		409		{object}	ErrorResponse			"User has not verified their email"
 //	@Failure		500		{object}	ErrorResponse			"Internal server error"
 //	@Router			/api/boards/{boardId}/members/invite [post]
 func inviteMemberByEmailHandler(svc *Service) gin.HandlerFunc {
@@ -380,6 +487,8 @@ func inviteMemberByEmailHandler(svc *Service) gin.HandlerFunc {
 				status = http.StatusNotFound
 				c.JSON(status, gin.H{"error": "user with this email not found"})
 				return
+			} else if errors.Is(err, ErrUserNotVerified) {
+				status = http.StatusConflict
 			}
 			c.JSON(status, gin.H{"error": err.Error()})
 			return
@@ -0,0 +1,52 @@
+package boards
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/websocket"
+)
+
+// Members only receive board events while the board is open, so changes to
+// someone's access are also sent to their personal channel.
+
+// notifyInvited tells a new member which board they were added to.
+func (s *Service) notifyInvited(ctx context.Context, actorID int32, m db.BoardMember) {
+	b, err := s.repo.Get(ctx, m.BoardID)
+	if err != nil {
+		logger.Error("Failed to load board for invitation event", "board_id", m.BoardID, "error", err)
+		return
+	}
+	s.hub.NotifyUser(m.UserID, websocket.EventMessage{Event: "board_invited", Data: gin.H{
+		"boardId": b.ID, "name": b.Name, "role": m.Role, "invitedBy": actorID,
+	}})
+}
+
+// notifyRemoved tells a removed member that the board is gone from their list.
+func (s *Service) notifyRemoved(ctx context.Context, boardID, memberID int32) {
+	b, err := s.repo.Get(ctx, boardID)
+	if err != nil {
+		logger.Error("Failed to load board for removal event", "board_id", boardID, "error", err)
+		return
+	}
+	s.hub.NotifyUser(memberID, websocket.EventMessage{Event: "board_removed", Data: gin.H{
+		"boardId": b.ID, "name": b.Name,
+	}})
+}
+
+// notifyDeleted tells every member of a board that it was moved to the trash.
+func (s *Service) notifyDeleted(ctx context.Context, b db.Board) {
+	members, err := s.repo.ListMembers(ctx, b.ID)
+	if err != nil {
+		logger.Error("Failed to list members for board deletion event", "board_id", b.ID, "error", err)
+		return
+	}
+	for _, m := range members {
+		s.hub.NotifyUser(m.UserID, websocket.EventMessage{Event: "board_deleted", Data: gin.H{
+			"boardId": b.ID, "name": b.Name,
+		}})
+	}
+}
@@ -3,15 +3,18 @@ package boards
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	db "backend/internal/db/sqlc"
 )
 
 type Repository struct {
 	queries *db.Queries
+	pool    *pgxpool.Pool // for statements that must commit together
 }
 
-func NewRepository(q *db.Queries) *Repository {
-	return &Repository{queries: q}
+func NewRepository(q *db.Queries, pool *pgxpool.Pool) *Repository {
+	return &Repository{queries: q, pool: pool}
 }
 
 func (r *Repository) Create(ctx context.Context, arg db.CreateBoardParams) (db.Board, error) {
@@ -34,16 +37,51 @@ func (r *Repository) Update(ctx context.Context, arg db.UpdateBoardParams) (db.B
 	return r.queries.UpdateBoard(ctx, arg)
 }
 
-func (r *Repository) Delete(ctx context.Context, id int32) error {
-	return r.queries.DeleteBoard(ctx, id)
+func (r *Repository) Archive(ctx context.Context, id int32) (db.Board, error) {
+	return r.queries.ArchiveBoard(ctx, id)
+}
+
+func (r *Repository) Restore(ctx context.Context, id int32) (db.Board, error) {
+	return r.queries.RestoreBoard(ctx, id)
+}
+
+func (r *Repository) ListArchivedByOwner(ctx context.Context, ownerID int32) ([]db.Board, error) {
+	return r.queries.ListArchivedBoardsByOwner(ctx, ownerID)
+}
+
+func (r *Repository) ListArchivedLists(ctx context.Context, boardID int32) ([]db.List, error) {
+	return r.queries.ListArchivedListsByBoard(ctx, boardID)
+}
+
+func (r *Repository) ListArchivedCards(ctx context.Context, boardID int32) ([]db.ListArchivedCardsByBoardRow, error) {
+	return r.queries.ListArchivedCardsByBoard(ctx, boardID)
 }
 
 func (r *Repository) AddMember(ctx context.Context, arg db.AddBoardMemberParams) (db.BoardMember, error) {
 	return r.queries.AddBoardMember(ctx, arg)
 }
 
-func (r *Repository) DeleteMember(ctx context.Context, arg db.DeleteBoardMemberParams) error {
-	return r.queries.DeleteBoardMember(ctx, arg)
+// DeleteMember removes the user from the board and unassigns them from
+// every card of it in one transaction, so no card is left assigned to a
+// non-member. It returns the IDs of the cards that lost the assignee.
+func (r *Repository) DeleteMember(ctx context.Context, arg db.DeleteBoardMemberParams) ([]int32, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := r.queries.WithTx(tx)
+
+	cardIDs, err := q.DeleteBoardAssigneesForUser(ctx, db.DeleteBoardAssigneesForUserParams{
+		BoardID: arg.BoardID, UserID: arg.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := q.DeleteBoardMember(ctx, arg); err != nil {
+		return nil, err
+	}
+	return cardIDs, tx.Commit(ctx)
 }
 
 func (r *Repository) ListMembers(ctx context.Context, boardID int32) ([]db.ListBoardMembersRow, error) {
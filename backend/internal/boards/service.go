@@ -5,23 +5,52 @@ import (
 	"errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 
+	"backend/internal/activity"
 	db "backend/internal/db/sqlc"
+	"backend/internal/versioning"
 	"backend/internal/websocket"
 )
 
 type Service struct {
-	repo *Repository
-	hub  *websocket.Hub
+	repo     *Repository
+	hub      *websocket.Hub
+	activity *activity.Recorder
+
+	onUnassigned []UnassignedFunc
 }
 
-func NewService(repo *Repository, hub *websocket.Hub) *Service {
-	return &Service{repo: repo, hub: hub}
+// UnassignedFunc is called after a member left or was removed from a board
+// with the cards of the board they were unassigned from.
+type UnassignedFunc func(ctx context.Context, boardID int32, cardIDs []int32)
+
+func NewService(repo *Repository, hub *websocket.Hub, rec *activity.Recorder) *Service {
+	return &Service{repo: repo, hub: hub, activity: rec}
+}
+
+// OnCardsUnassigned registers fn to run after a departing member was
+// unassigned from cards. Register callbacks before the server starts.
+func (s *Service) OnCardsUnassigned(fn UnassignedFunc) {
+	s.onUnassigned = append(s.onUnassigned, fn)
+}
+
+// cardsUnassigned runs the OnCardsUnassigned callbacks.
+func (s *Service) cardsUnassigned(ctx context.Context, boardID int32, cardIDs []int32) {
+	if len(cardIDs) == 0 {
+		return
+	}
+	for _, fn := range s.onUnassigned {
+		fn(ctx, boardID, cardIDs)
+	}
 }
 
 var (
 	ErrForbidden    = errors.New("forbidden: insufficient permissions")
 	ErrUserNotFound = errors.New("user not found")
+	// ErrUserNotVerified is returned when inviting by email a user who has
+	// not confirmed that address yet.
+	ErrUserNotVerified = errors.New("user has not verified their email")
 )
 
 func (s *Service) CreateBoard(ctx context.Context, ownerID int32, name string) (db.Board, error) {
@@ -31,6 +60,10 @@ func (s *Service) CreateBoard(ctx context.Context, ownerID int32, name string) (
 	}
 	// owner automatically added as board_member inside migration trigger or here
 	_, _ = s.repo.AddMember(ctx, db.AddBoardMemberParams{BoardID: b.ID, UserID: ownerID, Role: "owner"})
+	s.activity.Record(ctx, activity.Entry{
+		BoardID: b.ID, ActorID: ownerID, Action: activity.BoardCreated,
+		EntityType: activity.EntityBoard, EntityID: b.ID, After: b,
+	})
 
 	// Create board data with role information for WebSocket broadcast
 	boardData := gin.H{
@@ -38,6 +71,7 @@ func (s *Service) CreateBoard(ctx context.Context, ownerID int32, name string) (
 		"Name":      b.Name,
 		"OwnerID":   b.OwnerID,
 		"CreatedAt": b.CreatedAt,
+		"Version":   b.Version,
 		"role":      "owner", // Creator is always the owner
 	}
 	s.hub.Broadcast(b.ID, websocket.EventMessage{Event: "board_created", Data: boardData})
@@ -55,27 +89,55 @@ func (s *Service) ListBoardsByRole(ctx context.Context, userID int32, role strin
 	})
 }
 
+// UpdateBoard renames the board if it is still at arg.Version. Otherwise it
+// returns the current board together with versioning.ErrConflict.
 func (s *Service) UpdateBoard(ctx context.Context, userID int32, arg db.UpdateBoardParams) (db.Board, error) {
 	member, err := s.repo.queries.GetBoardMember(ctx, db.GetBoardMemberParams{BoardID: arg.ID, UserID: userID})
 	if err != nil || member.Role != "owner" {
 		return db.Board{}, ErrForbidden
 	}
+	before, err := s.repo.Get(ctx, arg.ID)
+	if err != nil {
+		return db.Board{}, err
+	}
+	if before.Version != arg.Version {
+		return before, versioning.ErrConflict
+	}
 	b, err := s.repo.Update(ctx, arg)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if b, err = s.repo.Get(ctx, arg.ID); err != nil {
+			return db.Board{}, err
+		}
+		return b, versioning.ErrConflict
+	}
 	if err == nil {
+		s.activity.Record(ctx, activity.Entry{
+			BoardID: b.ID, ActorID: userID, Action: activity.BoardUpdated,
+			EntityType: activity.EntityBoard, EntityID: b.ID, Before: before, After: b,
+		})
 		s.hub.Broadcast(b.ID, websocket.EventMessage{Event: "board_updated", Data: b})
 	}
 	return b, err
 }
 
+// DeleteBoard moves a board to its owner's trash. Archived boards disappear
+// from board lists and are read-only until restored.
 func (s *Service) DeleteBoard(ctx context.Context, userID, boardID int32) error {
 	member, err := s.repo.queries.GetBoardMember(ctx, db.GetBoardMemberParams{BoardID: boardID, UserID: userID})
 	if err != nil || member.Role != "owner" {
 		return ErrForbidden
 	}
-	if err := s.repo.Delete(ctx, boardID); err != nil {
+	b, err := s.repo.Archive(ctx, boardID)
+	if err != nil {
 		return err
 	}
-	s.hub.Broadcast(boardID, websocket.EventMessage{Event: "board_deleted", Data: gin.H{"id": boardID}})
+	s.activity.Record(ctx, activity.Entry{
+		BoardID: boardID, ActorID: userID, Action: activity.BoardArchived,
+		EntityType: activity.EntityBoard, EntityID: boardID, After: map[string]any{"ArchivedAt": b.ArchivedAt},
+	})
+	s.hub.Broadcast(boardID, websocket.EventMessage{Event: "board_deleted", Data: gin.H{"id": boardID, "version": b.Version}})
+	s.notifyDeleted(ctx, b)
+	s.hub.Revoke(boardID, 0)
 	return nil
 }
 
@@ -113,9 +175,14 @@ func (s *Service) AddMember(
 		BoardID: boardID, UserID: newUserID, Role: role,
 	})
 	if err == nil {
+		s.activity.Record(ctx, activity.Entry{
+			BoardID: boardID, ActorID: userID, Action: activity.MemberAdded,
+			EntityType: activity.EntityMember, EntityID: m.UserID, After: m,
+		})
 		s.hub.Broadcast(boardID, websocket.EventMessage{
 			Event: "member_added", Data: m,
 		})
+		s.notifyInvited(ctx, userID, m)
 	}
 	return m, err
 }
@@ -129,14 +196,29 @@ func (s *Service) RemoveMember(
 	if err != nil || owner.Role != "owner" {
 		return ErrForbidden
 	}
-	if err := s.repo.DeleteMember(ctx, db.DeleteBoardMemberParams{
+	member, err := s.repo.queries.GetBoardMember(ctx, db.GetBoardMemberParams{
 		BoardID: boardID, UserID: memberID,
-	}); err != nil {
+	})
+	if err != nil {
+		return err
+	}
+	// Delete the member together with their card assignments on this board
+	unassigned, err := s.repo.DeleteMember(ctx, db.DeleteBoardMemberParams{
+		BoardID: boardID, UserID: memberID,
+	})
+	if err != nil {
 		return err
 	}
+	s.activity.Record(ctx, activity.Entry{
+		BoardID: boardID, ActorID: userID, Action: activity.MemberRemoved,
+		EntityType: activity.EntityMember, EntityID: memberID, Before: member,
+	})
 	s.hub.Broadcast(boardID, websocket.EventMessage{
 		Event: "member_removed", Data: map[string]int32{"userId": memberID},
 	})
+	s.cardsUnassigned(ctx, boardID, unassigned)
+	s.notifyRemoved(ctx, boardID, memberID)
+	s.hub.Revoke(boardID, memberID)
 	return nil
 }
 
@@ -157,17 +239,25 @@ func (s *Service) LeaveBoard(
 		return errors.New("owners cannot leave their own board")
 	}
 
-	// Delete the member
-	if err := s.repo.DeleteMember(ctx, db.DeleteBoardMemberParams{
+	// Delete the member together with their card assignments on this board
+	unassigned, err := s.repo.DeleteMember(ctx, db.DeleteBoardMemberParams{
 		BoardID: boardID, UserID: userID,
-	}); err != nil {
+	})
+	if err != nil {
 		return err
 	}
 
+	s.activity.Record(ctx, activity.Entry{
+		BoardID: boardID, ActorID: userID, Action: activity.MemberLeft,
+		EntityType: activity.EntityMember, EntityID: userID, Before: member,
+	})
+
 	// Broadcast the event
 	s.hub.Broadcast(boardID, websocket.EventMessage{
 		Event: "member_left", Data: map[string]int32{"userId": userID},
 	})
+	s.cardsUnassigned(ctx, boardID, unassigned)
+	s.hub.Revoke(boardID, userID)
 	return nil
 }
 
@@ -187,6 +277,9 @@ func (s *Service) AddMemberByEmail(
 	if err != nil {
 		return db.BoardMember{}, ErrUserNotFound
 	}
+	if !user.EmailVerifiedAt.Valid {
+		return db.BoardMember{}, ErrUserNotVerified
+	}
 
 	// Default role to "member" if not specified
 	if role == "" {
@@ -198,9 +291,14 @@ func (s *Service) AddMemberByEmail(
 		BoardID: boardID, UserID: user.ID, Role: role,
 	})
 	if err == nil {
+		s.activity.Record(ctx, activity.Entry{
+			BoardID: boardID, ActorID: userID, Action: activity.MemberAdded,
+			EntityType: activity.EntityMember, EntityID: m.UserID, After: m,
+		})
 		s.hub.Broadcast(boardID, websocket.EventMessage{
 			Event: "member_added", Data: m,
 		})
+		s.notifyInvited(ctx, userID, m)
 	}
 	return m, err
 }
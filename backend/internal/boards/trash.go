@@ -0,0 +1,69 @@
+package boards
+
+import (
+	"context"
+	"errors"
+
+	"backend/internal/activity"
+	db "backend/internal/db/sqlc"
+	"backend/internal/websocket"
+)
+
+var ErrNotArchived = errors.New("board is not in the trash")
+
+// Trash is the archive browser of a board: lists and cards that were
+// deleted from it and can still be restored.
+type Trash struct {
+	Lists []db.List
+	Cards []db.ListArchivedCardsByBoardRow
+}
+
+// GetTrash returns the archived lists and cards of a board.
+func (s *Service) GetTrash(ctx context.Context, userID, boardID int32) (Trash, error) {
+	if _, err := s.repo.queries.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: boardID, UserID: userID,
+	}); err != nil {
+		return Trash{}, ErrForbidden
+	}
+	ls, err := s.repo.ListArchivedLists(ctx, boardID)
+	if err != nil {
+		return Trash{}, err
+	}
+	cs, err := s.repo.ListArchivedCards(ctx, boardID)
+	if err != nil {
+		return Trash{}, err
+	}
+	if ls == nil {
+		ls = []db.List{}
+	}
+	if cs == nil {
+		cs = []db.ListArchivedCardsByBoardRow{}
+	}
+	return Trash{Lists: ls, Cards: cs}, nil
+}
+
+// ListArchivedBoards returns the archived boards owned by userID.
+func (s *Service) ListArchivedBoards(ctx context.Context, userID int32) ([]db.Board, error) {
+	return s.repo.ListArchivedByOwner(ctx, userID)
+}
+
+// RestoreBoard takes a board out of the trash. Only its owner can do that.
+func (s *Service) RestoreBoard(ctx context.Context, userID, boardID int32) (db.Board, error) {
+	b, err := s.repo.Get(ctx, boardID)
+	if err != nil || b.OwnerID != userID {
+		return db.Board{}, ErrForbidden
+	}
+	if !b.ArchivedAt.Valid {
+		return db.Board{}, ErrNotArchived
+	}
+	restored, err := s.repo.Restore(ctx, boardID)
+	if err != nil {
+		return db.Board{}, err
+	}
+	s.activity.Record(ctx, activity.Entry{
+		BoardID: boardID, ActorID: userID, Action: activity.BoardRestored,
+		EntityType: activity.EntityBoard, EntityID: boardID, Before: b, After: restored,
+	})
+	s.hub.Broadcast(boardID, websocket.EventMessage{Event: "board_restored", Data: restored})
+	return restored, nil
+}
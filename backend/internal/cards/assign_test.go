@@ -0,0 +1,74 @@
+// internal/cards/assign_test.go
+package cards
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	db "backend/internal/db/sqlc"
+)
+
+// fakeDB answers single-row queries of a board whose members are given and
+// records the statements it executes.
+type fakeDB struct {
+	members map[int32]bool
+	execs   []string
+}
+
+func queryName(sql string) string {
+	name, _, _ := strings.Cut(strings.TrimPrefix(sql, "-- name: "), " ")
+	return name
+}
+
+func (f *fakeDB) Exec(_ context.Context, sql string, _ ...interface{}) (pgconn.CommandTag, error) {
+	f.execs = append(f.execs, queryName(sql))
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeDB) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query " + queryName(sql))
+}
+
+func (f *fakeDB) QueryRow(_ context.Context, sql string, args ...interface{}) pgx.Row {
+	switch queryName(sql) {
+	case "GetCardByID", "GetListByID":
+		return row{}
+	case "GetBoardMember":
+		if f.members[args[1].(int32)] {
+			return row{}
+		}
+		return row{err: pgx.ErrNoRows}
+	}
+	return row{err: errors.New("unexpected query " + queryName(sql))}
+}
+
+type row struct{ err error }
+
+func (r row) Scan(...any) error { return r.err }
+
+func TestAssign_AssigneeMustBeMember(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int32
+		assigneeID int32
+		want       error
+	}{
+		{"AssigneeNotMember", 1, 3, ErrAssigneeNotMember},
+		{"CallerNotMember", 3, 2, ErrNotMember},
+	}
+
+	for _, tc := range tests {
+		fake := &fakeDB{members: map[int32]bool{1: true, 2: true}}
+		svc := NewService(nil, db.New(fake), nil, nil)
+
+		_, err := svc.Assign(context.Background(), tc.userID, 10, tc.assigneeID)
+		assert.ErrorIs(t, err, tc.want, tc.name)
+		assert.Empty(t, fake.execs, tc.name)
+	}
+}
@@ -0,0 +1,109 @@
+package cards
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "backend/internal/db/sqlc"
+)
+
+// CardDetails is a card together with the board data attached to it.
+// db.Card is embedded so the JSON payload keeps the same shape as a plain
+// card and existing clients keep working.
+type CardDetails struct {
+	db.Card
+	Labels    []db.Label
+	Assignees []Assignee
+	Checklist ChecklistProgress
+	Overdue   bool
+}
+
+// ChecklistProgress is the number of checked items out of all checklist
+// items of a card.
+type ChecklistProgress struct {
+	Done  int32
+	Total int32
+}
+
+// Assignee is a board member assigned to a card.
+type Assignee struct {
+	UserID     int32
+	Name       string
+	Email      string
+	AssignedAt pgtype.Timestamp
+}
+
+// AssignedCard is a card assigned to the current user together with the
+// board and list it lives in.
+type AssignedCard struct {
+	CardDetails
+	BoardID   int32
+	BoardName string
+	ListTitle string
+}
+
+// WithDetails loads labels, assignees and checklist progress for all given
+// cards with one query each and flags the ones that are past due.
+func WithDetails(ctx context.Context, q *db.Queries, cs []db.Card) ([]CardDetails, error) {
+	out := make([]CardDetails, len(cs))
+	if len(cs) == 0 {
+		return out, nil
+	}
+	now := time.Now().UTC()
+	ids := make([]int32, len(cs))
+	index := make(map[int32]int, len(cs))
+	for i, c := range cs {
+		ids[i] = c.ID
+		index[c.ID] = i
+		out[i] = CardDetails{Card: c, Labels: []db.Label{}, Assignees: []Assignee{}, Overdue: isOverdue(c, now)}
+	}
+
+	labels, err := q.ListCardLabelsByCardIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range labels {
+		i := index[l.CardID]
+		out[i].Labels = append(out[i].Labels, db.Label{
+			ID:        l.ID,
+			BoardID:   l.BoardID,
+			Name:      l.Name,
+			Color:     l.Color,
+			CreatedAt: l.CreatedAt,
+		})
+	}
+
+	assignees, err := q.ListCardAssigneesByCardIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assignees {
+		i := index[a.CardID]
+		out[i].Assignees = append(out[i].Assignees, Assignee{
+			UserID:     a.UserID,
+			Name:       a.Name,
+			Email:      a.Email,
+			AssignedAt: a.AssignedAt,
+		})
+	}
+
+	progress, err := q.ChecklistProgressByCardIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range progress {
+		out[index[p.CardID]].Checklist = ChecklistProgress{Done: p.Done, Total: p.Total}
+	}
+	return out, nil
+}
+
+// details loads the attached data of a single card.
+func (s *Service) details(ctx context.Context, card db.Card) (CardDetails, error) {
+	ds, err := WithDetails(ctx, s.q, []db.Card{card})
+	if err != nil {
+		return CardDetails{}, err
+	}
+	return ds[0], nil
+}
@@ -15,22 +15,73 @@ type UpdateCardRequest struct {
 	Description string `json:"description" example:"Updated description of the bug"`
 	Position    *int32 `json:"position" example:"2"`
 	ListID      *int32 `json:"listId" example:"3"`
+	// StartAt and DueAt are kept as-is when omitted; send clearStartAt /
+	// clearDueAt to remove them.
+	StartAt      *time.Time `json:"startAt" example:"2023-01-02T09:00:00Z"`
+	DueAt        *time.Time `json:"dueAt" example:"2023-01-05T18:00:00Z"`
+	ClearStartAt bool       `json:"clearStartAt" example:"false"`
+	ClearDueAt   bool       `json:"clearDueAt" example:"false"`
+	Completed    *bool      `json:"completed" example:"false"`
+	// Version the update is based on; may be sent as If-Match instead.
+	Version *int32 `json:"version" example:"3"`
 }
 
 // MoveCardRequest represents the request body for moving a card
 type MoveCardRequest struct {
 	ListID   *int32 `json:"listId" example:"2"`
 	Position int32  `json:"position" binding:"required" example:"1"`
+	Version  *int32 `json:"version" example:"3"`
 }
 
-// CardResponse represents a card in API responses
+// CardResponse documents a card in API responses. Handlers return
+// CardDetails, which embeds db.Card without JSON tags, so fields keep their
+// Go names on the wire.
 type CardResponse struct {
-	ID          int32     `json:"id" example:"1"`
-	ListID      int32     `json:"listId" example:"1"`
-	Title       string    `json:"title" example:"Fix login bug"`
-	Description string    `json:"description" example:"The login form is not validating email properly"`
-	Position    int32     `json:"position" example:"1"`
-	CreatedAt   time.Time `json:"createdAt" example:"2023-01-01T00:00:00Z"`
+	ID          int32             `json:"ID" example:"1"`
+	ListID      int32             `json:"ListID" example:"1"`
+	Title       string            `json:"Title" example:"Fix login bug"`
+	Description *string           `json:"Description" example:"The login form is not validating email properly"`
+	Position    int32             `json:"Position" example:"1"`
+	CreatedAt   time.Time         `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+	StartAt     *time.Time        `json:"StartAt" example:"2023-01-02T09:00:00Z"`
+	DueAt       *time.Time        `json:"DueAt" example:"2023-01-05T18:00:00Z"`
+	Completed   bool              `json:"Completed" example:"false"`
+	ArchivedAt  *time.Time        `json:"ArchivedAt"`
+	Version     int32             `json:"Version" example:"3"`
+	Labels      []CardLabel       `json:"Labels"`
+	Assignees   []CardAssignee    `json:"Assignees"`
+	Checklist   ChecklistProgress `json:"Checklist"`
+	Overdue     bool              `json:"Overdue" example:"false"`
+}
+
+// CardAssignee represents a board member assigned to a card
+type CardAssignee struct {
+	UserID     int32     `json:"UserID" example:"2"`
+	Name       string    `json:"Name" example:"John Doe"`
+	Email      string    `json:"Email" example:"john@example.com"`
+	AssignedAt time.Time `json:"AssignedAt" example:"2023-01-01T00:00:00Z"`
+}
+
+// AssignCardRequest represents the request body for assigning a card
+type AssignCardRequest struct {
+	UserID int32 `json:"userId" binding:"required" example:"2"`
+}
+
+// AssignedCardResponse represents a card assigned to the current user
+type AssignedCardResponse struct {
+	CardResponse
+	BoardID   int32  `json:"BoardID" example:"1"`
+	BoardName string `json:"BoardName" example:"My Project Board"`
+	ListTitle string `json:"ListTitle" example:"In Progress"`
+}
+
+// CardLabel represents a label attached to a card
+type CardLabel struct {
+	ID        int32     `json:"ID" example:"1"`
+	BoardID   int32     `json:"BoardID" example:"1"`
+	Name      string    `json:"Name" example:"bug"`
+	Color     string    `json:"Color" example:"#eb5a46"`
+	CreatedAt time.Time `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
 }
 
 // ErrorResponse represents an error response
@@ -0,0 +1,32 @@
+// internal/cards/dto_test.go
+package cards
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "backend/internal/db/sqlc"
+)
+
+// keys returns the top-level JSON keys of v.
+func keys(t *testing.T, v any) []string {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	var m map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(b, &m))
+	return slices.Sorted(maps.Keys(m))
+}
+
+// The Swagger DTOs must describe what the handlers actually send.
+func TestCardResponse_MatchesPayload(t *testing.T) {
+	details := CardDetails{Card: db.Card{ID: 1}, Labels: []db.Label{}, Assignees: []Assignee{}}
+	assert.Equal(t, keys(t, details), keys(t, CardResponse{}))
+	assert.Equal(t, keys(t, db.Label{}), keys(t, CardLabel{}))
+	assert.Equal(t, keys(t, Assignee{}), keys(t, CardAssignee{}))
+	assert.Equal(t, keys(t, AssignedCard{CardDetails: details}), keys(t, AssignedCardResponse{}))
+}
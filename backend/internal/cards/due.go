@@ -0,0 +1,50 @@
+package cards
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "backend/internal/db/sqlc"
+)
+
+// DueFilter narrows the cards of a list by their due date.
+type DueFilter string
+
+const (
+	DueOverdue DueFilter = "overdue"
+	DueSoon    DueFilter = "due_soon"
+
+	// DefaultDueSoonWindow is how far ahead "due soon" looks when the client
+	// does not pass its own window.
+	DefaultDueSoonWindow = 24 * time.Hour
+)
+
+// ParseDueFilter validates the `due` query parameter.
+func ParseDueFilter(v string) (DueFilter, error) {
+	switch f := DueFilter(v); f {
+	case DueOverdue, DueSoon:
+		return f, nil
+	default:
+		return "", ErrInvalidDueFilter
+	}
+}
+
+// isOverdue reports whether an open card is past its due date at now.
+func isOverdue(c db.Card, now time.Time) bool {
+	return !c.Completed && c.DueAt.Valid && c.DueAt.Time.Before(now)
+}
+
+// validDates rejects a start date that comes after the due date.
+func validDates(startAt, dueAt pgtype.Timestamp) bool {
+	return !startAt.Valid || !dueAt.Valid || !startAt.Time.After(dueAt.Time)
+}
+
+// timestamp converts an optional time to a TIMESTAMP column value. Times are
+// stored in UTC because the column carries no zone.
+func timestamp(t *time.Time) pgtype.Timestamp {
+	if t == nil {
+		return pgtype.Timestamp{}
+	}
+	return pgtype.Timestamp{Time: t.UTC(), Valid: true}
+}
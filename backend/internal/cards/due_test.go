@@ -0,0 +1,49 @@
+// internal/cards/due_test.go
+package cards
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+
+	db "backend/internal/db/sqlc"
+)
+
+func ts(t time.Time) pgtype.Timestamp { return pgtype.Timestamp{Time: t, Valid: true} }
+
+func TestIsOverdue(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		card db.Card
+		want bool
+	}{
+		{"NoDueDate", db.Card{}, false},
+		{"PastDue", db.Card{DueAt: ts(now.Add(-time.Hour))}, true},
+		{"PastDueCompleted", db.Card{DueAt: ts(now.Add(-time.Hour)), Completed: true}, false},
+		{"DueLater", db.Card{DueAt: ts(now.Add(time.Hour))}, false},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, isOverdue(tc.card, now), tc.name)
+	}
+}
+
+func TestValidDates(t *testing.T) {
+	now := time.Now()
+	assert.True(t, validDates(pgtype.Timestamp{}, pgtype.Timestamp{}))
+	assert.True(t, validDates(ts(now), pgtype.Timestamp{}))
+	assert.True(t, validDates(ts(now), ts(now.Add(time.Hour))))
+	assert.False(t, validDates(ts(now.Add(time.Hour)), ts(now)))
+}
+
+func TestParseDueFilter(t *testing.T) {
+	f, err := ParseDueFilter("overdue")
+	assert.NoError(t, err)
+	assert.Equal(t, DueOverdue, f)
+
+	_, err = ParseDueFilter("tomorrow")
+	assert.ErrorIs(t, err, ErrInvalidDueFilter)
+}
@@ -2,8 +2,11 @@ package cards
 
 import (
 	db "backend/internal/db/sqlc"
+	"backend/internal/versioning"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 
@@ -21,6 +24,30 @@ func RegisterRoutes(r *gin.RouterGroup, svc *Service) {
 	// Card operations that don't need list context
 	cardGroup := r.Group("/cards")
 	cardGroup.POST("/:id/duplicate", duplicateCardHandler(svc))
+	cardGroup.POST("/:id/restore", restoreCardHandler(svc))
+	cardGroup.POST("/:id/labels/:labelId", attachLabelHandler(svc))
+	cardGroup.DELETE("/:id/labels/:labelId", detachLabelHandler(svc))
+	cardGroup.POST("/:id/assignees", assignCardHandler(svc))
+	cardGroup.DELETE("/:id/assignees/:userId", unassignCardHandler(svc))
+
+	r.GET("/me/cards", myCardsHandler(svc))
+}
+
+// errorStatus maps service errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotMember):
+		return http.StatusForbidden
+	case errors.Is(err, ErrNotArchived):
+		return http.StatusNotFound
+	case errors.Is(err, ErrListArchived):
+		return http.StatusConflict
+	case errors.Is(err, ErrLabelNotOnBoard), errors.Is(err, ErrInvalidDates), errors.Is(err, ErrInvalidDueFilter),
+		errors.Is(err, ErrAssigneeNotMember):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
 }
 
 // createCardHandler creates a new card in a list
@@ -63,12 +90,15 @@ func createCardHandler(svc *Service) gin.HandlerFunc {
 // listCardsHandler gets all cards in a list
 //
 //	@Summary		Get list cards
-//	@Description	Get all cards in a specific list
+//	@Description	Get all cards in a specific list, optionally only the overdue or due-soon ones
 //	@Tags			Cards
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			listId	path		int				true	"List ID"
+//	@Param			due		query		string			false	"Due filter (overdue or due_soon)"
+//	@Param			within	query		int				false	"Due-soon window in hours (default 24)"
 //	@Success		200		{array}		CardResponse	"List of cards"
+//	@Failure		400		{object}	ErrorResponse	"Invalid filter"
 //	@Failure		401		{object}	ErrorResponse	"Unauthorized"
 //	@Failure		500		{object}	ErrorResponse	"Internal server error"
 //	@Router			/api/lists/{listId}/cards [get]
@@ -77,6 +107,30 @@ func listCardsHandler(svc *Service) gin.HandlerFunc {
 		listID, _ := strconv.Atoi(c.Param("listId"))
 		userID := int32(c.GetInt("userID"))
 
+		if due := c.Query("due"); due != "" {
+			filter, err := ParseDueFilter(due)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			within := DefaultDueSoonWindow
+			if h := c.Query("within"); h != "" {
+				hours, err := strconv.Atoi(h)
+				if err != nil || hours <= 0 {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "invalid within, must be a positive number of hours"})
+					return
+				}
+				within = time.Duration(hours) * time.Hour
+			}
+			cs, err := svc.ListDue(c.Request.Context(), userID, int32(listID), filter, within)
+			if err != nil {
+				c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, cs)
+			return
+		}
+
 		cs, err := svc.ListByList(c.Request.Context(), userID, int32(listID))
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -89,18 +143,21 @@ func listCardsHandler(svc *Service) gin.HandlerFunc {
 // updateCardHandler updates a card
 //
 //	@Summary		Update card
-//	@Description	Update card title, description, position, or list
+//	@Description	Update card title, description, position, list, dates or completion. The expected version is taken from the body or the If-Match header
 //	@Tags			Cards
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			listId	path		int					true	"List ID"
-//	@Param			id		path		int					true	"Card ID"
-//	@Param			request	body		UpdateCardRequest	true	"Card update details"
-//	@Success		200		{object}	CardResponse		"Card updated successfully"
-//	@Failure		400		{object}	ErrorResponse		"Invalid request"
-//	@Failure		401		{object}	ErrorResponse		"Unauthorized"
-//	@Failure		500		{object}	ErrorResponse		"Internal server error"
+//	@Param			listId		path		int							true	"List ID"
+//	@Param			id			path		int							true	"Card ID"
+//	@Param			If-Match	header		string						false	"Expected card version, if not in the body"
+//	@Param			request		body		UpdateCardRequest			true	"Card update details"
+//	@Success		200			{object}	CardResponse				"Card updated successfully"
+//	@Failure		400			{object}	ErrorResponse				"Invalid request"
+//	@Failure		401			{object}	ErrorResponse				"Unauthorized"
+//	@Failure		409			{object}	versioning.ConflictResponse	"Card was changed by someone else"
+//	@Failure		428			{object}	ErrorResponse				"Version missing"
+//	@Failure		500			{object}	ErrorResponse				"Internal server error"
 //	@Router			/api/lists/{listId}/cards/{id} [put]
 func updateCardHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -108,15 +165,26 @@ func updateCardHandler(svc *Service) gin.HandlerFunc {
 		userID := int32(c.GetInt("userID"))
 
 		var req struct {
-			Title       string `json:"title"`
-			Description string `json:"description"`
-			Position    *int32 `json:"position"`
-			ListID      *int32 `json:"listId"`
+			Title        string     `json:"title"`
+			Description  string     `json:"description"`
+			Position     *int32     `json:"position"`
+			ListID       *int32     `json:"listId"`
+			StartAt      *time.Time `json:"startAt"`
+			DueAt        *time.Time `json:"dueAt"`
+			ClearStartAt bool       `json:"clearStartAt"`
+			ClearDueAt   bool       `json:"clearDueAt"`
+			Completed    *bool      `json:"completed"`
+			Version      *int32     `json:"version"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		version, err := versioning.Expected(c, req.Version)
+		if err != nil {
+			c.JSON(versioning.Status(err), gin.H{"error": err.Error()})
+			return
+		}
 
 		// Get the original card to retrieve its current values
 		originalCard, err := svc.q.GetCardByID(c.Request.Context(), int32(id))
@@ -133,8 +201,12 @@ func updateCardHandler(svc *Service) gin.HandlerFunc {
 				Valid:  true,
 			},
 			// Use the original card's values as defaults
-			Position: originalCard.Position,
-			ListID:   originalCard.ListID,
+			Position:  originalCard.Position,
+			ListID:    originalCard.ListID,
+			StartAt:   originalCard.StartAt,
+			DueAt:     originalCard.DueAt,
+			Completed: originalCard.Completed,
+			Version:   version,
 		}
 
 		// Override with request values if provided
@@ -144,12 +216,26 @@ func updateCardHandler(svc *Service) gin.HandlerFunc {
 		if req.ListID != nil {
 			p.ListID = *req.ListID
 		}
+		if req.StartAt != nil || req.ClearStartAt {
+			p.StartAt = timestamp(req.StartAt)
+		}
+		if req.DueAt != nil || req.ClearDueAt {
+			p.DueAt = timestamp(req.DueAt)
+		}
+		if req.Completed != nil {
+			p.Completed = *req.Completed
+		}
 
 		card, err := svc.Update(c.Request.Context(), userID, p)
+		if errors.Is(err, versioning.ErrConflict) {
+			c.JSON(http.StatusConflict, versioning.ConflictResponse{Error: err.Error(), Current: card})
+			return
+		}
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 			return
 		}
+		versioning.SetETag(c, card.Version)
 		c.JSON(http.StatusOK, card)
 	}
 }
@@ -157,18 +243,21 @@ func updateCardHandler(svc *Service) gin.HandlerFunc {
 // moveCardHandler moves a card to a new position or list
 //
 //	@Summary		Move card
-//	@Description	Move a card to a new position within the same list or to a different list
+//	@Description	Move a card to a new position within the same list or to a different list. The expected version is taken from the body or the If-Match header
 //	@Tags			Cards
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			listId	path		int				true	"Current List ID"
-//	@Param			id		path		int				true	"Card ID"
-//	@Param			request	body		MoveCardRequest	true	"Move details"
-//	@Success		200		{object}	CardResponse	"Card moved successfully"
-//	@Failure		400		{object}	ErrorResponse	"Invalid request"
-//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
-//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Param			listId		path		int							true	"Current List ID"
+//	@Param			id			path		int							true	"Card ID"
+//	@Param			If-Match	header		string						false	"Expected card version, if not in the body"
+//	@Param			request		body		MoveCardRequest				true	"Move details"
+//	@Success		200			{object}	CardResponse				"Card moved successfully"
+//	@Failure		400			{object}	ErrorResponse				"Invalid request"
+//	@Failure		401			{object}	ErrorResponse				"Unauthorized"
+//	@Failure		409			{object}	versioning.ConflictResponse	"Card was changed by someone else"
+//	@Failure		428			{object}	ErrorResponse				"Version missing"
+//	@Failure		500			{object}	ErrorResponse				"Internal server error"
 //	@Router			/api/lists/{listId}/cards/{id}/move [put]
 func moveCardHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -181,11 +270,17 @@ func moveCardHandler(svc *Service) gin.HandlerFunc {
 		var req struct {
 			ListID   *int32 `json:"listId"`
 			Position int32  `json:"position" binding:"required"`
+			Version  *int32 `json:"version"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		version, err := versioning.Expected(c, req.Version)
+		if err != nil {
+			c.JSON(versioning.Status(err), gin.H{"error": err.Error()})
+			return
+		}
 
 		userID := int32(c.GetInt("userID"))
 
@@ -202,11 +297,16 @@ func moveCardHandler(svc *Service) gin.HandlerFunc {
 			dstListID = *req.ListID
 		}
 
-		card, err := svc.Move(c.Request.Context(), userID, int32(id), dstListID, req.Position)
+		card, err := svc.Move(c.Request.Context(), userID, int32(id), dstListID, req.Position, version)
+		if errors.Is(err, versioning.ErrConflict) {
+			c.JSON(http.StatusConflict, versioning.ConflictResponse{Error: err.Error(), Current: card})
+			return
+		}
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		versioning.SetETag(c, card.Version)
 
 		c.JSON(http.StatusOK, card)
 	}
@@ -215,7 +315,7 @@ func moveCardHandler(svc *Service) gin.HandlerFunc {
 // deleteCardHandler deletes a card
 //
 //	@Summary		Delete card
-//	@Description	Delete a card from the list
+//	@Description	Move a card to the board's trash. It can be restored until the trash is purged
 //	@Tags			Cards
 //	@Produce		json
 //	@Security		BearerAuth
@@ -238,6 +338,35 @@ func deleteCardHandler(svc *Service) gin.HandlerFunc {
 	}
 }
 
+// restoreCardHandler restores a card from the trash
+//
+//	@Summary		Restore card
+//	@Description	Put an archived card back into its list, at its old position if possible
+//	@Tags			Cards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int				true	"Card ID"
+//	@Success		200	{object}	CardResponse	"Card restored successfully"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		404	{object}	ErrorResponse	"Card is not in the trash"
+//	@Failure		409	{object}	ErrorResponse	"The card's list is in the trash"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/cards/{id}/restore [post]
+func restoreCardHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		card, err := svc.Restore(c.Request.Context(), userID, int32(id))
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, card)
+	}
+}
+
 // duplicateCardHandler duplicates a card
 //
 //	@Summary		Duplicate card
@@ -270,3 +399,179 @@ func duplicateCardHandler(svc *Service) gin.HandlerFunc {
 		c.JSON(http.StatusOK, card)
 	}
 }
+
+// attachLabelHandler attaches a board label to a card
+//
+//	@Summary		Attach label
+//	@Description	Attach one of the board labels to a card
+//	@Tags			Cards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int				true	"Card ID"
+//	@Param			labelId	path		int				true	"Label ID"
+//	@Success		200		{object}	CardResponse	"Card with updated labels"
+//	@Failure		400		{object}	ErrorResponse	"Label belongs to another board"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/cards/{id}/labels/{labelId} [post]
+func attachLabelHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card id"})
+			return
+		}
+		labelID, err := strconv.Atoi(c.Param("labelId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label id"})
+			return
+		}
+
+		userID := int32(c.GetInt("userID"))
+		card, err := svc.AttachLabel(c.Request.Context(), userID, int32(id), int32(labelID))
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, card)
+	}
+}
+
+// detachLabelHandler removes a label from a card
+//
+//	@Summary		Detach label
+//	@Description	Remove a label from a card
+//	@Tags			Cards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int				true	"Card ID"
+//	@Param			labelId	path		int				true	"Label ID"
+//	@Success		200		{object}	CardResponse	"Card with updated labels"
+//	@Failure		400		{object}	ErrorResponse	"Invalid request"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/cards/{id}/labels/{labelId} [delete]
+func detachLabelHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card id"})
+			return
+		}
+		labelID, err := strconv.Atoi(c.Param("labelId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label id"})
+			return
+		}
+
+		userID := int32(c.GetInt("userID"))
+		card, err := svc.DetachLabel(c.Request.Context(), userID, int32(id), int32(labelID))
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, card)
+	}
+}
+
+// assignCardHandler assigns a board member to a card
+//
+//	@Summary		Assign card
+//	@Description	Assign a board member to a card
+//	@Tags			Cards
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"Card ID"
+//	@Param			request	body		AssignCardRequest		true	"Assignee"
+//	@Success		200		{object}	CardResponse			"Card with updated assignees"
+//	@Failure		400		{object}	ErrorResponse			"Invalid request or assignee is not a board member"
+//	@Failure		401		{object}	ErrorResponse			"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse			"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/cards/{id}/assignees [post]
+func assignCardHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card id"})
+			return
+		}
+		var req AssignCardRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID := int32(c.GetInt("userID"))
+		card, err := svc.Assign(c.Request.Context(), userID, int32(id), req.UserID)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, card)
+	}
+}
+
+// unassignCardHandler removes an assignee from a card
+//
+//	@Summary		Unassign card
+//	@Description	Remove a user from the assignees of a card
+//	@Tags			Cards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int				true	"Card ID"
+//	@Param			userId	path		int				true	"Assignee user ID"
+//	@Success		200		{object}	CardResponse	"Card with updated assignees"
+//	@Failure		400		{object}	ErrorResponse	"Invalid request"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/cards/{id}/assignees/{userId} [delete]
+func unassignCardHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card id"})
+			return
+		}
+		assigneeID, err := strconv.Atoi(c.Param("userId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+			return
+		}
+
+		userID := int32(c.GetInt("userID"))
+		card, err := svc.Unassign(c.Request.Context(), userID, int32(id), int32(assigneeID))
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, card)
+	}
+}
+
+// myCardsHandler lists the cards assigned to the current user
+//
+//	@Summary		Get my cards
+//	@Description	Get all cards assigned to the authenticated user across all of their boards
+//	@Tags			Cards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		AssignedCardResponse	"Assigned cards"
+//	@Failure		401	{object}	ErrorResponse			"Unauthorized"
+//	@Failure		500	{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/me/cards [get]
+func myCardsHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := int32(c.GetInt("userID"))
+		cs, err := svc.ListAssignedTo(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, cs)
+	}
+}
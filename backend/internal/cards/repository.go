@@ -16,7 +16,18 @@ func (r *Repository) Create(ctx context.Context, arg db.CreateCardParams) (db.Ca
 func (r *Repository) Update(ctx context.Context, arg db.UpdateCardParams) (db.Card, error) {
 	return r.q.UpdateCard(ctx, arg)
 }
-func (r *Repository) Delete(ctx context.Context, id int32) error { return r.q.DeleteCard(ctx, id) }
+func (r *Repository) SetPosition(ctx context.Context, id, position int32) error {
+	return r.q.SetCardPosition(ctx, db.SetCardPositionParams{ID: id, Position: position})
+}
+func (r *Repository) Archive(ctx context.Context, id int32) (db.Card, error) {
+	return r.q.ArchiveCard(ctx, id)
+}
+func (r *Repository) Restore(ctx context.Context, arg db.RestoreCardParams) (db.Card, error) {
+	return r.q.RestoreCard(ctx, arg)
+}
+func (r *Repository) GetArchived(ctx context.Context, id int32) (db.Card, error) {
+	return r.q.GetArchivedCardByID(ctx, id)
+}
 func (r *Repository) ListByList(ctx context.Context, listID int32) ([]db.Card, error) {
 	return r.q.ListCardsByList(ctx, listID)
 }
@@ -3,23 +3,49 @@ package cards
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"backend/internal/activity"
 	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/versioning"
 	"backend/internal/websocket"
 )
 
 type Service struct {
-	repo *Repository
-	q    *db.Queries
-	hub  *websocket.Hub
+	repo     *Repository
+	q        *db.Queries
+	hub      *websocket.Hub
+	activity *activity.Recorder
+
+	onDescription []DescriptionFunc
 }
 
-func NewService(repo *Repository, q *db.Queries, hub *websocket.Hub) *Service {
-	return &Service{repo: repo, q: q, hub: hub}
+// DescriptionFunc is called after an update changed the description of a
+// card from old to text.
+type DescriptionFunc func(ctx context.Context, userID, boardID, cardID int32, old, text string)
+
+func NewService(repo *Repository, q *db.Queries, hub *websocket.Hub, rec *activity.Recorder) *Service {
+	return &Service{repo: repo, q: q, hub: hub, activity: rec}
 }
 
+// OnDescriptionChange registers fn to run after Update changed a card
+// description. Register callbacks before the server starts.
+func (s *Service) OnDescriptionChange(fn DescriptionFunc) {
+	s.onDescription = append(s.onDescription, fn)
+}
+
+var (
+	ErrNotMember         = errors.New("not a member")
+	ErrLabelNotOnBoard   = errors.New("label belongs to another board")
+	ErrInvalidDates      = errors.New("start date must not be after due date")
+	ErrInvalidDueFilter  = errors.New("invalid due filter, must be 'overdue' or 'due_soon'")
+	ErrAssigneeNotMember = errors.New("assignee is not a member of the board")
+)
+
 func (s *Service) Create(ctx context.Context, userID, listID int32, title string, description string, position int32) (db.Card, error) {
 	lst, err := s.q.GetListByID(ctx, listID)
 	if err != nil {
@@ -31,12 +57,16 @@ func (s *Service) Create(ctx context.Context, userID, listID int32, title string
 	_ = s.repo.ShiftRight(ctx, listID, position)
 	card, err := s.repo.Create(ctx, db.CreateCardParams{ListID: listID, Title: title, Description: pgtype.Text{String: description, Valid: description != ""}, Position: position})
 	if err == nil {
+		s.activity.Record(ctx, activity.Entry{
+			BoardID: lst.BoardID, ActorID: userID, Action: activity.CardCreated,
+			EntityType: activity.EntityCard, EntityID: card.ID, After: card,
+		})
 		s.hub.Broadcast(lst.BoardID, websocket.EventMessage{Event: "card_created", Data: card})
 	}
 	return card, err
 }
 
-func (s *Service) ListByList(ctx context.Context, userID, listID int32) ([]db.Card, error) {
+func (s *Service) ListByList(ctx context.Context, userID, listID int32) ([]CardDetails, error) {
 	lst, err := s.q.GetListByID(ctx, listID)
 	if err != nil {
 		return nil, err
@@ -46,30 +76,272 @@ func (s *Service) ListByList(ctx context.Context, userID, listID int32) ([]db.Ca
 	}); err != nil {
 		return nil, errors.New("not a member")
 	}
-	return s.repo.ListByList(ctx, listID)
+	cs, err := s.repo.ListByList(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	return WithDetails(ctx, s.q, cs)
+}
+
+// ListDue returns the open cards of a list that are overdue, or due within
+// the given window for DueSoon.
+func (s *Service) ListDue(ctx context.Context, userID, listID int32, filter DueFilter, within time.Duration) ([]CardDetails, error) {
+	lst, err := s.q.GetListByID(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: lst.BoardID, UserID: userID,
+	}); err != nil {
+		return nil, ErrNotMember
+	}
+
+	now := time.Now().UTC()
+	var cs []db.Card
+	switch filter {
+	case DueOverdue:
+		cs, err = s.q.ListOverdueCardsByList(ctx, db.ListOverdueCardsByListParams{
+			ListID: listID,
+			Now:    pgtype.Timestamp{Time: now, Valid: true},
+		})
+	case DueSoon:
+		cs, err = s.q.ListCardsDueBetween(ctx, db.ListCardsDueBetweenParams{
+			ListID:  listID,
+			DueFrom: pgtype.Timestamp{Time: now, Valid: true},
+			DueTo:   pgtype.Timestamp{Time: now.Add(within), Valid: true},
+		})
+	default:
+		return nil, ErrInvalidDueFilter
+	}
+	if err != nil {
+		return nil, err
+	}
+	return WithDetails(ctx, s.q, cs)
 }
 
-func (s *Service) Update(ctx context.Context, userID int32, arg db.UpdateCardParams) (db.Card, error) {
+// Update overwrites the card if it is still at arg.Version. Otherwise it
+// returns the current card together with versioning.ErrConflict.
+func (s *Service) Update(ctx context.Context, userID int32, arg db.UpdateCardParams) (CardDetails, error) {
+	if !validDates(arg.StartAt, arg.DueAt) {
+		return CardDetails{}, ErrInvalidDates
+	}
 	card0, err := s.q.GetCardByID(ctx, arg.ID)
 	if err != nil {
-		return db.Card{}, err
+		return CardDetails{}, err
 	}
 	lst, err := s.q.GetListByID(ctx, card0.ListID)
 	if err != nil {
-		return db.Card{}, err
+		return CardDetails{}, err
 	}
 	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
 		BoardID: lst.BoardID, UserID: userID,
 	}); err != nil {
-		return db.Card{}, errors.New("not a member")
+		return CardDetails{}, errors.New("not a member")
+	}
+	if card0.Version != arg.Version {
+		return s.conflict(ctx, card0)
 	}
 	card, err := s.repo.Update(ctx, arg)
-	if err == nil {
-		s.hub.Broadcast(lst.BoardID, websocket.EventMessage{Event: "card_updated", Data: card})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Changed between the check and the update.
+		if card0, err = s.q.GetCardByID(ctx, arg.ID); err != nil {
+			return CardDetails{}, err
+		}
+		return s.conflict(ctx, card0)
 	}
-	return card, err
+	if err != nil {
+		return CardDetails{}, err
+	}
+	s.recordCard(ctx, lst.BoardID, userID, activity.CardUpdated, card.ID, card0, card)
+	if card0.Description.String != card.Description.String {
+		for _, fn := range s.onDescription {
+			fn(ctx, userID, lst.BoardID, card.ID, card0.Description.String, card.Description.String)
+		}
+	}
+	return s.broadcastUpdated(ctx, lst.BoardID, card)
+}
+
+// conflict returns the current state of a card that was changed by someone
+// else, for the 409 reply.
+func (s *Service) conflict(ctx context.Context, current db.Card) (CardDetails, error) {
+	cd, err := s.details(ctx, current)
+	if err != nil {
+		return CardDetails{}, err
+	}
+	return cd, versioning.ErrConflict
+}
+
+// broadcastUpdated loads the card details and pushes them as card_updated,
+// so other clients see label changes together with the card itself.
+func (s *Service) broadcastUpdated(ctx context.Context, boardID int32, card db.Card) (CardDetails, error) {
+	cd, err := s.details(ctx, card)
+	if err != nil {
+		return CardDetails{}, err
+	}
+	s.hub.Broadcast(boardID, websocket.EventMessage{Event: "card_updated", Data: cd})
+	return cd, nil
+}
+
+// recordCard adds a card entry to the board activity log.
+func (s *Service) recordCard(ctx context.Context, boardID, userID int32, action string, cardID int32, before, after any) {
+	s.activity.Record(ctx, activity.Entry{
+		BoardID: boardID, ActorID: userID, Action: action,
+		EntityType: activity.EntityCard, EntityID: cardID, Before: before, After: after,
+	})
+}
+
+// Refresh pushes the current state of a card as card_updated. Other packages
+// call it when data shown on the card, such as checklist progress, changes.
+func (s *Service) Refresh(ctx context.Context, boardID, cardID int32) (CardDetails, error) {
+	card, err := s.q.GetCardByID(ctx, cardID)
+	if err != nil {
+		return CardDetails{}, err
+	}
+	return s.broadcastUpdated(ctx, boardID, card)
+}
+
+// RefreshCards pushes each card as card_updated, logging the ones that fail.
+// It suits callbacks that run after the change was already committed.
+func (s *Service) RefreshCards(ctx context.Context, boardID int32, cardIDs []int32) {
+	for _, id := range cardIDs {
+		if _, err := s.Refresh(ctx, boardID, id); err != nil {
+			logger.WithContext(ctx).Error("Failed to refresh card",
+				"card_id", id,
+				"error", err,
+			)
+		}
+	}
+}
+
+// cardContext loads a card with its list and verifies that userID is a
+// member of the board the card belongs to.
+func (s *Service) cardContext(ctx context.Context, userID, cardID int32) (db.Card, db.List, error) {
+	card, err := s.q.GetCardByID(ctx, cardID)
+	if err != nil {
+		return db.Card{}, db.List{}, err
+	}
+	lst, err := s.q.GetListByID(ctx, card.ListID)
+	if err != nil {
+		return db.Card{}, db.List{}, err
+	}
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: lst.BoardID, UserID: userID,
+	}); err != nil {
+		return db.Card{}, db.List{}, ErrNotMember
+	}
+	return card, lst, nil
 }
 
+// AttachLabel adds a board label to the card.
+func (s *Service) AttachLabel(ctx context.Context, userID, cardID, labelID int32) (CardDetails, error) {
+	card, lst, err := s.cardContext(ctx, userID, cardID)
+	if err != nil {
+		return CardDetails{}, err
+	}
+	lbl, err := s.q.GetLabelByID(ctx, labelID)
+	if err != nil {
+		return CardDetails{}, err
+	}
+	if lbl.BoardID != lst.BoardID {
+		return CardDetails{}, ErrLabelNotOnBoard
+	}
+	if err := s.q.AttachCardLabel(ctx, db.AttachCardLabelParams{CardID: cardID, LabelID: labelID}); err != nil {
+		return CardDetails{}, err
+	}
+	s.recordCard(ctx, lst.BoardID, userID, activity.CardLabelAdded, cardID,
+		nil, map[string]any{"LabelID": lbl.ID, "Name": lbl.Name})
+	return s.broadcastUpdated(ctx, lst.BoardID, card)
+}
+
+// DetachLabel removes a label from the card.
+func (s *Service) DetachLabel(ctx context.Context, userID, cardID, labelID int32) (CardDetails, error) {
+	card, lst, err := s.cardContext(ctx, userID, cardID)
+	if err != nil {
+		return CardDetails{}, err
+	}
+	if err := s.q.DetachCardLabel(ctx, db.DetachCardLabelParams{CardID: cardID, LabelID: labelID}); err != nil {
+		return CardDetails{}, err
+	}
+	s.recordCard(ctx, lst.BoardID, userID, activity.CardLabelRemoved, cardID,
+		map[string]any{"LabelID": labelID}, nil)
+	return s.broadcastUpdated(ctx, lst.BoardID, card)
+}
+
+// Assign adds a board member to the card's assignees.
+func (s *Service) Assign(ctx context.Context, userID, cardID, assigneeID int32) (CardDetails, error) {
+	card, lst, err := s.cardContext(ctx, userID, cardID)
+	if err != nil {
+		return CardDetails{}, err
+	}
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: lst.BoardID, UserID: assigneeID,
+	}); err != nil {
+		return CardDetails{}, ErrAssigneeNotMember
+	}
+	if err := s.q.AddCardAssignee(ctx, db.AddCardAssigneeParams{CardID: cardID, UserID: assigneeID}); err != nil {
+		return CardDetails{}, err
+	}
+	s.recordCard(ctx, lst.BoardID, userID, activity.CardAssigned, cardID,
+		nil, map[string]any{"UserID": assigneeID})
+	if assigneeID != userID {
+		// The assignee may not have the board open.
+		s.hub.NotifyUser(assigneeID, websocket.EventMessage{Event: "card_assigned", Data: map[string]any{
+			"boardId": lst.BoardID, "cardId": card.ID, "title": card.Title, "assignedBy": userID,
+		}})
+	}
+	return s.broadcastUpdated(ctx, lst.BoardID, card)
+}
+
+// Unassign removes a user from the card's assignees.
+func (s *Service) Unassign(ctx context.Context, userID, cardID, assigneeID int32) (CardDetails, error) {
+	card, lst, err := s.cardContext(ctx, userID, cardID)
+	if err != nil {
+		return CardDetails{}, err
+	}
+	if err := s.q.RemoveCardAssignee(ctx, db.RemoveCardAssigneeParams{CardID: cardID, UserID: assigneeID}); err != nil {
+		return CardDetails{}, err
+	}
+	s.recordCard(ctx, lst.BoardID, userID, activity.CardUnassigned, cardID,
+		map[string]any{"UserID": assigneeID}, nil)
+	return s.broadcastUpdated(ctx, lst.BoardID, card)
+}
+
+// ListAssignedTo returns the cards assigned to userID across all boards
+// the user is still a member of.
+func (s *Service) ListAssignedTo(ctx context.Context, userID int32) ([]AssignedCard, error) {
+	rows, err := s.q.ListCardsAssignedToUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	cs := make([]db.Card, len(rows))
+	for i, r := range rows {
+		cs[i] = db.Card{
+			ID:          r.ID,
+			ListID:      r.ListID,
+			Title:       r.Title,
+			Description: r.Description,
+			Position:    r.Position,
+			CreatedAt:   r.CreatedAt,
+			StartAt:     r.StartAt,
+			DueAt:       r.DueAt,
+			Completed:   r.Completed,
+			ArchivedAt:  r.ArchivedAt,
+			Version:     r.Version,
+		}
+	}
+	ds, err := WithDetails(ctx, s.q, cs)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]AssignedCard, len(rows))
+	for i, r := range rows {
+		out[i] = AssignedCard{CardDetails: ds[i], BoardID: r.BoardID, BoardName: r.BoardName, ListTitle: r.ListTitle}
+	}
+	return out, nil
+}
+
+// Delete moves a card to the board's trash. The following cards move up so
+// positions stay contiguous; Restore puts the card back.
 func (s *Service) Delete(ctx context.Context, userID, cardID int32) error {
 	card0, err := s.q.GetCardByID(ctx, cardID)
 	if err != nil {
@@ -84,16 +356,23 @@ func (s *Service) Delete(ctx context.Context, userID, cardID int32) error {
 	}); err != nil {
 		return errors.New("not a member")
 	}
-	if err := s.repo.Delete(ctx, cardID); err != nil {
+	archived, err := s.repo.Archive(ctx, cardID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.ShiftLeft(ctx, card0.ListID, card0.Position); err != nil {
 		return err
 	}
+	s.recordCard(ctx, lst.BoardID, userID, activity.CardArchived, cardID, card0, archived)
 	s.hub.Broadcast(lst.BoardID, websocket.EventMessage{
-		Event: "card_deleted", Data: map[string]int32{"id": cardID},
+		Event: "card_deleted", Data: map[string]int32{"id": cardID, "version": archived.Version},
 	})
 	return nil
 }
 
-func (s *Service) Move(ctx context.Context, userID, cardID, dstListID, newPos int32) (db.Card, error) {
+// Move puts the card at newPos in dstListID if it is still at version.
+// Otherwise it returns the current card together with versioning.ErrConflict.
+func (s *Service) Move(ctx context.Context, userID, cardID, dstListID, newPos, version int32) (db.Card, error) {
 	card, err := s.q.GetCardByID(ctx, cardID)
 	if err != nil {
 		return db.Card{}, err
@@ -115,24 +394,43 @@ func (s *Service) Move(ctx context.Context, userID, cardID, dstListID, newPos in
 	}); err != nil {
 		return db.Card{}, errors.New("not a member")
 	}
-
-	if err := s.repo.ShiftLeft(ctx, srcList.ID, card.Position); err != nil {
-		return db.Card{}, err
-	}
-	if err := s.repo.ShiftRight(ctx, dstList.ID, newPos); err != nil {
-		return db.Card{}, err
+	if card.Version != version {
+		return card, versioning.ErrConflict
 	}
 
+	// Claim the card first, parked at position 0 in the destination list, so
+	// a concurrent update cannot slip in between the shifts below.
 	updated, err := s.repo.Update(ctx, db.UpdateCardParams{
 		ID:          cardID,
 		ListID:      dstListID,
-		Position:    newPos,
+		Position:    0,
 		Title:       card.Title,
 		Description: card.Description,
+		StartAt:     card.StartAt,
+		DueAt:       card.DueAt,
+		Completed:   card.Completed,
+		Version:     version,
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		if card, err = s.q.GetCardByID(ctx, cardID); err != nil {
+			return db.Card{}, err
+		}
+		return card, versioning.ErrConflict
+	}
 	if err != nil {
 		return db.Card{}, err
 	}
+	if err := s.repo.ShiftLeft(ctx, srcList.ID, card.Position); err != nil {
+		return db.Card{}, err
+	}
+	if err := s.repo.ShiftRight(ctx, dstList.ID, newPos); err != nil {
+		return db.Card{}, err
+	}
+	if err := s.repo.SetPosition(ctx, cardID, newPos); err != nil {
+		return db.Card{}, err
+	}
+	updated.Position = newPos
+	s.recordCard(ctx, srcList.BoardID, userID, activity.CardMoved, cardID, card, updated)
 	// Send WebSocket event in the format expected by the frontend
 	s.hub.Broadcast(srcList.BoardID, websocket.EventMessage{
 		Event: "card_moved",
@@ -140,35 +438,36 @@ func (s *Service) Move(ctx context.Context, userID, cardID, dstListID, newPos in
 			"cardId":   updated.ID,
 			"toListId": updated.ListID,
 			"toPos":    updated.Position,
+			"version":  updated.Version,
 		},
 	})
 	return updated, nil
 }
 
-func (s *Service) Duplicate(ctx context.Context, userID, cardID int32) (db.Card, error) {
+func (s *Service) Duplicate(ctx context.Context, userID, cardID int32) (CardDetails, error) {
 	// Get the original card
 	origCard, err := s.q.GetCardByID(ctx, cardID)
 	if err != nil {
-		return db.Card{}, err
+		return CardDetails{}, err
 	}
 
 	// Get the list to check permissions and calculate position
 	list, err := s.q.GetListByID(ctx, origCard.ListID)
 	if err != nil {
-		return db.Card{}, err
+		return CardDetails{}, err
 	}
 
 	// Check if user is a member of the board
 	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
 		BoardID: list.BoardID, UserID: userID,
 	}); err != nil {
-		return db.Card{}, errors.New("not a member")
+		return CardDetails{}, errors.New("not a member")
 	}
 
 	// Get the highest position in the list
 	cards, err := s.repo.ListByList(ctx, list.ID)
 	if err != nil {
-		return db.Card{}, err
+		return CardDetails{}, err
 	}
 
 	// Calculate new position (at the end of the list)
@@ -191,14 +490,25 @@ func (s *Service) Duplicate(ctx context.Context, userID, cardID int32) (db.Card,
 	})
 
 	if err != nil {
-		return db.Card{}, err
+		return CardDetails{}, err
+	}
+
+	// Carry the labels over to the copy
+	if err := s.q.CopyCardLabels(ctx, db.CopyCardLabelsParams{DstCardID: newCard.ID, SrcCardID: origCard.ID}); err != nil {
+		return CardDetails{}, err
+	}
+	s.recordCard(ctx, list.BoardID, userID, activity.CardDuplicated, newCard.ID,
+		nil, map[string]any{"SourceCardID": origCard.ID, "Title": newCard.Title, "ListID": newCard.ListID})
+	cd, err := s.details(ctx, newCard)
+	if err != nil {
+		return CardDetails{}, err
 	}
 
 	// Broadcast the event
 	s.hub.Broadcast(list.BoardID, websocket.EventMessage{
 		Event: "card_created",
-		Data:  newCard,
+		Data:  cd,
 	})
 
-	return newCard, nil
+	return cd, nil
 }
@@ -0,0 +1,54 @@
+package cards
+
+import (
+	"context"
+	"errors"
+
+	"backend/internal/activity"
+	db "backend/internal/db/sqlc"
+	"backend/internal/lists"
+	"backend/internal/websocket"
+)
+
+var (
+	ErrNotArchived  = errors.New("card is not in the trash")
+	ErrListArchived = errors.New("the card's list is in the trash, restore the list first")
+)
+
+// Restore takes a card out of the trash and puts it back into its list.
+func (s *Service) Restore(ctx context.Context, userID, cardID int32) (CardDetails, error) {
+	card, err := s.repo.GetArchived(ctx, cardID)
+	if err != nil {
+		return CardDetails{}, ErrNotArchived
+	}
+	lst, err := s.q.GetListByID(ctx, card.ListID)
+	if err != nil {
+		return CardDetails{}, ErrListArchived
+	}
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: lst.BoardID, UserID: userID,
+	}); err != nil {
+		return CardDetails{}, ErrNotMember
+	}
+
+	cs, err := s.repo.ListByList(ctx, lst.ID)
+	if err != nil {
+		return CardDetails{}, err
+	}
+	pos := lists.RestorePosition(card.Position, int32(len(cs)))
+	if err := s.repo.ShiftRight(ctx, lst.ID, pos); err != nil {
+		return CardDetails{}, err
+	}
+	restored, err := s.repo.Restore(ctx, db.RestoreCardParams{ID: cardID, Position: pos})
+	if err != nil {
+		return CardDetails{}, err
+	}
+	s.recordCard(ctx, lst.BoardID, userID, activity.CardRestored, cardID, card, restored)
+
+	cd, err := s.details(ctx, restored)
+	if err != nil {
+		return CardDetails{}, err
+	}
+	s.hub.Broadcast(lst.BoardID, websocket.EventMessage{Event: "card_restored", Data: cd})
+	return cd, nil
+}
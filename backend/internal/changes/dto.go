@@ -0,0 +1,20 @@
+package changes
+
+// ChangesResponse represents the events of a board since a revision
+type ChangesResponse struct {
+	Revision       int64           `json:"revision" example:"42"`
+	ResyncRequired bool            `json:"resyncRequired" example:"false"`
+	Events         []EventResponse `json:"events"`
+}
+
+// EventResponse represents a recorded board event
+type EventResponse struct {
+	Event    string         `json:"event" example:"card_moved"`
+	Data     map[string]any `json:"data"`
+	Revision int64          `json:"revision" example:"41"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error" example:"not a member"`
+}
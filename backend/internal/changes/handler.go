@@ -0,0 +1,51 @@
+package changes
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup, svc *Service) {
+	r.GET("/boards/:boardId/changes", changesHandler(svc))
+}
+
+// changesHandler gets the events of a board since a revision
+//
+//	@Summary		Get board changes
+//	@Description	Get the events of a board after the given revision, oldest first. If they are no longer available resyncRequired is set and the client has to reload the snapshot
+//	@Tags			Boards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId	path		int				true	"Board ID"
+//	@Param			since	query		int				true	"Last revision the client has seen"
+//	@Success		200		{object}	ChangesResponse	"Events since the revision"
+//	@Failure		400		{object}	ErrorResponse	"Invalid request"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/boards/{boardId}/changes [get]
+func changesHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		boardID, _ := strconv.Atoi(c.Param("boardId"))
+		userID := int32(c.GetInt("userID"))
+		since, err := strconv.ParseInt(c.Query("since"), 10, 64)
+		if err != nil || since < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since revision"})
+			return
+		}
+
+		ch, err := svc.Since(c.Request.Context(), userID, int32(boardID), since)
+		if err != nil {
+			if errors.Is(err, ErrNotMember) {
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			return
+		}
+		c.JSON(http.StatusOK, ch)
+	}
+}
@@ -0,0 +1,56 @@
+package changes
+
+import (
+	"context"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+)
+
+const (
+	// MaxEvents is how many of the most recent events are kept per board.
+	// Clients that fall further behind must reload the board snapshot.
+	MaxEvents = 1000
+	// pruneEvery is how often (in revisions) old events are deleted.
+	pruneEvery = 100
+)
+
+// Journal stores board events in the database. It implements
+// websocket.Journal.
+type Journal struct {
+	q *db.Queries
+}
+
+func NewJournal(q *db.Queries) *Journal {
+	return &Journal{q: q}
+}
+
+// Append records an event under the next revision of the board.
+func (j *Journal) Append(ctx context.Context, boardID int32, event string, data []byte) (int64, error) {
+	rev, err := j.q.AppendBoardEvent(ctx, db.AppendBoardEventParams{
+		BoardID: boardID,
+		Event:   event,
+		Payload: data,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if rev%pruneEvery == 0 && rev > MaxEvents {
+		if err := j.q.PruneBoardEvents(ctx, db.PruneBoardEventsParams{
+			BoardID:  boardID,
+			Revision: rev - MaxEvents,
+		}); err != nil {
+			// The event itself is recorded; pruning is retried later.
+			logger.WithContext(ctx).Error("Failed to prune board events",
+				"board_id", boardID,
+				"error", err,
+			)
+		}
+	}
+	return rev, nil
+}
+
+// Revision returns the newest revision of the board, 0 if it has none.
+func (j *Journal) Revision(ctx context.Context, boardID int32) (int64, error) {
+	return j.q.GetBoardRevision(ctx, boardID)
+}
@@ -0,0 +1,33 @@
+// internal/changes/resync_test.go
+package changes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	db "backend/internal/db/sqlc"
+)
+
+func revs(rs ...int64) []db.ListBoardEventsSinceRow {
+	out := make([]db.ListBoardEventsSinceRow, len(rs))
+	for i, r := range rs {
+		out[i].Revision = r
+	}
+	return out
+}
+
+func TestResyncRequired(t *testing.T) {
+	// Up to date.
+	assert.False(t, resyncRequired(5, 5, nil, 10))
+	// Contiguous events up to the current revision.
+	assert.False(t, resyncRequired(5, 7, revs(6, 7), 10))
+	// The client is ahead of the server.
+	assert.True(t, resyncRequired(9, 5, nil, 10))
+	// Events right after since were pruned.
+	assert.True(t, resyncRequired(2, 7, revs(5, 6, 7), 10))
+	// More events than fit in one page.
+	assert.True(t, resyncRequired(0, 5, revs(1, 2, 3), 3))
+	// A full page that reaches the current revision is fine.
+	assert.False(t, resyncRequired(0, 3, revs(1, 2, 3), 3))
+}
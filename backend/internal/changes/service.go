@@ -0,0 +1,83 @@
+package changes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	db "backend/internal/db/sqlc"
+)
+
+var ErrNotMember = errors.New("not a member")
+
+type Service struct {
+	q *db.Queries
+}
+
+func NewService(q *db.Queries) *Service {
+	return &Service{q: q}
+}
+
+// Event is a recorded board event in the same shape as the WebSocket message.
+type Event struct {
+	Event    string          `json:"event"`
+	Data     json.RawMessage `json:"data"`
+	Revision int64           `json:"revision"`
+}
+
+// Changes is the answer to "what happened since revision N". When
+// ResyncRequired is set the events are not available any more and the
+// client has to reload the board snapshot.
+type Changes struct {
+	Revision       int64   `json:"revision"`
+	ResyncRequired bool    `json:"resyncRequired"`
+	Events         []Event `json:"events"`
+}
+
+// resyncRequired reports whether the events read after since cannot bring a
+// client at since up to date with current: some were pruned, there are more
+// than one page, or since is from the future (e.g. after a database restore).
+func resyncRequired(since, current int64, events []db.ListBoardEventsSinceRow, limit int) bool {
+	if len(events) == 0 {
+		return since != current
+	}
+	if events[0].Revision != since+1 {
+		return true
+	}
+	return len(events) >= limit && events[len(events)-1].Revision < current
+}
+
+// Since returns the events of a board after revision since, oldest first.
+// Events can describe changes already visible in a snapshot taken at since,
+// so clients should apply them idempotently.
+func (s *Service) Since(ctx context.Context, userID, boardID int32, since int64) (Changes, error) {
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: boardID, UserID: userID,
+	}); err != nil {
+		return Changes{}, ErrNotMember
+	}
+
+	events, err := s.q.ListBoardEventsSince(ctx, db.ListBoardEventsSinceParams{
+		BoardID:  boardID,
+		Revision: since,
+		Limit:    MaxEvents,
+	})
+	if err != nil {
+		return Changes{}, err
+	}
+	// Read after the events so it is never behind them.
+	current, err := s.q.GetBoardRevision(ctx, boardID)
+	if err != nil {
+		return Changes{}, err
+	}
+
+	if resyncRequired(since, current, events, MaxEvents) {
+		return Changes{Revision: current, ResyncRequired: true, Events: []Event{}}, nil
+	}
+	out := Changes{Revision: since, Events: make([]Event, len(events))}
+	for i, e := range events {
+		out.Events[i] = Event{Event: e.Event, Data: e.Payload, Revision: e.Revision}
+		out.Revision = e.Revision
+	}
+	return out, nil
+}
@@ -0,0 +1,90 @@
+package checklists
+
+import "time"
+
+// CreateChecklistRequest represents the request body for creating a checklist
+type CreateChecklistRequest struct {
+	Title string `json:"title" binding:"required" example:"Release"`
+}
+
+// RenameChecklistRequest represents the request body for renaming a checklist
+type RenameChecklistRequest struct {
+	Title string `json:"title" binding:"required" example:"Release steps"`
+}
+
+// CreateItemRequest represents the request body for adding a checklist item
+type CreateItemRequest struct {
+	Title    string `json:"title" binding:"required" example:"Tag the release"`
+	Position int32  `json:"position" example:"1"`
+}
+
+// UpdateItemRequest represents the request body for updating a checklist item
+type UpdateItemRequest struct {
+	Title *string `json:"title" example:"Tag the release"`
+	Done  *bool   `json:"done" example:"true"`
+}
+
+// MoveItemRequest represents the request body for moving a checklist item
+type MoveItemRequest struct {
+	ChecklistID int32 `json:"checklistId" binding:"required" example:"1"`
+	Position    int32 `json:"position" binding:"required" example:"2"`
+}
+
+// ConvertItemRequest represents the request body for turning an item into a card
+type ConvertItemRequest struct {
+	ListID *int32 `json:"listId" example:"1"`
+}
+
+// ChecklistItemResponse documents a checklist item in API responses.
+// Handlers return db.ChecklistItem, which has no JSON tags, so fields keep
+// their Go names on the wire.
+type ChecklistItemResponse struct {
+	ID          int32     `json:"ID" example:"1"`
+	ChecklistID int32     `json:"ChecklistID" example:"1"`
+	Title       string    `json:"Title" example:"Tag the release"`
+	Done        bool      `json:"Done" example:"false"`
+	Position    int32     `json:"Position" example:"1"`
+	CreatedAt   time.Time `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+}
+
+// ChecklistSummaryResponse documents a checklist without its items, as
+// returned when it is renamed.
+type ChecklistSummaryResponse struct {
+	ID        int32     `json:"ID" example:"1"`
+	CardID    int32     `json:"CardID" example:"1"`
+	Title     string    `json:"Title" example:"Release"`
+	Position  int32     `json:"Position" example:"1"`
+	CreatedAt time.Time `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+}
+
+// ChecklistResponse documents a checklist with its items in API responses
+type ChecklistResponse struct {
+	ChecklistSummaryResponse
+	Items []ChecklistItemResponse `json:"Items"`
+}
+
+// CardResponse documents the card created from a checklist item. Handlers
+// return db.Card as is.
+type CardResponse struct {
+	ID          int32      `json:"ID" example:"1"`
+	ListID      int32      `json:"ListID" example:"1"`
+	Title       string     `json:"Title" example:"Tag the release"`
+	Description *string    `json:"Description"`
+	Position    int32      `json:"Position" example:"4"`
+	CreatedAt   time.Time  `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+	StartAt     *time.Time `json:"StartAt"`
+	DueAt       *time.Time `json:"DueAt"`
+	Completed   bool       `json:"Completed" example:"false"`
+	ArchivedAt  *time.Time `json:"ArchivedAt"`
+	Version     int32      `json:"Version" example:"1"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error" example:"Checklist not found"`
+}
+
+// MessageResponse represents a simple message response
+type MessageResponse struct {
+	Message string `json:"message" example:"checklist deleted"`
+}
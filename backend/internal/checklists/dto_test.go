@@ -0,0 +1,31 @@
+// internal/checklists/dto_test.go
+package checklists
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "backend/internal/db/sqlc"
+)
+
+// keys returns the top-level JSON keys of v.
+func keys(t *testing.T, v any) []string {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	var m map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(b, &m))
+	return slices.Sorted(maps.Keys(m))
+}
+
+// The Swagger DTOs must describe what the handlers actually send.
+func TestChecklistResponse_MatchesPayload(t *testing.T) {
+	assert.Equal(t, keys(t, Checklist{Items: []db.ChecklistItem{}}), keys(t, ChecklistResponse{}))
+	assert.Equal(t, keys(t, db.CardChecklist{}), keys(t, ChecklistSummaryResponse{}))
+	assert.Equal(t, keys(t, db.ChecklistItem{}), keys(t, ChecklistItemResponse{}))
+	assert.Equal(t, keys(t, db.Card{}), keys(t, CardResponse{}))
+}
@@ -0,0 +1,335 @@
+package checklists
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup, svc *Service) {
+	r.GET("/cards/:id/checklists", listChecklistsHandler(svc))
+	r.POST("/cards/:id/checklists", createChecklistHandler(svc))
+
+	g := r.Group("/checklists")
+	g.PUT("/:id", renameChecklistHandler(svc))
+	g.DELETE("/:id", deleteChecklistHandler(svc))
+	g.POST("/:id/items", createItemHandler(svc))
+
+	items := r.Group("/checklist-items")
+	items.PUT("/:id", updateItemHandler(svc))
+	items.PUT("/:id/move", moveItemHandler(svc))
+	items.POST("/:id/convert", convertItemHandler(svc))
+	items.DELETE("/:id", deleteItemHandler(svc))
+}
+
+// errorStatus maps service errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotMember):
+		return http.StatusForbidden
+	case errors.Is(err, ErrChecklistNotFound), errors.Is(err, ErrItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrEmptyTitle), errors.Is(err, ErrOtherCard), errors.Is(err, ErrListNotOnBoard):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// listChecklistsHandler gets the checklists of a card
+//
+//	@Summary		Get card checklists
+//	@Description	Get all checklists of a card with their items in position order
+//	@Tags			Checklists
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int					true	"Card ID"
+//	@Success		200	{array}		ChecklistResponse	"List of checklists"
+//	@Failure		401	{object}	ErrorResponse		"Unauthorized"
+//	@Failure		403	{object}	ErrorResponse		"Forbidden - not a board member"
+//	@Failure		500	{object}	ErrorResponse		"Internal server error"
+//	@Router			/api/cards/{id}/checklists [get]
+func listChecklistsHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		cls, err := svc.ListByCard(c.Request.Context(), userID, int32(cardID))
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, cls)
+	}
+}
+
+// createChecklistHandler adds a checklist to a card
+//
+//	@Summary		Create a checklist
+//	@Description	Append a new, empty checklist to a card
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"Card ID"
+//	@Param			request	body		CreateChecklistRequest	true	"Checklist details"
+//	@Success		201		{object}	ChecklistResponse		"Checklist created successfully"
+//	@Failure		400		{object}	ErrorResponse			"Invalid request"
+//	@Failure		401		{object}	ErrorResponse			"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse			"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/cards/{id}/checklists [post]
+func createChecklistHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		var req CreateChecklistRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		userID := int32(c.GetInt("userID"))
+		cl, err := svc.Create(c.Request.Context(), userID, int32(cardID), req.Title)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, cl)
+	}
+}
+
+// renameChecklistHandler renames a checklist
+//
+//	@Summary		Rename checklist
+//	@Description	Change the title of a checklist
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"Checklist ID"
+//	@Param			request	body		RenameChecklistRequest	true	"New title"
+//	@Success		200		{object}	ChecklistSummaryResponse	"Checklist renamed successfully"
+//	@Failure		400		{object}	ErrorResponse			"Invalid request"
+//	@Failure		401		{object}	ErrorResponse			"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse			"Forbidden - not a board member"
+//	@Failure		404		{object}	ErrorResponse			"Checklist not found"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/checklists/{id} [put]
+func renameChecklistHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		var req RenameChecklistRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		cl, err := svc.Rename(c.Request.Context(), userID, int32(id), req.Title)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, cl)
+	}
+}
+
+// deleteChecklistHandler deletes a checklist
+//
+//	@Summary		Delete checklist
+//	@Description	Delete a checklist together with all of its items
+//	@Tags			Checklists
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int				true	"Checklist ID"
+//	@Success		200	{object}	MessageResponse	"Checklist deleted successfully"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		404	{object}	ErrorResponse	"Checklist not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/checklists/{id} [delete]
+func deleteChecklistHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		if err := svc.Delete(c.Request.Context(), userID, int32(id)); err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "checklist deleted"})
+	}
+}
+
+// createItemHandler adds an item to a checklist
+//
+//	@Summary		Create a checklist item
+//	@Description	Insert an item at the given position, or at the end when position is omitted
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"Checklist ID"
+//	@Param			request	body		CreateItemRequest		true	"Item details"
+//	@Success		201		{object}	ChecklistItemResponse	"Item created successfully"
+//	@Failure		400		{object}	ErrorResponse			"Invalid request"
+//	@Failure		401		{object}	ErrorResponse			"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse			"Forbidden - not a board member"
+//	@Failure		404		{object}	ErrorResponse			"Checklist not found"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/checklists/{id}/items [post]
+func createItemHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		var req CreateItemRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		userID := int32(c.GetInt("userID"))
+		item, err := svc.AddItem(c.Request.Context(), userID, int32(id), req.Title, req.Position)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, item)
+	}
+}
+
+// updateItemHandler renames or checks off a checklist item
+//
+//	@Summary		Update checklist item
+//	@Description	Change the title and/or the done flag of an item; omitted fields are left unchanged
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"Item ID"
+//	@Param			request	body		UpdateItemRequest		true	"Item update details"
+//	@Success		200		{object}	ChecklistItemResponse	"Item updated successfully"
+//	@Failure		400		{object}	ErrorResponse			"Invalid request"
+//	@Failure		401		{object}	ErrorResponse			"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse			"Forbidden - not a board member"
+//	@Failure		404		{object}	ErrorResponse			"Item not found"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/checklist-items/{id} [put]
+func updateItemHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		var req UpdateItemRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		item, err := svc.UpdateItem(c.Request.Context(), userID, int32(id), req.Title, req.Done)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+// moveItemHandler reorders a checklist item
+//
+//	@Summary		Move checklist item
+//	@Description	Move an item to a new position, possibly into another checklist of the same card
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"Item ID"
+//	@Param			request	body		MoveItemRequest			true	"Target checklist and position"
+//	@Success		200		{object}	ChecklistItemResponse	"Item moved successfully"
+//	@Failure		400		{object}	ErrorResponse			"Invalid request"
+//	@Failure		401		{object}	ErrorResponse			"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse			"Forbidden - not a board member"
+//	@Failure		404		{object}	ErrorResponse			"Item or checklist not found"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/checklist-items/{id}/move [put]
+func moveItemHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		var req MoveItemRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		item, err := svc.MoveItem(c.Request.Context(), userID, int32(id), req.ChecklistID, req.Position)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+// convertItemHandler turns a checklist item into a card
+//
+//	@Summary		Convert checklist item to card
+//	@Description	Create a card from the item at the end of the given list (the card's own list by default) and remove the item
+//	@Tags			Checklists
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int					true	"Item ID"
+//	@Param			request	body		ConvertItemRequest	false	"Target list"
+//	@Success		201		{object}	CardResponse		"Card created successfully"
+//	@Failure		400		{object}	ErrorResponse		"Invalid request"
+//	@Failure		401		{object}	ErrorResponse		"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse		"Forbidden - not a board member"
+//	@Failure		404		{object}	ErrorResponse		"Item not found"
+//	@Failure		500		{object}	ErrorResponse		"Internal server error"
+//	@Router			/api/checklist-items/{id}/convert [post]
+func convertItemHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		var req ConvertItemRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+		card, err := svc.ConvertItem(c.Request.Context(), userID, int32(id), req.ListID)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, card)
+	}
+}
+
+// deleteItemHandler deletes a checklist item
+//
+//	@Summary		Delete checklist item
+//	@Description	Delete an item; the following items move up
+//	@Tags			Checklists
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int				true	"Item ID"
+//	@Success		200	{object}	MessageResponse	"Item deleted successfully"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		404	{object}	ErrorResponse	"Item not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/checklist-items/{id} [delete]
+func deleteItemHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		if err := svc.DeleteItem(c.Request.Context(), userID, int32(id)); err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "checklist item deleted"})
+	}
+}
@@ -0,0 +1,29 @@
+// internal/checklists/position_test.go
+package checklists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClampPosition(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  int32
+		last int32
+		want int32
+	}{
+		{"EmptyChecklist", 0, 1, 1},
+		{"Omitted", 0, 4, 4},
+		{"First", 1, 4, 1},
+		{"Middle", 2, 4, 2},
+		{"Last", 4, 4, 4},
+		{"PastEnd", 9, 4, 4},
+		{"Negative", -1, 4, 4},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, clampPosition(tc.pos, tc.last), tc.name)
+	}
+}
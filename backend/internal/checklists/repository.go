@@ -0,0 +1,56 @@
+package checklists
+
+import (
+	"context"
+
+	db "backend/internal/db/sqlc"
+)
+
+type Repository struct{ q *db.Queries }
+
+func NewRepository(q *db.Queries) *Repository { return &Repository{q: q} }
+
+func (r *Repository) Create(ctx context.Context, arg db.CreateChecklistParams) (db.CardChecklist, error) {
+	return r.q.CreateChecklist(ctx, arg)
+}
+func (r *Repository) Get(ctx context.Context, id int32) (db.CardChecklist, error) {
+	return r.q.GetChecklistByID(ctx, id)
+}
+func (r *Repository) ListByCard(ctx context.Context, cardID int32) ([]db.CardChecklist, error) {
+	return r.q.ListChecklistsByCard(ctx, cardID)
+}
+func (r *Repository) CountByCard(ctx context.Context, cardID int32) (int32, error) {
+	return r.q.CountChecklistsByCard(ctx, cardID)
+}
+func (r *Repository) Rename(ctx context.Context, arg db.UpdateChecklistTitleParams) (db.CardChecklist, error) {
+	return r.q.UpdateChecklistTitle(ctx, arg)
+}
+func (r *Repository) Delete(ctx context.Context, id int32) error { return r.q.DeleteChecklist(ctx, id) }
+func (r *Repository) ShiftLeft(ctx context.Context, cardID, from int32) error {
+	return r.q.DecChecklistPosAfter(ctx, db.DecChecklistPosAfterParams{CardID: cardID, Position: from})
+}
+
+func (r *Repository) CreateItem(ctx context.Context, arg db.CreateChecklistItemParams) (db.ChecklistItem, error) {
+	return r.q.CreateChecklistItem(ctx, arg)
+}
+func (r *Repository) GetItem(ctx context.Context, id int32) (db.ChecklistItem, error) {
+	return r.q.GetChecklistItemByID(ctx, id)
+}
+func (r *Repository) ListItemsByCard(ctx context.Context, cardID int32) ([]db.ChecklistItem, error) {
+	return r.q.ListChecklistItemsByCard(ctx, cardID)
+}
+func (r *Repository) CountItems(ctx context.Context, checklistID int32) (int32, error) {
+	return r.q.CountChecklistItems(ctx, checklistID)
+}
+func (r *Repository) UpdateItem(ctx context.Context, arg db.UpdateChecklistItemParams) (db.ChecklistItem, error) {
+	return r.q.UpdateChecklistItem(ctx, arg)
+}
+func (r *Repository) DeleteItem(ctx context.Context, id int32) error {
+	return r.q.DeleteChecklistItem(ctx, id)
+}
+func (r *Repository) ShiftItemsRight(ctx context.Context, checklistID, from int32) error {
+	return r.q.IncChecklistItemPosAfter(ctx, db.IncChecklistItemPosAfterParams{ChecklistID: checklistID, Position: from})
+}
+func (r *Repository) ShiftItemsLeft(ctx context.Context, checklistID, from int32) error {
+	return r.q.DecChecklistItemPosAfter(ctx, db.DecChecklistItemPosAfterParams{ChecklistID: checklistID, Position: from})
+}
@@ -0,0 +1,376 @@
+package checklists
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"backend/internal/cards"
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/websocket"
+)
+
+type Service struct {
+	repo  *Repository
+	q     *db.Queries // for cross‑repo checks
+	hub   *websocket.Hub
+	cards *cards.Service
+}
+
+func NewService(repo *Repository, q *db.Queries, hub *websocket.Hub, cardsSvc *cards.Service) *Service {
+	return &Service{repo: repo, q: q, hub: hub, cards: cardsSvc}
+}
+
+var (
+	ErrNotMember         = errors.New("not a member")
+	ErrEmptyTitle        = errors.New("title is required")
+	ErrOtherCard         = errors.New("checklist belongs to another card")
+	ErrListNotOnBoard    = errors.New("list belongs to another board")
+	ErrChecklistNotFound = errors.New("checklist not found")
+	ErrItemNotFound      = errors.New("checklist item not found")
+)
+
+// Checklist is a checklist together with its items in position order.
+type Checklist struct {
+	db.CardChecklist
+	Items []db.ChecklistItem
+}
+
+// clampPosition keeps a requested 1-based position inside 1..last, where
+// last is the position right after the final item. Positions outside the
+// range, including zero for "not given", mean "at the end".
+func clampPosition(pos, last int32) int32 {
+	if pos < 1 || pos > last {
+		return last
+	}
+	return pos
+}
+
+func normalizeTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", ErrEmptyTitle
+	}
+	return title, nil
+}
+
+// cardBoard returns the board of a card after checking that userID is a
+// member of it.
+func (s *Service) cardBoard(ctx context.Context, userID, cardID int32) (db.Card, int32, error) {
+	card, err := s.q.GetCardByID(ctx, cardID)
+	if err != nil {
+		return db.Card{}, 0, err
+	}
+	lst, err := s.q.GetListByID(ctx, card.ListID)
+	if err != nil {
+		return db.Card{}, 0, err
+	}
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: lst.BoardID, UserID: userID,
+	}); err != nil {
+		return db.Card{}, 0, ErrNotMember
+	}
+	return card, lst.BoardID, nil
+}
+
+// checklistContext loads a checklist and the board it belongs to.
+func (s *Service) checklistContext(ctx context.Context, userID, checklistID int32) (db.CardChecklist, int32, error) {
+	cl, err := s.repo.Get(ctx, checklistID)
+	if err != nil {
+		return db.CardChecklist{}, 0, ErrChecklistNotFound
+	}
+	_, boardID, err := s.cardBoard(ctx, userID, cl.CardID)
+	if err != nil {
+		return db.CardChecklist{}, 0, err
+	}
+	return cl, boardID, nil
+}
+
+// itemContext loads a checklist item with its checklist and board.
+func (s *Service) itemContext(ctx context.Context, userID, itemID int32) (db.ChecklistItem, db.CardChecklist, int32, error) {
+	item, err := s.repo.GetItem(ctx, itemID)
+	if err != nil {
+		return db.ChecklistItem{}, db.CardChecklist{}, 0, ErrItemNotFound
+	}
+	cl, boardID, err := s.checklistContext(ctx, userID, item.ChecklistID)
+	if err != nil {
+		return db.ChecklistItem{}, db.CardChecklist{}, 0, err
+	}
+	return item, cl, boardID, nil
+}
+
+// refreshCard pushes the card with its new checklist progress. A failure
+// here must not fail the checklist operation that already succeeded.
+func (s *Service) refreshCard(ctx context.Context, boardID, cardID int32) {
+	if _, err := s.cards.Refresh(ctx, boardID, cardID); err != nil {
+		logger.WithContext(ctx).Error("Failed to refresh card after checklist change",
+			"card_id", cardID,
+			"error", err,
+		)
+	}
+}
+
+func (s *Service) ListByCard(ctx context.Context, userID, cardID int32) ([]Checklist, error) {
+	if _, _, err := s.cardBoard(ctx, userID, cardID); err != nil {
+		return nil, err
+	}
+	cls, err := s.repo.ListByCard(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.ListItemsByCard(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Checklist, len(cls))
+	index := make(map[int32]int, len(cls))
+	for i, cl := range cls {
+		out[i] = Checklist{CardChecklist: cl, Items: []db.ChecklistItem{}}
+		index[cl.ID] = i
+	}
+	for _, it := range items {
+		i := index[it.ChecklistID]
+		out[i].Items = append(out[i].Items, it)
+	}
+	return out, nil
+}
+
+// Create appends a new checklist to the card.
+func (s *Service) Create(ctx context.Context, userID, cardID int32, title string) (Checklist, error) {
+	_, boardID, err := s.cardBoard(ctx, userID, cardID)
+	if err != nil {
+		return Checklist{}, err
+	}
+	title, err = normalizeTitle(title)
+	if err != nil {
+		return Checklist{}, err
+	}
+	n, err := s.repo.CountByCard(ctx, cardID)
+	if err != nil {
+		return Checklist{}, err
+	}
+	cl, err := s.repo.Create(ctx, db.CreateChecklistParams{CardID: cardID, Title: title, Position: n + 1})
+	if err != nil {
+		logger.WithContext(ctx).Error("Failed to create checklist",
+			"user_id", userID,
+			"card_id", cardID,
+			"error", err,
+		)
+		return Checklist{}, err
+	}
+	out := Checklist{CardChecklist: cl, Items: []db.ChecklistItem{}}
+	s.hub.Broadcast(boardID, websocket.EventMessage{Event: "checklist_created", Data: out})
+	return out, nil
+}
+
+func (s *Service) Rename(ctx context.Context, userID, checklistID int32, title string) (db.CardChecklist, error) {
+	cl, boardID, err := s.checklistContext(ctx, userID, checklistID)
+	if err != nil {
+		return db.CardChecklist{}, err
+	}
+	title, err = normalizeTitle(title)
+	if err != nil {
+		return db.CardChecklist{}, err
+	}
+	updated, err := s.repo.Rename(ctx, db.UpdateChecklistTitleParams{ID: cl.ID, Title: title})
+	if err != nil {
+		return db.CardChecklist{}, err
+	}
+	s.hub.Broadcast(boardID, websocket.EventMessage{Event: "checklist_updated", Data: updated})
+	return updated, nil
+}
+
+// Delete removes a checklist with all of its items.
+func (s *Service) Delete(ctx context.Context, userID, checklistID int32) error {
+	cl, boardID, err := s.checklistContext(ctx, userID, checklistID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, cl.ID); err != nil {
+		return err
+	}
+	if err := s.repo.ShiftLeft(ctx, cl.CardID, cl.Position); err != nil {
+		return err
+	}
+	s.hub.Broadcast(boardID, websocket.EventMessage{
+		Event: "checklist_deleted", Data: map[string]int32{"id": cl.ID, "cardId": cl.CardID},
+	})
+	s.refreshCard(ctx, boardID, cl.CardID)
+	return nil
+}
+
+// AddItem inserts an item at position, shifting the following items down.
+// A zero position appends the item.
+func (s *Service) AddItem(ctx context.Context, userID, checklistID int32, title string, position int32) (db.ChecklistItem, error) {
+	cl, boardID, err := s.checklistContext(ctx, userID, checklistID)
+	if err != nil {
+		return db.ChecklistItem{}, err
+	}
+	title, err = normalizeTitle(title)
+	if err != nil {
+		return db.ChecklistItem{}, err
+	}
+	n, err := s.repo.CountItems(ctx, cl.ID)
+	if err != nil {
+		return db.ChecklistItem{}, err
+	}
+	position = clampPosition(position, n+1)
+	if err := s.repo.ShiftItemsRight(ctx, cl.ID, position); err != nil {
+		return db.ChecklistItem{}, err
+	}
+	item, err := s.repo.CreateItem(ctx, db.CreateChecklistItemParams{ChecklistID: cl.ID, Title: title, Position: position})
+	if err != nil {
+		return db.ChecklistItem{}, err
+	}
+	s.hub.Broadcast(boardID, websocket.EventMessage{Event: "checklist_item_created", Data: item})
+	s.refreshCard(ctx, boardID, cl.CardID)
+	return item, nil
+}
+
+// UpdateItem renames and/or checks an item. Nil arguments are left as is.
+func (s *Service) UpdateItem(ctx context.Context, userID, itemID int32, title *string, done *bool) (db.ChecklistItem, error) {
+	item, cl, boardID, err := s.itemContext(ctx, userID, itemID)
+	if err != nil {
+		return db.ChecklistItem{}, err
+	}
+	arg := db.UpdateChecklistItemParams{
+		ID:          item.ID,
+		Title:       item.Title,
+		Done:        item.Done,
+		Position:    item.Position,
+		ChecklistID: item.ChecklistID,
+	}
+	if title != nil {
+		if arg.Title, err = normalizeTitle(*title); err != nil {
+			return db.ChecklistItem{}, err
+		}
+	}
+	if done != nil {
+		arg.Done = *done
+	}
+	updated, err := s.repo.UpdateItem(ctx, arg)
+	if err != nil {
+		return db.ChecklistItem{}, err
+	}
+	s.hub.Broadcast(boardID, websocket.EventMessage{Event: "checklist_item_updated", Data: updated})
+	if updated.Done != item.Done {
+		s.refreshCard(ctx, boardID, cl.CardID)
+	}
+	return updated, nil
+}
+
+// MoveItem moves an item to newPos inside dstChecklistID, which must be a
+// checklist of the same card. Positions stay contiguous in both checklists.
+func (s *Service) MoveItem(ctx context.Context, userID, itemID, dstChecklistID, newPos int32) (db.ChecklistItem, error) {
+	item, src, boardID, err := s.itemContext(ctx, userID, itemID)
+	if err != nil {
+		return db.ChecklistItem{}, err
+	}
+	dst := src
+	if dstChecklistID != src.ID {
+		if dst, err = s.repo.Get(ctx, dstChecklistID); err != nil {
+			return db.ChecklistItem{}, ErrChecklistNotFound
+		}
+		if dst.CardID != src.CardID {
+			return db.ChecklistItem{}, ErrOtherCard
+		}
+	}
+
+	if err := s.repo.ShiftItemsLeft(ctx, src.ID, item.Position); err != nil {
+		return db.ChecklistItem{}, err
+	}
+	n, err := s.repo.CountItems(ctx, dst.ID)
+	if err != nil {
+		return db.ChecklistItem{}, err
+	}
+	if dst.ID == src.ID {
+		n-- // the moved item is still counted at its old position
+	}
+	newPos = clampPosition(newPos, n+1)
+	if err := s.repo.ShiftItemsRight(ctx, dst.ID, newPos); err != nil {
+		return db.ChecklistItem{}, err
+	}
+
+	updated, err := s.repo.UpdateItem(ctx, db.UpdateChecklistItemParams{
+		ID:          item.ID,
+		Title:       item.Title,
+		Done:        item.Done,
+		Position:    newPos,
+		ChecklistID: dst.ID,
+	})
+	if err != nil {
+		return db.ChecklistItem{}, err
+	}
+	s.hub.Broadcast(boardID, websocket.EventMessage{
+		Event: "checklist_item_moved",
+		Data: map[string]any{
+			"itemId":          updated.ID,
+			"fromChecklistId": src.ID,
+			"toChecklistId":   updated.ChecklistID,
+			"toPos":           updated.Position,
+		},
+	})
+	return updated, nil
+}
+
+// removeItem deletes an item and closes the gap it leaves.
+func (s *Service) removeItem(ctx context.Context, boardID int32, item db.ChecklistItem, cl db.CardChecklist) error {
+	if err := s.repo.DeleteItem(ctx, item.ID); err != nil {
+		return err
+	}
+	if err := s.repo.ShiftItemsLeft(ctx, item.ChecklistID, item.Position); err != nil {
+		return err
+	}
+	s.hub.Broadcast(boardID, websocket.EventMessage{
+		Event: "checklist_item_deleted", Data: map[string]int32{"id": item.ID, "checklistId": item.ChecklistID},
+	})
+	s.refreshCard(ctx, boardID, cl.CardID)
+	return nil
+}
+
+func (s *Service) DeleteItem(ctx context.Context, userID, itemID int32) error {
+	item, cl, boardID, err := s.itemContext(ctx, userID, itemID)
+	if err != nil {
+		return err
+	}
+	return s.removeItem(ctx, boardID, item, cl)
+}
+
+// ConvertItem turns an item into a card at the end of listID, or of the
+// list holding the item's card when listID is nil, and removes the item.
+func (s *Service) ConvertItem(ctx context.Context, userID, itemID int32, listID *int32) (db.Card, error) {
+	item, cl, boardID, err := s.itemContext(ctx, userID, itemID)
+	if err != nil {
+		return db.Card{}, err
+	}
+	card, err := s.q.GetCardByID(ctx, cl.CardID)
+	if err != nil {
+		return db.Card{}, err
+	}
+	dstListID := card.ListID
+	if listID != nil {
+		lst, err := s.q.GetListByID(ctx, *listID)
+		if err != nil {
+			return db.Card{}, err
+		}
+		if lst.BoardID != boardID {
+			return db.Card{}, ErrListNotOnBoard
+		}
+		dstListID = lst.ID
+	}
+
+	cs, err := s.q.ListCardsByList(ctx, dstListID)
+	if err != nil {
+		return db.Card{}, err
+	}
+	newCard, err := s.cards.Create(ctx, userID, dstListID, item.Title, "", int32(len(cs))+1)
+	if err != nil {
+		return db.Card{}, err
+	}
+	if err := s.removeItem(ctx, boardID, item, cl); err != nil {
+		return db.Card{}, err
+	}
+	return newCard, nil
+}
@@ -0,0 +1,65 @@
+package collab
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// HistoryLimit is how many past operations a document keeps for
+// transforming late operations. Clients further behind must reopen it.
+const HistoryLimit = 500
+
+var ErrStaleRevision = errors.New("revision is too old or unknown, reopen the document")
+
+// document is the server copy of one card description. Every accepted
+// operation advances revision by one; history holds the operations that led
+// to revisions base+1 … revision.
+type document struct {
+	mu       sync.Mutex
+	cardID   int32
+	boardID  int32
+	text     []rune
+	revision int32
+	base     int32
+	history  []Op
+	// saved is the revision last written to cards.description.
+	saved    int32
+	lastUsed time.Time
+	// loaded is set once the text has been read; evicted once the document
+	// has been dropped from the service and must not be used any more.
+	loaded, evicted bool
+}
+
+// transform rebases op, made against revision rev, onto the current text.
+func (d *document) transform(rev int32, op Op) (Op, error) {
+	if rev < d.base || rev > d.revision {
+		return Op{}, ErrStaleRevision
+	}
+	for _, h := range d.history[rev-d.base:] {
+		var err error
+		if op, _, err = Transform(op, h); err != nil {
+			return Op{}, err
+		}
+	}
+	if op.BaseLen() != len(d.text) {
+		return Op{}, ErrLengthMismatch
+	}
+	return op, nil
+}
+
+// push applies an operation that already matches the current text.
+func (d *document) push(op Op) error {
+	text, err := op.Apply(d.text)
+	if err != nil {
+		return err
+	}
+	d.text = text
+	d.revision++
+	d.history = append(d.history, op)
+	if over := len(d.history) - HistoryLimit; over > 0 {
+		d.history = append([]Op(nil), d.history[over:]...)
+		d.base += int32(over)
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package collab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidOp      = errors.New("invalid operation")
+	ErrLengthMismatch = errors.New("operation does not match the document length")
+)
+
+// Op is a text operation in the format used by ot.js: a sequence of
+// components that walks over the whole document. A positive number retains
+// that many characters, a negative number deletes them and a string inserts
+// it. Lengths are counted in Unicode code points.
+//
+//	[5, "abc", -2, 10]
+type Op struct {
+	comps []component
+	// base is the length of the document the operation applies to, target
+	// the length of the result.
+	base, target int
+}
+
+// component is one step of an operation: n > 0 retains, n < 0 deletes,
+// otherwise s is inserted.
+type component struct {
+	n int
+	s string
+}
+
+func (c component) isRetain() bool { return c.n > 0 }
+func (c component) isDelete() bool { return c.n < 0 }
+func (c component) isInsert() bool { return c.n == 0 }
+
+// Retain skips over n characters.
+func (o *Op) Retain(n int) *Op {
+	if n <= 0 {
+		return o
+	}
+	o.base += n
+	o.target += n
+	if last := len(o.comps) - 1; last >= 0 && o.comps[last].isRetain() {
+		o.comps[last].n += n
+	} else {
+		o.comps = append(o.comps, component{n: n})
+	}
+	return o
+}
+
+// Insert inserts s at the current position.
+func (o *Op) Insert(s string) *Op {
+	if s == "" {
+		return o
+	}
+	o.target += utf8.RuneCountInString(s)
+	last := len(o.comps) - 1
+	switch {
+	case last >= 0 && o.comps[last].isInsert():
+		o.comps[last].s += s
+	case last >= 0 && o.comps[last].isDelete():
+		// Keep inserts before deletes so equal operations look the same.
+		if last > 0 && o.comps[last-1].isInsert() {
+			o.comps[last-1].s += s
+		} else {
+			o.comps = append(o.comps, o.comps[last])
+			o.comps[last] = component{s: s}
+		}
+	default:
+		o.comps = append(o.comps, component{s: s})
+	}
+	return o
+}
+
+// Delete removes the next n characters.
+func (o *Op) Delete(n int) *Op {
+	if n <= 0 {
+		return o
+	}
+	o.base += n
+	if last := len(o.comps) - 1; last >= 0 && o.comps[last].isDelete() {
+		o.comps[last].n -= n
+	} else {
+		o.comps = append(o.comps, component{n: -n})
+	}
+	return o
+}
+
+// BaseLen is the length of the document the operation applies to.
+func (o Op) BaseLen() int { return o.base }
+
+// TargetLen is the length of the document after the operation.
+func (o Op) TargetLen() int { return o.target }
+
+// IsNoop reports whether the operation leaves the document unchanged.
+func (o Op) IsNoop() bool {
+	return len(o.comps) == 0 || len(o.comps) == 1 && o.comps[0].isRetain()
+}
+
+// Apply applies the operation to doc.
+func (o Op) Apply(doc []rune) ([]rune, error) {
+	if len(doc) != o.base {
+		return nil, ErrLengthMismatch
+	}
+	out := make([]rune, 0, o.target)
+	i := 0
+	for _, c := range o.comps {
+		switch {
+		case c.isRetain():
+			out = append(out, doc[i:i+c.n]...)
+			i += c.n
+		case c.isDelete():
+			i -= c.n
+		default:
+			out = append(out, []rune(c.s)...)
+		}
+	}
+	return out, nil
+}
+
+// Transform takes two operations a and b made concurrently on the same
+// document and returns a' and b' such that applying b then a' gives the
+// same result as applying a then b'. When both insert at the same position
+// the insert of a comes first.
+func Transform(a, b Op) (Op, Op, error) {
+	if a.base != b.base {
+		return Op{}, Op{}, ErrLengthMismatch
+	}
+	var a1, b1 Op
+	as, bs := a.comps, b.comps
+	var ca, cb component
+	next := func(cs *[]component) (component, bool) {
+		if len(*cs) == 0 {
+			return component{}, false
+		}
+		c := (*cs)[0]
+		*cs = (*cs)[1:]
+		return c, true
+	}
+	okA, okB := false, false
+	ca, okA = next(&as)
+	cb, okB = next(&bs)
+	for okA || okB {
+		// Inserts go first; a wins ties.
+		if okA && ca.isInsert() {
+			a1.Insert(ca.s)
+			b1.Retain(utf8.RuneCountInString(ca.s))
+			ca, okA = next(&as)
+			continue
+		}
+		if okB && cb.isInsert() {
+			a1.Retain(utf8.RuneCountInString(cb.s))
+			b1.Insert(cb.s)
+			cb, okB = next(&bs)
+			continue
+		}
+		if !okA || !okB {
+			return Op{}, Op{}, ErrInvalidOp
+		}
+		la, lb := abs(ca.n), abs(cb.n)
+		n := min(la, lb)
+		switch {
+		case ca.isRetain() && cb.isRetain():
+			a1.Retain(n)
+			b1.Retain(n)
+		case ca.isDelete() && cb.isDelete():
+			// Both deleted the same characters.
+		case ca.isDelete() && cb.isRetain():
+			a1.Delete(n)
+		case ca.isRetain() && cb.isDelete():
+			b1.Delete(n)
+		}
+		if la == n {
+			ca, okA = next(&as)
+		} else {
+			ca.n = sign(ca.n) * (la - n)
+		}
+		if lb == n {
+			cb, okB = next(&bs)
+		} else {
+			cb.n = sign(cb.n) * (lb - n)
+		}
+	}
+	return a1, b1, nil
+}
+
+// MarshalJSON encodes the operation as a JSON array of components.
+func (o Op) MarshalJSON() ([]byte, error) {
+	out := make([]interface{}, len(o.comps))
+	for i, c := range o.comps {
+		if c.isInsert() {
+			out[i] = c.s
+		} else {
+			out[i] = c.n
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes an operation from a JSON array of components.
+func (o *Op) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return ErrInvalidOp
+	}
+	*o = Op{}
+	for _, r := range raw {
+		var s string
+		if err := json.Unmarshal(r, &s); err == nil {
+			if s == "" || !utf8.ValidString(s) {
+				return ErrInvalidOp
+			}
+			o.Insert(s)
+			continue
+		}
+		var n int
+		if err := json.Unmarshal(r, &n); err != nil || n == 0 {
+			return fmt.Errorf("%w: unexpected component %s", ErrInvalidOp, r)
+		}
+		if n > 0 {
+			o.Retain(n)
+		} else {
+			o.Delete(-n)
+		}
+	}
+	return nil
+}
+
+// Diff returns an operation that turns from into to by replacing the part
+// between their common prefix and suffix.
+func Diff(from, to []rune) Op {
+	p := 0
+	for p < len(from) && p < len(to) && from[p] == to[p] {
+		p++
+	}
+	s := 0
+	for s < len(from)-p && s < len(to)-p && from[len(from)-1-s] == to[len(to)-1-s] {
+		s++
+	}
+	var o Op
+	o.Retain(p)
+	o.Insert(string(to[p : len(to)-s]))
+	o.Delete(len(from) - p - s)
+	o.Retain(s)
+	return o
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	}
+	return 1
+}
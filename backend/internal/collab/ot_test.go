@@ -0,0 +1,257 @@
+// internal/collab/ot_test.go
+package collab
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"backend/internal/websocket"
+)
+
+// randomOp makes a random edit of doc.
+func randomOp(r *rand.Rand, doc []rune) Op {
+	var o Op
+	left := len(doc)
+	for left > 0 {
+		n := 1 + r.Intn(min(left, 5))
+		switch r.Intn(4) {
+		case 0:
+			o.Delete(n)
+		case 1:
+			o.Insert(randomText(r))
+			o.Retain(n)
+		default:
+			o.Retain(n)
+		}
+		left -= n
+	}
+	if r.Intn(2) == 0 {
+		o.Insert(randomText(r))
+	}
+	return o
+}
+
+func randomText(r *rand.Rand) string {
+	const letters = "abcxyzé日😀 "
+	rs := []rune(letters)
+	out := make([]rune, 1+r.Intn(3))
+	for i := range out {
+		out[i] = rs[r.Intn(len(rs))]
+	}
+	return string(out)
+}
+
+func TestOp_ApplyAndJSON(t *testing.T) {
+	var o Op
+	o.Retain(2).Insert("日本").Delete(1).Retain(1)
+	got, err := o.Apply([]rune("abcd"))
+	require.NoError(t, err)
+	assert.Equal(t, "ab日本d", string(got))
+
+	raw, err := json.Marshal(o)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[2, "日本", -1, 1]`, string(raw))
+
+	var back Op
+	require.NoError(t, json.Unmarshal(raw, &back))
+	assert.Equal(t, o, back)
+
+	_, err = o.Apply([]rune("abc"))
+	assert.ErrorIs(t, err, ErrLengthMismatch)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`[1, 0]`), &back), ErrInvalidOp)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`[1, {}]`), &back), ErrInvalidOp)
+}
+
+func TestDiff(t *testing.T) {
+	for _, tc := range [][2]string{{"hello world", "hello brave world"}, {"abc", ""}, {"", "x"}, {"same", "same"}} {
+		o := Diff([]rune(tc[0]), []rune(tc[1]))
+		got, err := o.Apply([]rune(tc[0]))
+		require.NoError(t, err)
+		assert.Equal(t, tc[1], string(got))
+	}
+}
+
+func TestTransform_Converges(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		doc := []rune(randomText(r) + randomText(r) + randomText(r))
+		a, b := randomOp(r, doc), randomOp(r, doc)
+		a1, b1, err := Transform(a, b)
+		require.NoError(t, err)
+
+		ab, err := a.Apply(doc)
+		require.NoError(t, err)
+		ab, err = b1.Apply(ab)
+		require.NoError(t, err)
+		ba, err := b.Apply(doc)
+		require.NoError(t, err)
+		ba, err = a1.Apply(ba)
+		require.NoError(t, err)
+		assert.Equal(t, string(ab), string(ba))
+	}
+}
+
+// simClient follows the usual OT client protocol: at most one operation in
+// flight, later local edits wait in a buffer and incoming operations are
+// transformed against both.
+type simClient struct {
+	doc      []rune
+	rev      int32
+	inflight *Op
+	buffer   []Op
+	outbox   []sent
+	inbox    []received
+}
+
+type sent struct {
+	rev int32
+	op  Op
+}
+
+type received struct {
+	op  Op
+	ack bool
+}
+
+func (c *simClient) edit(op Op) {
+	doc, err := op.Apply(c.doc)
+	if err != nil {
+		panic(err)
+	}
+	c.doc = doc
+	if c.inflight == nil {
+		c.inflight = &op
+		c.outbox = append(c.outbox, sent{c.rev, op})
+		return
+	}
+	c.buffer = append(c.buffer, op)
+}
+
+func (c *simClient) deliver() error {
+	m := c.inbox[0]
+	c.inbox = c.inbox[1:]
+	c.rev++
+	if m.ack {
+		c.inflight = nil
+		if len(c.buffer) > 0 {
+			next := c.buffer[0]
+			c.buffer = c.buffer[1:]
+			c.inflight = &next
+			c.outbox = append(c.outbox, sent{c.rev, next})
+		}
+		return nil
+	}
+	op := m.op
+	var err error
+	if c.inflight != nil {
+		var mine Op
+		if mine, op, err = Transform(*c.inflight, op); err != nil {
+			return err
+		}
+		c.inflight = &mine
+	}
+	for i := range c.buffer {
+		if c.buffer[i], op, err = Transform(c.buffer[i], op); err != nil {
+			return err
+		}
+	}
+	c.doc, err = op.Apply(c.doc)
+	return err
+}
+
+func TestDocument_ConcurrentClients(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		server := &document{text: []rune("shared description")}
+		clients := make([]*simClient, 4)
+		for i := range clients {
+			clients[i] = &simClient{doc: append([]rune(nil), server.text...)}
+		}
+
+		// Interleave local edits, server processing and delivery at random.
+		for step := 0; step < 400; step++ {
+			c := clients[r.Intn(len(clients))]
+			switch r.Intn(3) {
+			case 0:
+				c.edit(randomOp(r, c.doc))
+			case 1:
+				if len(c.outbox) == 0 {
+					continue
+				}
+				m := c.outbox[0]
+				c.outbox = c.outbox[1:]
+				op, err := server.transform(m.rev, m.op)
+				require.NoError(t, err)
+				require.NoError(t, server.push(op))
+				for _, o := range clients {
+					o.inbox = append(o.inbox, received{op: op, ack: o == c})
+				}
+			case 2:
+				if len(c.inbox) > 0 {
+					require.NoError(t, c.deliver())
+				}
+			}
+		}
+
+		// Drain everything.
+		for busy := true; busy; {
+			busy = false
+			for _, c := range clients {
+				for len(c.inbox) > 0 {
+					require.NoError(t, c.deliver())
+					busy = true
+				}
+				for len(c.outbox) > 0 {
+					m := c.outbox[0]
+					c.outbox = c.outbox[1:]
+					op, err := server.transform(m.rev, m.op)
+					require.NoError(t, err)
+					require.NoError(t, server.push(op))
+					for _, o := range clients {
+						o.inbox = append(o.inbox, received{op: op, ack: o == c})
+					}
+					busy = true
+				}
+			}
+		}
+		for i, c := range clients {
+			assert.Equal(t, string(server.text), string(c.doc), "seed %d client %d", seed, i)
+			assert.Equal(t, server.revision, c.rev)
+		}
+	}
+}
+
+func TestDocument_History(t *testing.T) {
+	d := &document{text: []rune("")}
+	for i := 0; i < HistoryLimit+10; i++ {
+		var o Op
+		o.Retain(len(d.text)).Insert("x")
+		require.NoError(t, d.push(o))
+	}
+	assert.Equal(t, int32(10), d.base)
+	var o Op
+	o.Insert("y")
+	_, err := d.transform(5, o)
+	assert.ErrorIs(t, err, ErrStaleRevision)
+	_, err = d.transform(d.revision+1, o)
+	assert.ErrorIs(t, err, ErrStaleRevision)
+}
+
+// An operation that writes a description of the longest allowed length must
+// fit in a WebSocket command, even if every character needs escaping.
+func TestMaxLength_FitsCommand(t *testing.T) {
+	var o Op
+	o.Insert(strings.Repeat("\x01", MaxLength))
+	raw, err := json.Marshal(map[string]interface{}{
+		"id":      "42",
+		"command": "description.op",
+		"data":    map[string]interface{}{"cardId": 7, "revision": 12, "opId": "c1-5", "op": o},
+	})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(raw), websocket.MaxMessageSize)
+}
@@ -0,0 +1,322 @@
+// Package collab implements simultaneous editing of card descriptions with
+// operational transformation. Clients send operations over the board
+// WebSocket; the server rebases them onto the latest text, logs them in
+// Postgres, which fixes their order across instances, and relays them to
+// the board. The merged text is saved to cards.description periodically.
+package collab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"backend/internal/cards"
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/websocket"
+)
+
+const (
+	// MaxLength bounds a description, in Unicode code points.
+	MaxLength = 100_000
+	// IdleTimeout is how long a saved document stays in memory unused.
+	IdleTimeout = 10 * time.Minute
+	// appendAttempts bounds retries when other instances keep taking the
+	// next revision first.
+	appendAttempts = 3
+)
+
+var (
+	ErrCardNotFound = errors.New("card not found")
+	ErrTooLong      = errors.New("description is too long")
+	ErrBusy         = errors.New("document is busy, retry")
+)
+
+// State is what a client needs to start editing a description.
+type State struct {
+	CardID   int32  `json:"cardId"`
+	Revision int32  `json:"revision"`
+	Text     string `json:"text"`
+}
+
+// OpEvent is the data of a description_op event. OpID is chosen by the
+// client that sent the operation so it can recognise its own.
+type OpEvent struct {
+	CardID   int32  `json:"cardId"`
+	Revision int32  `json:"revision"`
+	UserID   int32  `json:"userId"`
+	OpID     string `json:"opId,omitempty"`
+	Op       Op     `json:"op"`
+}
+
+type Service struct {
+	q     *db.Queries
+	hub   *websocket.Hub
+	cards *cards.Service
+
+	mu   sync.Mutex
+	docs map[int32]*document
+}
+
+func NewService(q *db.Queries, hub *websocket.Hub, cardsSvc *cards.Service) *Service {
+	return &Service{q: q, hub: hub, cards: cardsSvc, docs: make(map[int32]*document)}
+}
+
+// acquire returns the document of a card, loaded and locked. base replaces
+// the stored description as the starting text when the document has to be
+// loaded, for callers that have just overwritten it.
+func (s *Service) acquire(ctx context.Context, cardID int32, base *string) (*document, error) {
+	for {
+		s.mu.Lock()
+		d := s.docs[cardID]
+		if d == nil {
+			d = &document{cardID: cardID}
+			s.docs[cardID] = d
+		}
+		s.mu.Unlock()
+
+		d.mu.Lock()
+		if d.evicted {
+			d.mu.Unlock()
+			continue
+		}
+		if !d.loaded {
+			if err := s.load(ctx, d, base); err != nil {
+				d.evicted = true
+				s.mu.Lock()
+				delete(s.docs, cardID)
+				s.mu.Unlock()
+				d.mu.Unlock()
+				return nil, err
+			}
+		}
+		d.lastUsed = time.Now()
+		return d, nil
+	}
+}
+
+// load reads the saved description and replays the operations logged
+// after it.
+func (s *Service) load(ctx context.Context, d *document, base *string) error {
+	st, err := s.q.GetCardDescriptionState(ctx, d.cardID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrCardNotFound
+	}
+	if err != nil {
+		return err
+	}
+	text := st.Description.String
+	if base != nil {
+		text = *base
+	}
+	d.boardID = st.BoardID
+	d.text = []rune(text)
+	d.revision, d.base, d.saved = st.Revision, st.Revision, st.Revision
+	d.history = nil
+	if err := s.catchUp(ctx, d); err != nil {
+		return err
+	}
+	d.loaded = true
+	return nil
+}
+
+// catchUp applies operations that other instances logged since the
+// document was last read.
+func (s *Service) catchUp(ctx context.Context, d *document) error {
+	rows, err := s.q.ListCardDescriptionOpsSince(ctx, db.ListCardDescriptionOpsSinceParams{
+		CardID: d.cardID, Revision: d.revision,
+	})
+	if err != nil {
+		return err
+	}
+	if len(rows) > 0 && rows[0].Revision != d.revision+1 {
+		// Pruned while this copy was far behind; start over from the save.
+		return s.load(ctx, d, nil)
+	}
+	for _, r := range rows {
+		if r.Revision != d.revision+1 {
+			return fmt.Errorf("card %d: operation log has a gap at revision %d", d.cardID, d.revision+1)
+		}
+		var op Op
+		if err := json.Unmarshal(r.Op, &op); err != nil {
+			return err
+		}
+		if err := d.push(op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commit logs op, which must match the current text, under the next
+// revision and applies it. It reports false if another instance took that
+// revision first.
+func (s *Service) commit(ctx context.Context, d *document, userID int32, op Op) (bool, error) {
+	if op.TargetLen() > MaxLength {
+		return false, ErrTooLong
+	}
+	raw, err := json.Marshal(op)
+	if err != nil {
+		return false, err
+	}
+	n, err := s.q.AppendCardDescriptionOp(ctx, db.AppendCardDescriptionOpParams{
+		CardID:   d.cardID,
+		Revision: d.revision + 1,
+		UserID:   pgtype.Int4{Int32: userID, Valid: userID != 0},
+		Op:       raw,
+	})
+	if err != nil || n == 0 {
+		return false, err
+	}
+	return true, d.push(op)
+}
+
+// Open returns the current text of a card description for editing.
+func (s *Service) Open(ctx context.Context, boardID, cardID int32) (State, error) {
+	d, err := s.acquire(ctx, cardID, nil)
+	if err != nil {
+		return State{}, err
+	}
+	defer d.mu.Unlock()
+	if d.boardID != boardID {
+		return State{}, ErrCardNotFound
+	}
+	if err := s.catchUp(ctx, d); err != nil {
+		return State{}, err
+	}
+	return State{CardID: cardID, Revision: d.revision, Text: string(d.text)}, nil
+}
+
+// Submit applies an operation a client made against revision rev and
+// returns the revision it was assigned. The rebased operation is sent to
+// the board as description_op.
+func (s *Service) Submit(ctx context.Context, userID, boardID, cardID, rev int32, opID string, op Op) (int32, error) {
+	d, err := s.acquire(ctx, cardID, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer d.mu.Unlock()
+	if d.boardID != boardID {
+		return 0, ErrCardNotFound
+	}
+	for i := 0; i < appendAttempts; i++ {
+		if err := s.catchUp(ctx, d); err != nil {
+			return 0, err
+		}
+		t, err := d.transform(rev, op)
+		if err != nil {
+			return 0, err
+		}
+		ok, err := s.commit(ctx, d, userID, t)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			// Sent while holding the document so events leave in revision order.
+			s.hub.Notify(boardID, websocket.EventMessage{Event: "description_op", Data: OpEvent{
+				CardID: cardID, Revision: d.revision, UserID: userID, OpID: opID, Op: t,
+			}})
+			return d.revision, nil
+		}
+	}
+	return 0, ErrBusy
+}
+
+// Replace records a description written through the REST API as an
+// operation, so open editors converge on it. The cards service calls it
+// after the new text has been stored; old is the text it replaced.
+func (s *Service) Replace(ctx context.Context, userID, boardID, cardID int32, old, text string) {
+	d, err := s.acquire(ctx, cardID, &old)
+	if err != nil {
+		logger.Error("Failed to load card description", "card_id", cardID, "error", err)
+		return
+	}
+	defer d.mu.Unlock()
+	for i := 0; i < appendAttempts; i++ {
+		if err := s.catchUp(ctx, d); err != nil {
+			logger.Error("Failed to load card description", "card_id", cardID, "error", err)
+			return
+		}
+		op := Diff(d.text, []rune(text))
+		ok, err := s.commit(ctx, d, userID, op)
+		if err != nil {
+			logger.Error("Failed to record card description change", "card_id", cardID, "error", err)
+			return
+		}
+		if !ok {
+			continue
+		}
+		// cards.description already holds the new text, unless a Flush of
+		// the previous revision overwrote it before the document was
+		// locked here. Saving under the new revision puts it back.
+		s.save(ctx, d)
+		s.hub.Notify(boardID, websocket.EventMessage{Event: "description_op", Data: OpEvent{
+			CardID: cardID, Revision: d.revision, UserID: userID, Op: op,
+		}})
+		return
+	}
+	logger.Warn("Gave up recording card description change", "card_id", cardID)
+}
+
+// Flush saves the edited descriptions to cards.description, announces them
+// as card_updated and drops documents that have been idle for a while.
+func (s *Service) Flush(ctx context.Context) {
+	s.mu.Lock()
+	docs := make([]*document, 0, len(s.docs))
+	for _, d := range s.docs {
+		docs = append(docs, d)
+	}
+	s.mu.Unlock()
+
+	for _, d := range docs {
+		d.mu.Lock()
+		saved := s.save(ctx, d)
+		if d.loaded && d.revision == d.saved && time.Since(d.lastUsed) > IdleTimeout {
+			d.evicted = true
+			s.mu.Lock()
+			delete(s.docs, d.cardID)
+			s.mu.Unlock()
+		}
+		d.mu.Unlock()
+
+		if saved {
+			if _, err := s.cards.Refresh(ctx, d.boardID, d.cardID); err != nil {
+				logger.Error("Failed to announce saved card description", "card_id", d.cardID, "error", err)
+			}
+		}
+	}
+}
+
+// save writes the text of d if it changed since the last save. It reports
+// whether cards.description was updated; another instance may have saved a
+// later revision already. Caller holds d.mu.
+func (s *Service) save(ctx context.Context, d *document) bool {
+	if !d.loaded || d.revision == d.saved {
+		return false
+	}
+	n, err := s.q.SaveCardDescription(ctx, db.SaveCardDescriptionParams{
+		CardID:      d.cardID,
+		Revision:    d.revision,
+		Description: pgtype.Text{String: string(d.text), Valid: true},
+	})
+	if err != nil {
+		logger.Error("Failed to save card description", "card_id", d.cardID, "error", err)
+		return false
+	}
+	d.saved = d.revision
+	// Keep enough of the log for documents loaded from an older save.
+	if old := d.revision - HistoryLimit; old > 0 {
+		if err := s.q.PruneCardDescriptionOps(ctx, db.PruneCardDescriptionOpsParams{
+			CardID: d.cardID, Revision: old,
+		}); err != nil {
+			logger.Warn("Failed to prune card description operations", "card_id", d.cardID, "error", err)
+		}
+	}
+	return n > 0
+}
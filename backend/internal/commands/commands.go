@@ -0,0 +1,212 @@
+// Package commands maps WebSocket client commands to the card and list
+// services, so clients can make quick edits such as drag and drop without a
+// separate HTTP round trip. Results and broadcasts are the same as for the
+// matching REST endpoints.
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"backend/internal/cards"
+	"backend/internal/collab"
+	db "backend/internal/db/sqlc"
+	"backend/internal/lists"
+	"backend/internal/locks"
+	"backend/internal/versioning"
+	"backend/internal/websocket"
+)
+
+var (
+	ErrInvalidData = errors.New("invalid command data")
+	ErrWrongBoard  = errors.New("list belongs to another board")
+)
+
+type createCard struct {
+	ListID      int32  `json:"listId"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Position    int32  `json:"position"`
+}
+
+type moveCard struct {
+	CardID   int32 `json:"cardId"`
+	ListID   int32 `json:"listId"`
+	Position int32 `json:"position"`
+	Version  int32 `json:"version"`
+}
+
+type renameList struct {
+	ListID  int32  `json:"listId"`
+	Title   string `json:"title"`
+	Version int32  `json:"version"`
+}
+
+type moveList struct {
+	ListID   int32 `json:"listId"`
+	Position int32 `json:"position"`
+	Version  int32 `json:"version"`
+}
+
+type cardRef struct {
+	CardID int32 `json:"cardId"`
+}
+
+type descriptionOp struct {
+	CardID   int32     `json:"cardId"`
+	Revision int32     `json:"revision"`
+	OpID     string    `json:"opId"`
+	Op       collab.Op `json:"op"`
+}
+
+// decode unmarshals command data, rejecting unknown fields so typos do not
+// silently turn into zero values.
+func decode(data json.RawMessage, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return ErrInvalidData
+	}
+	return nil
+}
+
+// stale attaches the current entity to a version conflict so the client
+// can retry without reloading the board.
+func stale(current interface{}, err error) (interface{}, error) {
+	if errors.Is(err, versioning.ErrConflict) {
+		return nil, &websocket.StateError{Err: err, Current: current}
+	}
+	return current, err
+}
+
+// Register installs the command handlers on the hub.
+func Register(hub *websocket.Hub, cardsSvc *cards.Service, listsSvc *lists.Service, locksSvc *locks.Service, collabSvc *collab.Service) {
+	// sameBoard keeps commands within the board the socket is connected to.
+	sameBoard := func(ctx context.Context, boardID, listID int32) (db.List, error) {
+		lst, err := listsSvc.GetListByID(ctx, listID)
+		if err != nil {
+			return db.List{}, err
+		}
+		if lst.BoardID != boardID {
+			return db.List{}, ErrWrongBoard
+		}
+		return lst, nil
+	}
+
+	hub.Handle("card.create", func(ctx context.Context, userID, boardID int32, data json.RawMessage) (interface{}, error) {
+		var req createCard
+		if err := decode(data, &req); err != nil {
+			return nil, err
+		}
+		if req.ListID == 0 || strings.TrimSpace(req.Title) == "" || req.Position < 1 {
+			return nil, ErrInvalidData
+		}
+		if _, err := sameBoard(ctx, boardID, req.ListID); err != nil {
+			return nil, err
+		}
+		return cardsSvc.Create(ctx, userID, req.ListID, req.Title, req.Description, req.Position)
+	})
+
+	hub.Handle("card.move", func(ctx context.Context, userID, boardID int32, data json.RawMessage) (interface{}, error) {
+		var req moveCard
+		if err := decode(data, &req); err != nil {
+			return nil, err
+		}
+		if req.CardID == 0 || req.ListID == 0 || req.Position < 1 {
+			return nil, ErrInvalidData
+		}
+		if req.Version < 1 {
+			return nil, versioning.ErrRequired
+		}
+		if _, err := sameBoard(ctx, boardID, req.ListID); err != nil {
+			return nil, err
+		}
+		return stale(cardsSvc.Move(ctx, userID, req.CardID, req.ListID, req.Position, req.Version))
+	})
+
+	hub.Handle("list.rename", func(ctx context.Context, userID, boardID int32, data json.RawMessage) (interface{}, error) {
+		var req renameList
+		if err := decode(data, &req); err != nil {
+			return nil, err
+		}
+		if req.ListID == 0 || strings.TrimSpace(req.Title) == "" {
+			return nil, ErrInvalidData
+		}
+		if req.Version < 1 {
+			return nil, versioning.ErrRequired
+		}
+		lst, err := sameBoard(ctx, boardID, req.ListID)
+		if err != nil {
+			return nil, err
+		}
+		return stale(listsSvc.Update(ctx, userID, db.UpdateListParams{
+			ID:       lst.ID,
+			Title:    req.Title,
+			Position: lst.Position,
+			Version:  req.Version,
+		}))
+	})
+
+	hub.Handle("list.move", func(ctx context.Context, userID, boardID int32, data json.RawMessage) (interface{}, error) {
+		var req moveList
+		if err := decode(data, &req); err != nil {
+			return nil, err
+		}
+		if req.ListID == 0 || req.Position < 1 {
+			return nil, ErrInvalidData
+		}
+		if req.Version < 1 {
+			return nil, versioning.ErrRequired
+		}
+		if _, err := sameBoard(ctx, boardID, req.ListID); err != nil {
+			return nil, err
+		}
+		return stale(listsSvc.Move(ctx, userID, req.ListID, req.Position, req.Version))
+	})
+
+	// cardCommand adapts the lock and description service methods, which all take a card ID.
+	cardCommand := func(fn func(ctx context.Context, userID, boardID, cardID int32) (interface{}, error)) websocket.CommandFunc {
+		return func(ctx context.Context, userID, boardID int32, data json.RawMessage) (interface{}, error) {
+			var req cardRef
+			if err := decode(data, &req); err != nil {
+				return nil, err
+			}
+			if req.CardID == 0 {
+				return nil, ErrInvalidData
+			}
+			return fn(ctx, userID, boardID, req.CardID)
+		}
+	}
+
+	hub.Handle("card.lock", cardCommand(func(ctx context.Context, userID, boardID, cardID int32) (interface{}, error) {
+		return locksSvc.Acquire(ctx, userID, boardID, cardID)
+	}))
+	hub.Handle("card.unlock", cardCommand(func(ctx context.Context, userID, boardID, cardID int32) (interface{}, error) {
+		return nil, locksSvc.Release(ctx, userID, boardID, cardID)
+	}))
+	hub.Handle("card.typing", cardCommand(func(ctx context.Context, userID, boardID, cardID int32) (interface{}, error) {
+		return nil, locksSvc.Typing(ctx, userID, boardID, cardID)
+	}))
+
+	hub.Handle("description.open", cardCommand(func(ctx context.Context, userID, boardID, cardID int32) (interface{}, error) {
+		return collabSvc.Open(ctx, boardID, cardID)
+	}))
+
+	hub.Handle("description.op", func(ctx context.Context, userID, boardID int32, data json.RawMessage) (interface{}, error) {
+		var req descriptionOp
+		if err := decode(data, &req); err != nil {
+			return nil, err
+		}
+		if req.CardID == 0 || req.Revision < 0 || req.OpID == "" || len(req.OpID) > 64 || req.Op.IsNoop() {
+			return nil, ErrInvalidData
+		}
+		rev, err := collabSvc.Submit(ctx, userID, boardID, req.CardID, req.Revision, req.OpID, req.Op)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]int32{"revision": rev}, nil
+	})
+}
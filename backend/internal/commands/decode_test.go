@@ -0,0 +1,34 @@
+// internal/commands/decode_test.go
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"backend/internal/versioning"
+	"backend/internal/websocket"
+)
+
+func TestDecode(t *testing.T) {
+	var m moveCard
+	assert.NoError(t, decode(json.RawMessage(`{"cardId":1,"listId":2,"position":3}`), &m))
+	assert.Equal(t, moveCard{CardID: 1, ListID: 2, Position: 3}, m)
+
+	assert.ErrorIs(t, decode(json.RawMessage(`{"card":1}`), &m), ErrInvalidData, "unknown field")
+	assert.ErrorIs(t, decode(json.RawMessage(`{"cardId":"1"}`), &m), ErrInvalidData, "wrong type")
+	assert.ErrorIs(t, decode(nil, &m), ErrInvalidData, "missing data")
+}
+
+func TestStale(t *testing.T) {
+	_, err := stale(1, versioning.ErrConflict)
+	var se *websocket.StateError
+	assert.ErrorAs(t, err, &se)
+	assert.Equal(t, 1, se.Current)
+	assert.ErrorIs(t, err, versioning.ErrConflict)
+
+	res, err := stale(2, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res)
+}
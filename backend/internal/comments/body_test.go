@@ -0,0 +1,40 @@
+// internal/comments/body_test.go
+package comments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	db "backend/internal/db/sqlc"
+)
+
+func TestNormalizeBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr error
+	}{
+		{"Valid", "Looks good", "Looks good", nil},
+		{"Trims", "  ship it \n", "ship it", nil},
+		{"Empty", " \t\n", "", ErrEmptyBody},
+		{"AtLimit", strings.Repeat("я", MaxBodyLength), strings.Repeat("я", MaxBodyLength), nil},
+		{"TooLong", strings.Repeat("a", MaxBodyLength+1), "", ErrBodyTooLong},
+	}
+
+	for _, tc := range tests {
+		got, err := normalizeBody(tc.in)
+		assert.Equal(t, tc.wantErr, err, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+}
+
+func TestCanDelete(t *testing.T) {
+	c := db.CardComment{ID: 1, AuthorID: 7}
+
+	assert.True(t, canDelete(c, 7, "member"), "author")
+	assert.True(t, canDelete(c, 9, "owner"), "board owner")
+	assert.False(t, canDelete(c, 9, "member"), "other member")
+}
@@ -0,0 +1,56 @@
+package comments
+
+import "time"
+
+// CreateCommentRequest represents the request body for posting a comment
+type CreateCommentRequest struct {
+	Body     string `json:"body" binding:"required" example:"Looks good to me"`
+	ParentID *int32 `json:"parentId" example:"3"`
+}
+
+// UpdateCommentRequest represents the request body for editing a comment
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required" example:"Looks good to me, merging"`
+}
+
+// CommentResponse documents a comment in API responses. Handlers return
+// db.CardComment, which has no JSON tags, so fields keep their Go names on
+// the wire.
+type CommentResponse struct {
+	ID        int32      `json:"ID" example:"1"`
+	CardID    int32      `json:"CardID" example:"1"`
+	AuthorID  int32      `json:"AuthorID" example:"1"`
+	ParentID  *int32     `json:"ParentID" example:"3"`
+	Body      string     `json:"Body" example:"Looks good to me"`
+	CreatedAt time.Time  `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt *time.Time `json:"UpdatedAt" example:"2023-01-01T00:05:00Z"`
+	DeletedAt *time.Time `json:"DeletedAt"`
+}
+
+// ThreadCommentResponse documents a comment of a card's thread, which also
+// names its author
+type ThreadCommentResponse struct {
+	CommentResponse
+	AuthorName string `json:"AuthorName" example:"John Doe"`
+}
+
+// CommentRevisionResponse documents an entry of a comment's edit history
+type CommentRevisionResponse struct {
+	ID         int32     `json:"ID" example:"1"`
+	CommentID  int32     `json:"CommentID" example:"1"`
+	EditorID   int32     `json:"EditorID" example:"1"`
+	Action     string    `json:"Action" example:"edited"`
+	Body       string    `json:"Body" example:"Looks good"`
+	CreatedAt  time.Time `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+	EditorName string    `json:"EditorName" example:"John Doe"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error" example:"Comment not found"`
+}
+
+// MessageResponse represents a simple message response
+type MessageResponse struct {
+	Message string `json:"message" example:"comment deleted"`
+}
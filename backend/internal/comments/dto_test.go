@@ -0,0 +1,30 @@
+// internal/comments/dto_test.go
+package comments
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "backend/internal/db/sqlc"
+)
+
+// keys returns the top-level JSON keys of v.
+func keys(t *testing.T, v any) []string {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	var m map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(b, &m))
+	return slices.Sorted(maps.Keys(m))
+}
+
+// The Swagger DTOs must describe what the handlers actually send.
+func TestCommentResponse_MatchesPayload(t *testing.T) {
+	assert.Equal(t, keys(t, db.CardComment{}), keys(t, CommentResponse{}))
+	assert.Equal(t, keys(t, db.ListCommentsByCardRow{}), keys(t, ThreadCommentResponse{}))
+	assert.Equal(t, keys(t, db.ListCommentRevisionsRow{}), keys(t, CommentRevisionResponse{}))
+}
@@ -0,0 +1,196 @@
+package comments
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup, svc *Service) {
+	g := r.Group("/cards/:id/comments")
+	g.GET("", listCommentsHandler(svc))
+	g.POST("", createCommentHandler(svc))
+	g.PUT("/:commentId", updateCommentHandler(svc))
+	g.DELETE("/:commentId", deleteCommentHandler(svc))
+	g.GET("/:commentId/history", commentHistoryHandler(svc))
+}
+
+// errorStatus maps service errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotMember), errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrCardNotFound), errors.Is(err, ErrCommentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrCommentDeleted):
+		return http.StatusConflict
+	case errors.Is(err, ErrEmptyBody), errors.Is(err, ErrBodyTooLong), errors.Is(err, ErrParentNotFound):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// listCommentsHandler gets the comment thread of a card
+//
+//	@Summary		Get card comments
+//	@Description	Get all comments of a card in chronological order; replies reference their parent via ParentID
+//	@Tags			Comments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int						true	"Card ID"
+//	@Success		200	{array}		ThreadCommentResponse	"List of comments"
+//	@Failure		401	{object}	ErrorResponse			"Unauthorized"
+//	@Failure		403	{object}	ErrorResponse			"Forbidden - not a board member"
+//	@Failure		404	{object}	ErrorResponse			"Card not found"
+//	@Failure		500	{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/cards/{id}/comments [get]
+func listCommentsHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		comments, err := svc.ListByCard(c.Request.Context(), userID, int32(cardID))
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, comments)
+	}
+}
+
+// createCommentHandler posts a comment on a card
+//
+//	@Summary		Create a comment
+//	@Description	Post a comment on a card, optionally as a reply to another comment of the same card
+//	@Tags			Comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		int						true	"Card ID"
+//	@Param			request	body		CreateCommentRequest	true	"Comment details"
+//	@Success		201		{object}	CommentResponse			"Comment created successfully"
+//	@Failure		400		{object}	ErrorResponse			"Invalid request"
+//	@Failure		401		{object}	ErrorResponse			"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse			"Forbidden - not a board member"
+//	@Failure		404		{object}	ErrorResponse			"Card not found"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/cards/{id}/comments [post]
+func createCommentHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		var req CreateCommentRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		userID := int32(c.GetInt("userID"))
+		comment, err := svc.Create(c.Request.Context(), userID, int32(cardID), req.ParentID, req.Body)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, comment)
+	}
+}
+
+// updateCommentHandler edits a comment
+//
+//	@Summary		Update comment
+//	@Description	Edit the body of a comment. Only the author can edit; the previous body is kept in the history
+//	@Tags			Comments
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int						true	"Card ID"
+//	@Param			commentId	path		int						true	"Comment ID"
+//	@Param			request		body		UpdateCommentRequest	true	"Comment update details"
+//	@Success		200			{object}	CommentResponse			"Comment updated successfully"
+//	@Failure		400			{object}	ErrorResponse			"Invalid request"
+//	@Failure		401			{object}	ErrorResponse			"Unauthorized"
+//	@Failure		403			{object}	ErrorResponse			"Forbidden - not the author"
+//	@Failure		404			{object}	ErrorResponse			"Comment not found"
+//	@Failure		409			{object}	ErrorResponse			"Comment has been deleted"
+//	@Failure		500			{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/cards/{id}/comments/{commentId} [put]
+func updateCommentHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		commentID, _ := strconv.Atoi(c.Param("commentId"))
+		userID := int32(c.GetInt("userID"))
+
+		var req UpdateCommentRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		comment, err := svc.Update(c.Request.Context(), userID, int32(cardID), int32(commentID), req.Body)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, comment)
+	}
+}
+
+// deleteCommentHandler deletes a comment
+//
+//	@Summary		Delete comment
+//	@Description	Soft-delete a comment so its replies stay in the thread. Allowed for the author and the board owner
+//	@Tags			Comments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int				true	"Card ID"
+//	@Param			commentId	path		int				true	"Comment ID"
+//	@Success		200			{object}	MessageResponse	"Comment deleted successfully"
+//	@Failure		401			{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403			{object}	ErrorResponse	"Forbidden - insufficient permissions"
+//	@Failure		404			{object}	ErrorResponse	"Comment not found"
+//	@Failure		409			{object}	ErrorResponse	"Comment has been deleted"
+//	@Failure		500			{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/cards/{id}/comments/{commentId} [delete]
+func deleteCommentHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		commentID, _ := strconv.Atoi(c.Param("commentId"))
+		userID := int32(c.GetInt("userID"))
+
+		if err := svc.Delete(c.Request.Context(), userID, int32(cardID), int32(commentID)); err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "comment deleted"})
+	}
+}
+
+// commentHistoryHandler gets the edit history of a comment
+//
+//	@Summary		Get comment history
+//	@Description	Get previous versions of a comment, oldest first
+//	@Tags			Comments
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		int							true	"Card ID"
+//	@Param			commentId	path		int							true	"Comment ID"
+//	@Success		200			{array}		CommentRevisionResponse		"Comment history"
+//	@Failure		401			{object}	ErrorResponse				"Unauthorized"
+//	@Failure		403			{object}	ErrorResponse				"Forbidden - not a board member"
+//	@Failure		404			{object}	ErrorResponse				"Comment not found"
+//	@Failure		500			{object}	ErrorResponse				"Internal server error"
+//	@Router			/api/cards/{id}/comments/{commentId}/history [get]
+func commentHistoryHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cardID, _ := strconv.Atoi(c.Param("id"))
+		commentID, _ := strconv.Atoi(c.Param("commentId"))
+		userID := int32(c.GetInt("userID"))
+
+		revs, err := svc.History(c.Request.Context(), userID, int32(cardID), int32(commentID))
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, revs)
+	}
+}
@@ -0,0 +1,101 @@
+package comments
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/websocket"
+)
+
+// mentions returns the board members mentioned in body as @Full Name or
+// @email, ignoring case, in the order of the member list. The author is
+// never included. When handles overlap, such as "Ann" and "Ann Lee", the
+// longest one wins.
+func mentions(body string, members []db.ListBoardMembersRow, authorID int32) []int32 {
+	type handle struct {
+		text   string
+		userID int32
+	}
+	var handles []handle
+	for _, m := range members {
+		for _, h := range []string{m.Name, m.Email} {
+			if h = strings.ToLower(strings.TrimSpace(h)); h != "" {
+				handles = append(handles, handle{h, m.UserID})
+			}
+		}
+	}
+	sort.SliceStable(handles, func(i, j int) bool { return len(handles[i].text) > len(handles[j].text) })
+
+	text := strings.ToLower(body)
+	taken := make(map[int]bool) // positions of @ already matched
+	found := make(map[int32]bool)
+	for _, h := range handles {
+		for from := 0; ; {
+			i := strings.Index(text[from:], "@"+h.text)
+			if i < 0 {
+				break
+			}
+			at := from + i
+			end := at + 1 + len(h.text)
+			from = at + 1
+			if taken[at] || !boundaryBefore(text, at) || !boundaryAfter(text, end) {
+				continue
+			}
+			taken[at] = true
+			found[h.userID] = true
+		}
+	}
+
+	var out []int32
+	for _, m := range members {
+		if found[m.UserID] && m.UserID != authorID {
+			out = append(out, m.UserID)
+		}
+	}
+	return out
+}
+
+func isHandleRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
+
+func boundaryBefore(text string, i int) bool {
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return i == 0 || !isHandleRune(r)
+}
+
+func boundaryAfter(text string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return i == len(text) || !isHandleRune(r)
+}
+
+// notifyMentions sends a mentioned event to the personal channel of each
+// member mentioned in c who was not already mentioned in previous.
+func (s *Service) notifyMentions(ctx context.Context, boardID int32, c db.CardComment, previous string) {
+	members, err := s.q.ListBoardMembers(ctx, boardID)
+	if err != nil {
+		logger.WithContext(ctx).Error("Failed to list members for mentions",
+			"board_id", boardID,
+			"comment_id", c.ID,
+			"error", err,
+		)
+		return
+	}
+	already := make(map[int32]bool)
+	for _, id := range mentions(previous, members, c.AuthorID) {
+		already[id] = true
+	}
+	for _, id := range mentions(c.Body, members, c.AuthorID) {
+		if already[id] {
+			continue
+		}
+		s.hub.NotifyUser(id, websocket.EventMessage{Event: "mentioned", Data: map[string]int32{
+			"boardId": boardID, "cardId": c.CardID, "commentId": c.ID, "authorId": c.AuthorID,
+		}})
+	}
+}
@@ -0,0 +1,37 @@
+// internal/comments/mentions_test.go
+package comments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	db "backend/internal/db/sqlc"
+)
+
+func TestMentions(t *testing.T) {
+	members := []db.ListBoardMembersRow{
+		{UserID: 1, Name: "Alice", Email: "alice@example.com"},
+		{UserID: 2, Name: "Ann", Email: "ann@example.com"},
+		{UserID: 3, Name: "Ann Lee", Email: "lee@example.com"},
+		{UserID: 4, Name: "Bob", Email: "bob@example.com"},
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want []int32
+	}{
+		{"ByName", "@bob please check", []int32{4}},
+		{"ByEmail", "cc @Lee@Example.com.", []int32{3}},
+		{"LongestWins", "@Ann Lee, thoughts?", []int32{3}},
+		{"Both", "@Ann and @Ann Lee", []int32{2, 3}},
+		{"PartOfWord", "@Bobby and mail@bob", nil},
+		{"Author", "@Alice note to self", nil},
+		{"Repeated", "@bob @bob", []int32{4}},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, mentions(tc.body, members, 1), tc.name)
+	}
+}
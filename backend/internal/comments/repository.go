@@ -0,0 +1,91 @@
+package comments
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	db "backend/internal/db/sqlc"
+)
+
+type Repository struct {
+	q    *db.Queries
+	pool *pgxpool.Pool // for statements that must commit together
+}
+
+func NewRepository(q *db.Queries, pool *pgxpool.Pool) *Repository {
+	return &Repository{q: q, pool: pool}
+}
+
+func (r *Repository) Create(ctx context.Context, arg db.CreateCommentParams) (db.CardComment, error) {
+	return r.q.CreateComment(ctx, arg)
+}
+func (r *Repository) Get(ctx context.Context, id int32) (db.CardComment, error) {
+	return r.q.GetCommentByID(ctx, id)
+}
+func (r *Repository) ListByCard(ctx context.Context, cardID int32) ([]db.ListCommentsByCardRow, error) {
+	return r.q.ListCommentsByCard(ctx, cardID)
+}
+
+// Edit replaces the body of a comment and records the one it replaced as a
+// revision, in one transaction. It returns the previous body. The comment is
+// locked first, so concurrent edits each record what they replaced and an
+// edit racing a delete fails with ErrCommentDeleted.
+func (r *Repository) Edit(ctx context.Context, id, editorID int32, body string) (db.CardComment, string, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.CardComment{}, "", err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	c, err := q.GetCommentForUpdate(ctx, id)
+	if err != nil {
+		return db.CardComment{}, "", err
+	}
+	if c.DeletedAt.Valid {
+		return db.CardComment{}, "", ErrCommentDeleted
+	}
+	if _, err := q.CreateCommentRevision(ctx, db.CreateCommentRevisionParams{
+		CommentID: id, EditorID: editorID, Action: revisionEdited, Body: c.Body,
+	}); err != nil {
+		return db.CardComment{}, "", err
+	}
+	updated, err := q.UpdateCommentBody(ctx, db.UpdateCommentBodyParams{ID: id, Body: body})
+	if err != nil {
+		return db.CardComment{}, "", err
+	}
+	return updated, c.Body, tx.Commit(ctx)
+}
+
+// Delete soft-deletes a comment, drops its earlier versions and records who
+// deleted it, in one transaction. The comment is locked first, so the purge
+// also catches a revision an edit committed just before.
+func (r *Repository) Delete(ctx context.Context, id, editorID int32) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	q := r.q.WithTx(tx)
+
+	c, err := q.GetCommentForUpdate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.DeletedAt.Valid {
+		return ErrCommentDeleted
+	}
+	if _, err := q.SoftDeleteComment(ctx, id); err != nil {
+		return err
+	}
+	if _, err := q.CreateCommentRevision(ctx, db.CreateCommentRevisionParams{
+		CommentID: id, EditorID: editorID, Action: revisionDeleted,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+func (r *Repository) ListRevisions(ctx context.Context, commentID int32) ([]db.ListCommentRevisionsRow, error) {
+	return r.q.ListCommentRevisions(ctx, commentID)
+}
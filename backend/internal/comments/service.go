@@ -0,0 +1,201 @@
+package comments
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/websocket"
+)
+
+type Service struct {
+	repo *Repository
+	q    *db.Queries // for cross‑repo checks
+	hub  *websocket.Hub
+}
+
+func NewService(repo *Repository, q *db.Queries, hub *websocket.Hub) *Service {
+	return &Service{repo: repo, q: q, hub: hub}
+}
+
+// MaxBodyLength limits the size of a single comment.
+const MaxBodyLength = 10000
+
+var (
+	ErrNotMember       = errors.New("not a member")
+	ErrForbidden       = errors.New("forbidden: insufficient permissions")
+	ErrCardNotFound    = errors.New("card not found")
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrParentNotFound  = errors.New("parent comment not found on this card")
+	ErrCommentDeleted  = errors.New("comment has been deleted")
+	ErrEmptyBody       = errors.New("comment body is required")
+	ErrBodyTooLong     = errors.New("comment body is too long")
+)
+
+// Revision actions stored in card_comment_revisions.action.
+const (
+	revisionEdited  = "edited"
+	revisionDeleted = "deleted"
+)
+
+// normalizeBody trims the comment text and enforces the length limits.
+func normalizeBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", ErrEmptyBody
+	}
+	if len([]rune(body)) > MaxBodyLength {
+		return "", ErrBodyTooLong
+	}
+	return body, nil
+}
+
+// canDelete reports whether a user with the given board role may delete the
+// comment: its author always can, otherwise only the board owner.
+func canDelete(c db.CardComment, userID int32, role string) bool {
+	return c.AuthorID == userID || role == "owner"
+}
+
+// boardMember resolves the board of a card and returns the caller's membership.
+func (s *Service) boardMember(ctx context.Context, userID, cardID int32) (db.BoardMember, error) {
+	card, err := s.q.GetCardByID(ctx, cardID)
+	if err != nil {
+		return db.BoardMember{}, ErrCardNotFound
+	}
+	lst, err := s.q.GetListByID(ctx, card.ListID)
+	if err != nil {
+		return db.BoardMember{}, err
+	}
+	m, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{BoardID: lst.BoardID, UserID: userID})
+	if err != nil {
+		return db.BoardMember{}, ErrNotMember
+	}
+	return m, nil
+}
+
+// comment loads a comment and checks that it belongs to cardID.
+func (s *Service) comment(ctx context.Context, cardID, commentID int32) (db.CardComment, error) {
+	c, err := s.repo.Get(ctx, commentID)
+	if err != nil || c.CardID != cardID {
+		return db.CardComment{}, ErrCommentNotFound
+	}
+	return c, nil
+}
+
+func (s *Service) Create(ctx context.Context, userID, cardID int32, parentID *int32, body string) (db.CardComment, error) {
+	m, err := s.boardMember(ctx, userID, cardID)
+	if err != nil {
+		return db.CardComment{}, err
+	}
+	body, err = normalizeBody(body)
+	if err != nil {
+		return db.CardComment{}, err
+	}
+
+	parent := pgtype.Int4{}
+	if parentID != nil {
+		p, err := s.repo.Get(ctx, *parentID)
+		if err != nil || p.CardID != cardID {
+			return db.CardComment{}, ErrParentNotFound
+		}
+		parent = pgtype.Int4{Int32: p.ID, Valid: true}
+	}
+
+	c, err := s.repo.Create(ctx, db.CreateCommentParams{
+		CardID: cardID, AuthorID: userID, ParentID: parent, Body: body,
+	})
+	if err != nil {
+		logger.WithContext(ctx).Error("Failed to create comment",
+			"user_id", userID,
+			"card_id", cardID,
+			"error", err,
+		)
+		return db.CardComment{}, err
+	}
+	s.hub.Broadcast(m.BoardID, websocket.EventMessage{Event: "comment_created", Data: c})
+	s.notifyMentions(ctx, m.BoardID, c, "")
+	return c, nil
+}
+
+func (s *Service) ListByCard(ctx context.Context, userID, cardID int32) ([]db.ListCommentsByCardRow, error) {
+	if _, err := s.boardMember(ctx, userID, cardID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByCard(ctx, cardID)
+}
+
+// Update edits a comment. Only the author can edit; the previous body is
+// kept as a revision.
+func (s *Service) Update(ctx context.Context, userID, cardID, commentID int32, body string) (db.CardComment, error) {
+	m, err := s.boardMember(ctx, userID, cardID)
+	if err != nil {
+		return db.CardComment{}, err
+	}
+	c, err := s.comment(ctx, cardID, commentID)
+	if err != nil {
+		return db.CardComment{}, err
+	}
+	if c.DeletedAt.Valid {
+		return db.CardComment{}, ErrCommentDeleted
+	}
+	if c.AuthorID != userID {
+		return db.CardComment{}, ErrForbidden
+	}
+	body, err = normalizeBody(body)
+	if err != nil {
+		return db.CardComment{}, err
+	}
+
+	updated, previous, err := s.repo.Edit(ctx, c.ID, userID, body)
+	if err != nil {
+		return db.CardComment{}, err
+	}
+	s.hub.Broadcast(m.BoardID, websocket.EventMessage{Event: "comment_updated", Data: updated})
+	s.notifyMentions(ctx, m.BoardID, updated, previous)
+	return updated, nil
+}
+
+// Delete soft-deletes a comment so its replies stay in the thread. Only the
+// author or the board owner can delete.
+func (s *Service) Delete(ctx context.Context, userID, cardID, commentID int32) error {
+	m, err := s.boardMember(ctx, userID, cardID)
+	if err != nil {
+		return err
+	}
+	c, err := s.comment(ctx, cardID, commentID)
+	if err != nil {
+		return err
+	}
+	if c.DeletedAt.Valid {
+		return ErrCommentDeleted
+	}
+	if !canDelete(c, userID, m.Role) {
+		return ErrForbidden
+	}
+
+	// Soft-deleting purges the earlier versions too; the history keeps only
+	// who deleted the comment and when.
+	if err := s.repo.Delete(ctx, c.ID, userID); err != nil {
+		return err
+	}
+	s.hub.Broadcast(m.BoardID, websocket.EventMessage{
+		Event: "comment_deleted", Data: map[string]int32{"id": c.ID, "cardId": c.CardID},
+	})
+	return nil
+}
+
+// History returns the edit/delete history of a comment, oldest first. A
+// deleted comment has no earlier versions left, only the deletion entry.
+func (s *Service) History(ctx context.Context, userID, cardID, commentID int32) ([]db.ListCommentRevisionsRow, error) {
+	if _, err := s.boardMember(ctx, userID, cardID); err != nil {
+		return nil, err
+	}
+	if _, err := s.comment(ctx, cardID, commentID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRevisions(ctx, commentID)
+}
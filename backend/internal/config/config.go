@@ -1,12 +1,35 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type Config struct {
 	DBUrl     string
 	JWTSecret string
 	Port      string
 	Log       LogConfig
+	Storage   StorageConfig
+	Mail      MailConfig
+	OIDC      OIDCConfig
+	// AppURL is the address of the web app, used for links in emails.
+	AppURL string
+	// TrashRetention is how long archived boards, lists and cards are kept
+	// before they are deleted for good.
+	TrashRetention time.Duration
+	// WSBackend selects how WebSocket events reach other API instances:
+	// "local" (single instance) or "postgres" (LISTEN/NOTIFY fan-out).
+	WSBackend string
+	// WSAllowedOrigins are the origins browsers may open WebSocket and SSE
+	// connections from; "*" allows any.
+	WSAllowedOrigins []string
+	// AccessTokenTTL is how long a JWT access token is valid; RefreshTokenTTL
+	// how long a session lasts without being refreshed.
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
 }
 
 type LogConfig struct {
@@ -15,6 +38,31 @@ type LogConfig struct {
 	Output string // stdout, file
 }
 
+type StorageConfig struct {
+	Dir           string // directory for uploaded attachments
+	MaxUploadSize int64  // per-file limit in bytes
+}
+
+type MailConfig struct {
+	Backend      string // outbox, smtp
+	From         string // sender address
+	OutboxDir    string // where the outbox writes messages; empty only logs them
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string // empty disables authentication
+	SMTPPassword string
+}
+
+// OIDCConfig enables single sign-on through an OpenID Connect provider when
+// Issuer is set.
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // callback URL registered with the provider
+	Scopes       []string
+}
+
 func Load() *Config {
 	host := getenv("POSTGRES_HOST", "localhost")
 	user := getenv("POSTGRES_USER", "postgres")
@@ -31,11 +79,46 @@ func Load() *Config {
 		Output: getenv("LOG_OUTPUT", "stdout"),
 	}
 
+	storageConfig := StorageConfig{
+		Dir:           getenv("STORAGE_DIR", "uploads"),
+		MaxUploadSize: int64(getenvInt("MAX_UPLOAD_SIZE_MB", 10)) << 20,
+	}
+
+	mailConfig := MailConfig{
+		Backend:      getenv("MAIL_BACKEND", "outbox"),
+		From:         getenv("MAIL_FROM", "CollabBoard <no-reply@localhost>"),
+		OutboxDir:    os.Getenv("MAIL_OUTBOX_DIR"),
+		SMTPHost:     getenv("SMTP_HOST", "localhost"),
+		SMTPPort:     getenvInt("SMTP_PORT", 587),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+	}
+
+	oidcConfig := OIDCConfig{
+		Issuer:       os.Getenv("OIDC_ISSUER"),
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  getenv("OIDC_REDIRECT_URL", "http://localhost:"+port+"/auth/oidc/callback"),
+		Scopes:       getenvList("OIDC_SCOPES", "openid,email,profile"),
+	}
+
 	return &Config{
 		DBUrl:     dbURL,
 		JWTSecret: secret,
 		Port:      port,
 		Log:       logConfig,
+		Storage:   storageConfig,
+		Mail:      mailConfig,
+		OIDC:      oidcConfig,
+		AppURL:    getenv("APP_URL", "http://localhost:5173"),
+
+		TrashRetention: time.Duration(getenvInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour,
+		WSBackend:      getenv("WS_BACKEND", "local"),
+
+		WSAllowedOrigins: getenvList("WS_ALLOWED_ORIGINS", "http://localhost:5173"),
+
+		AccessTokenTTL:  time.Duration(getenvInt("ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute,
+		RefreshTokenTTL: time.Duration(getenvInt("REFRESH_TOKEN_TTL_DAYS", 30)) * 24 * time.Hour,
 	}
 }
 
@@ -45,3 +128,22 @@ func getenv(k, fallback string) string {
 	}
 	return fallback
 }
+
+// getenvInt reads a positive integer, falling back on missing or invalid values.
+func getenvInt(k string, fallback int) int {
+	if n, err := strconv.Atoi(os.Getenv(k)); err == nil && n > 0 {
+		return n
+	}
+	return fallback
+}
+
+// getenvList reads a comma-separated list, skipping empty items.
+func getenvList(k, fallback string) []string {
+	var out []string
+	for _, v := range strings.Split(getenv(k, fallback), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,16 @@
+-- Labels table: colored labels defined once per board.
+CREATE TABLE labels (
+                        id SERIAL PRIMARY KEY,
+                        board_id INT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+                        name TEXT NOT NULL,
+                        color TEXT NOT NULL,
+                        created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                        UNIQUE (board_id, name)
+);
+
+-- Card labels table: attaches board labels to cards (many-to-many).
+CREATE TABLE card_labels (
+                             card_id INT NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+                             label_id INT NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+                             PRIMARY KEY (card_id, label_id)
+);
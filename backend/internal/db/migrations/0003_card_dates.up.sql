@@ -0,0 +1,8 @@
+-- Card scheduling: optional start/due timestamps and a completion flag.
+ALTER TABLE cards
+    ADD COLUMN start_at TIMESTAMP,
+    ADD COLUMN due_at TIMESTAMP,
+    ADD COLUMN completed BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Speeds up overdue / due-soon lookups, which only care about open cards.
+CREATE INDEX cards_due_at_idx ON cards (list_id, due_at) WHERE NOT completed;
@@ -0,0 +1,10 @@
+-- Card assignees table: board members responsible for a card.
+CREATE TABLE card_assignees (
+                                card_id INT NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+                                user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                assigned_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                PRIMARY KEY (card_id, user_id)
+);
+
+-- Serves the "cards assigned to me" lookup.
+CREATE INDEX card_assignees_user_idx ON card_assignees (user_id);
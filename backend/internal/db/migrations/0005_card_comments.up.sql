@@ -0,0 +1,26 @@
+-- Card comments table: threaded discussion on cards. Deleted comments are
+-- kept (body cleared, deleted_at set) so replies stay attached to the thread.
+CREATE TABLE card_comments (
+                               id SERIAL PRIMARY KEY,
+                               card_id INT NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+                               author_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                               parent_id INT REFERENCES card_comments(id) ON DELETE CASCADE,
+                               body TEXT NOT NULL,
+                               created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                               updated_at TIMESTAMP,
+                               deleted_at TIMESTAMP
+);
+
+CREATE INDEX card_comments_card_idx ON card_comments (card_id, created_at);
+
+-- Comment revisions table: the previous body of a comment, recorded on every
+-- edit. Deleting a comment drops its earlier versions and records only who
+-- deleted it, with an empty body.
+CREATE TABLE card_comment_revisions (
+                                        id SERIAL PRIMARY KEY,
+                                        comment_id INT NOT NULL REFERENCES card_comments(id) ON DELETE CASCADE,
+                                        editor_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                        action TEXT NOT NULL CHECK (action IN ('edited','deleted')),
+                                        body TEXT NOT NULL,
+                                        created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
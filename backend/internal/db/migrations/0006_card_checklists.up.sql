@@ -0,0 +1,23 @@
+-- Card checklists table: named checklists inside a card, ordered by position
+CREATE TABLE card_checklists (
+                                 id SERIAL PRIMARY KEY,
+                                 card_id INT NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+                                 title TEXT NOT NULL,
+                                 position INT NOT NULL,
+                                 created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX card_checklists_card_idx ON card_checklists (card_id, position);
+
+-- Checklist items table: positions are contiguous (1..n) inside a checklist,
+-- like cards inside a list
+CREATE TABLE checklist_items (
+                                 id SERIAL PRIMARY KEY,
+                                 checklist_id INT NOT NULL REFERENCES card_checklists(id) ON DELETE CASCADE,
+                                 title TEXT NOT NULL,
+                                 done BOOLEAN NOT NULL DEFAULT FALSE,
+                                 position INT NOT NULL,
+                                 created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX checklist_items_checklist_idx ON checklist_items (checklist_id, position);
@@ -0,0 +1,14 @@
+-- Card attachments table: metadata of uploaded files. The content itself
+-- lives in the storage backend under storage_key.
+CREATE TABLE card_attachments (
+                                  id SERIAL PRIMARY KEY,
+                                  card_id INT NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+                                  uploader_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                  filename TEXT NOT NULL,
+                                  content_type TEXT NOT NULL,
+                                  size BIGINT NOT NULL,
+                                  storage_key TEXT NOT NULL UNIQUE,
+                                  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX card_attachments_card_idx ON card_attachments (card_id, created_at);
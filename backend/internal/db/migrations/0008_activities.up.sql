@@ -0,0 +1,16 @@
+-- Activities table: audit trail of board mutations. entity_id is not a
+-- foreign key so the history of deleted lists and cards is kept.
+CREATE TABLE activities (
+                            id SERIAL PRIMARY KEY,
+                            board_id INT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+                            actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+                            action TEXT NOT NULL,
+                            entity_type TEXT NOT NULL CHECK (entity_type IN ('board','list','card','member')),
+                            entity_id INT NOT NULL,
+                            before JSONB,
+                            after JSONB,
+                            created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX activities_board_idx ON activities (board_id, id DESC);
+CREATE INDEX activities_entity_idx ON activities (entity_type, entity_id, id DESC);
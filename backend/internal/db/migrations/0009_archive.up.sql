@@ -0,0 +1,10 @@
+-- Trash: cards, lists and boards are archived instead of deleted. Archived
+-- rows keep their old position for restoring and are purged by a background
+-- job after the retention period.
+ALTER TABLE boards ADD COLUMN archived_at TIMESTAMP;
+ALTER TABLE lists ADD COLUMN archived_at TIMESTAMP;
+ALTER TABLE cards ADD COLUMN archived_at TIMESTAMP;
+
+CREATE INDEX boards_archived_at_idx ON boards (archived_at) WHERE archived_at IS NOT NULL;
+CREATE INDEX lists_archived_at_idx ON lists (archived_at) WHERE archived_at IS NOT NULL;
+CREATE INDEX cards_archived_at_idx ON cards (archived_at) WHERE archived_at IS NOT NULL;
@@ -0,0 +1,16 @@
+-- Board revisions: a counter per board, bumped on every broadcast mutation.
+CREATE TABLE board_revisions (
+                                 board_id INT PRIMARY KEY REFERENCES boards(id) ON DELETE CASCADE,
+                                 revision BIGINT NOT NULL
+);
+
+-- Board events: the recent events of a board by revision, so reconnecting
+-- clients can catch up on what they missed. Old events are pruned.
+CREATE TABLE board_events (
+                              board_id INT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+                              revision BIGINT NOT NULL,
+                              event TEXT NOT NULL,
+                              payload JSONB NOT NULL,
+                              created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                              PRIMARY KEY (board_id, revision)
+);
@@ -0,0 +1,10 @@
+-- Card locks: soft edit locks, one holder per card. A lock is free once
+-- expires_at has passed, so crashed clients cannot hold a card forever.
+CREATE TABLE card_locks (
+                            card_id INT PRIMARY KEY REFERENCES cards(id) ON DELETE CASCADE,
+                            board_id INT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+                            user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                            expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX card_locks_board_idx ON card_locks (board_id, user_id);
@@ -0,0 +1,6 @@
+-- Optimistic concurrency: every update or move of a board, list or card
+-- must name the version it was based on and bumps it by one. Position
+-- shifts of neighbouring rows do not change their version.
+ALTER TABLE boards ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE lists ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE cards ADD COLUMN version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,18 @@
+-- Collaborative editing of card descriptions. Every accepted operation is
+-- logged under the next revision of the card's document; the primary key
+-- makes all instances agree on the order. cards.description is saved
+-- periodically and card_description_revisions records which revision it
+-- reflects, so a document is rebuilt from it plus the later operations.
+CREATE TABLE card_description_ops (
+                                      card_id INT NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+                                      revision INT NOT NULL,
+                                      user_id INT REFERENCES users(id) ON DELETE SET NULL,
+                                      op JSONB NOT NULL,
+                                      created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                      PRIMARY KEY (card_id, revision)
+);
+
+CREATE TABLE card_description_revisions (
+                                            card_id INT PRIMARY KEY REFERENCES cards(id) ON DELETE CASCADE,
+                                            revision INT NOT NULL
+);
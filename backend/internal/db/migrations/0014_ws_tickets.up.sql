@@ -0,0 +1,12 @@
+-- WebSocket tickets: single-use, short-lived credentials for opening a
+-- board or personal connection, so the long-lived JWT stays out of URLs.
+-- Only a hash of the ticket is stored. board_id is NULL for tickets of the
+-- personal channel.
+CREATE TABLE ws_tickets (
+                            token_hash TEXT PRIMARY KEY,
+                            user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                            board_id INT REFERENCES boards(id) ON DELETE CASCADE,
+                            expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX ws_tickets_expires_at_idx ON ws_tickets (expires_at);
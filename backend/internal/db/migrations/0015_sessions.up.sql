@@ -0,0 +1,18 @@
+-- Sessions: one row per signed-in device. The refresh token is rotated on
+-- every use and only its hash is stored; previous_token_hash keeps the one
+-- it replaced so a stolen, already rotated token can be detected. Deleting
+-- the row signs the device out.
+CREATE TABLE sessions (
+                          id SERIAL PRIMARY KEY,
+                          user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          refresh_token_hash TEXT NOT NULL UNIQUE,
+                          previous_token_hash TEXT,
+                          user_agent TEXT NOT NULL DEFAULT '',
+                          ip_address TEXT NOT NULL DEFAULT '',
+                          created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                          last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                          expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX sessions_user_idx ON sessions (user_id);
+CREATE INDEX sessions_previous_token_idx ON sessions (previous_token_hash);
@@ -0,0 +1,10 @@
+-- Password reset tokens: single-use and short-lived. Only a hash of the
+-- token is stored, so the table alone cannot be used to take over accounts.
+CREATE TABLE password_resets (
+                                 token_hash TEXT PRIMARY KEY,
+                                 user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                 created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                 expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX password_resets_user_id_idx ON password_resets (user_id);
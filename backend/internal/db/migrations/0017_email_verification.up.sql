@@ -0,0 +1,15 @@
+-- Email verification: users confirm their address through an emailed link.
+-- Accounts that existed before verification was introduced count as
+-- verified.
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP;
+UPDATE users SET email_verified_at = created_at;
+
+-- Verification tokens: single-use and expiring; only a hash is stored.
+CREATE TABLE email_verifications (
+                                     token_hash TEXT PRIMARY KEY,
+                                     user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                     expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX email_verifications_user_id_idx ON email_verifications (user_id);
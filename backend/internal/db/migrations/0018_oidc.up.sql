@@ -0,0 +1,24 @@
+-- Pending OIDC sign-ins: what the callback needs to finish a login started
+-- at the provider. Rows are single-use and expire after a few minutes; the
+-- state is stored hashed.
+CREATE TABLE oidc_logins (
+                             state_hash TEXT PRIMARY KEY,
+                             nonce TEXT NOT NULL,
+                             code_verifier TEXT NOT NULL,
+                             expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX oidc_logins_expires_at_idx ON oidc_logins (expires_at);
+
+-- External identities: accounts at an OIDC provider linked to users.
+CREATE TABLE user_identities (
+                                 id SERIAL PRIMARY KEY,
+                                 user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                 issuer TEXT NOT NULL,
+                                 subject TEXT NOT NULL,
+                                 email TEXT NOT NULL,
+                                 created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                 UNIQUE (issuer, subject)
+);
+
+CREATE INDEX user_identities_user_id_idx ON user_identities (user_id);
@@ -0,0 +1,18 @@
+-- Personal access tokens: long-lived credentials for scripts and CI. Only a
+-- hash of the token is stored; token_prefix helps users recognise their
+-- tokens. board_ids restricts a token to some boards, NULL allows all
+-- boards of the user.
+CREATE TABLE personal_access_tokens (
+                                        id SERIAL PRIMARY KEY,
+                                        user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                        name TEXT NOT NULL,
+                                        token_hash TEXT NOT NULL UNIQUE,
+                                        token_prefix TEXT NOT NULL,
+                                        scopes TEXT[] NOT NULL,
+                                        board_ids INT[],
+                                        created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                        last_used_at TIMESTAMP,
+                                        expires_at TIMESTAMP
+);
+
+CREATE INDEX personal_access_tokens_user_id_idx ON personal_access_tokens (user_id);
@@ -0,0 +1,32 @@
+-- name: CreateActivity :one
+INSERT INTO activities (board_id, actor_id, action, entity_type, entity_id, before, after)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+    RETURNING id, board_id, actor_id, action, entity_type, entity_id, before, after, created_at;
+
+-- name: ListBoardActivity :many
+SELECT a.id, a.board_id, a.actor_id, u.name AS actor_name, a.action, a.entity_type, a.entity_id,
+       a.before, a.after, a.created_at
+FROM activities a
+         LEFT JOIN users u ON u.id = a.actor_id
+WHERE a.board_id = sqlc.arg(board_id)
+  AND (sqlc.arg(before_id)::int = 0 OR a.id < sqlc.arg(before_id)::int)
+ORDER BY a.id DESC
+LIMIT sqlc.arg(page_size)::int;
+
+-- name: ListEntityActivity :many
+SELECT a.id, a.board_id, a.actor_id, u.name AS actor_name, a.action, a.entity_type, a.entity_id,
+       a.before, a.after, a.created_at
+FROM activities a
+         LEFT JOIN users u ON u.id = a.actor_id
+WHERE a.entity_type = sqlc.arg(entity_type)
+  AND a.entity_id = sqlc.arg(entity_id)
+  AND (sqlc.arg(before_id)::int = 0 OR a.id < sqlc.arg(before_id)::int)
+ORDER BY a.id DESC
+LIMIT sqlc.arg(page_size)::int;
+
+-- name: GetEntityActivityBoard :one
+SELECT board_id
+FROM activities
+WHERE entity_type = $1 AND entity_id = $2
+ORDER BY id DESC
+LIMIT 1;
@@ -0,0 +1,19 @@
+-- name: CreateAttachment :one
+INSERT INTO card_attachments (card_id, uploader_id, filename, content_type, size, storage_key)
+VALUES ($1, $2, $3, $4, $5, $6)
+    RETURNING id, card_id, uploader_id, filename, content_type, size, storage_key, created_at;
+
+-- name: GetAttachmentByID :one
+SELECT id, card_id, uploader_id, filename, content_type, size, storage_key, created_at
+FROM card_attachments
+WHERE id = $1;
+
+-- name: ListAttachmentsByCard :many
+SELECT id, card_id, uploader_id, filename, content_type, size, storage_key, created_at
+FROM card_attachments
+WHERE card_id = $1
+ORDER BY created_at, id;
+
+-- name: DeleteAttachment :exec
+DELETE FROM card_attachments
+WHERE id = $1;
@@ -0,0 +1,30 @@
+-- name: AppendBoardEvent :one
+WITH rev AS (
+    INSERT INTO board_revisions (board_id, revision)
+    VALUES ($1, 1)
+    ON CONFLICT (board_id) DO UPDATE SET revision = board_revisions.revision + 1
+    RETURNING revision
+)
+INSERT INTO board_events (board_id, revision, event, payload)
+SELECT $1, revision, $2, $3
+FROM rev
+    RETURNING revision;
+
+-- name: GetBoardRevision :one
+SELECT COALESCE((SELECT revision FROM board_revisions WHERE board_id = $1), 0)::BIGINT AS revision;
+
+-- name: ListBoardEventsSince :many
+SELECT revision, event, payload, created_at
+FROM board_events
+WHERE board_id = $1 AND revision > $2
+ORDER BY revision
+LIMIT $3;
+
+-- name: PruneBoardEvents :exec
+DELETE FROM board_events
+WHERE board_id = $1 AND revision <= $2;
+
+-- name: GetBoardEvent :one
+SELECT revision, event, payload, created_at
+FROM board_events
+WHERE board_id = $1 AND revision = $2;
@@ -4,9 +4,10 @@ VALUES ($1, $2, $3)
     RETURNING board_id, user_id, role;
 
 -- name: GetBoardMember :one
-SELECT board_id, user_id, role
-FROM board_members
-WHERE board_id = $1 AND user_id = $2;
+SELECT bm.board_id, bm.user_id, bm.role
+FROM board_members bm
+         JOIN boards b ON b.id = bm.board_id
+WHERE bm.board_id = $1 AND bm.user_id = $2 AND b.archived_at IS NULL;
 
 -- name: ListBoardMembers :many
 SELECT u.id AS user_id, u.name, u.email, bm.role
@@ -16,17 +17,17 @@ WHERE bm.board_id = $1
 ORDER BY u.name;
 
 -- name: ListBoardsByUser :many
-SELECT b.id AS board_id, b.name, b.owner_id, b.created_at
+SELECT b.id AS board_id, b.name, b.owner_id, b.created_at, b.version
 FROM board_members bm
          JOIN boards b ON b.id = bm.board_id
-WHERE bm.user_id = $1
+WHERE bm.user_id = $1 AND b.archived_at IS NULL
 ORDER BY b.created_at;
 
 -- name: ListBoardsByUserAndRole :many
-SELECT b.id AS board_id, b.name, b.owner_id, b.created_at, bm.role
+SELECT b.id AS board_id, b.name, b.owner_id, b.created_at, b.version, bm.role
 FROM board_members bm
          JOIN boards b ON b.id = bm.board_id
-WHERE bm.user_id = $1 AND bm.role = $2
+WHERE bm.user_id = $1 AND bm.role = $2 AND b.archived_at IS NULL
 ORDER BY b.created_at;
 
 -- name: UpdateBoardMemberRole :one
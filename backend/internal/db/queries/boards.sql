@@ -1,31 +1,62 @@
 -- name: CreateBoard :one
 INSERT INTO boards (name, owner_id)
 VALUES ($1, $2)
-    RETURNING id, name, owner_id, created_at;
+    RETURNING id, name, owner_id, created_at, archived_at, version;
 
 -- name: GetBoardByID :one
-SELECT id, name, owner_id, created_at
+SELECT id, name, owner_id, created_at, archived_at, version
 FROM boards
 WHERE id = $1;
 
 -- name: ListBoards :many
-SELECT id, name, owner_id, created_at
+SELECT id, name, owner_id, created_at, archived_at, version
 FROM boards
+WHERE archived_at IS NULL
 ORDER BY created_at;
 
 -- name: ListBoardsByMember :many
-SELECT b.id, b.name, b.owner_id, b.created_at
+SELECT b.id, b.name, b.owner_id, b.created_at, b.archived_at, b.version
 FROM boards b
          JOIN board_members bm ON bm.board_id = b.id
-WHERE bm.user_id = $1
+WHERE bm.user_id = $1 AND b.archived_at IS NULL
 ORDER BY b.created_at;
 
+-- name: ListArchivedBoardsByOwner :many
+SELECT id, name, owner_id, created_at, archived_at, version
+FROM boards
+WHERE owner_id = $1 AND archived_at IS NOT NULL
+ORDER BY archived_at DESC;
+
 -- name: UpdateBoard :one
 UPDATE boards
-SET name = $2
-WHERE id = $1
-    RETURNING id, name, owner_id, created_at;
+SET name = $2, version = version + 1
+WHERE id = $1 AND version = $3 AND archived_at IS NULL
+    RETURNING id, name, owner_id, created_at, archived_at, version;
+
+-- name: ArchiveBoard :one
+UPDATE boards
+SET archived_at = NOW(), version = version + 1
+WHERE id = $1 AND archived_at IS NULL
+    RETURNING id, name, owner_id, created_at, archived_at, version;
+
+-- name: RestoreBoard :one
+UPDATE boards
+SET archived_at = NULL, version = version + 1
+WHERE id = $1 AND archived_at IS NOT NULL
+    RETURNING id, name, owner_id, created_at, archived_at, version;
 
 -- name: DeleteBoard :exec
 DELETE FROM boards
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: PurgeArchivedBoards :many
+WITH purged AS (
+    DELETE FROM boards
+    WHERE archived_at < $1
+    RETURNING id
+)
+SELECT p.id, a.storage_key
+FROM purged p
+         LEFT JOIN lists l ON l.board_id = p.id
+         LEFT JOIN cards c ON c.list_id = l.id
+         LEFT JOIN card_attachments a ON a.card_id = c.id;
@@ -0,0 +1,38 @@
+-- name: AddCardAssignee :exec
+INSERT INTO card_assignees (card_id, user_id)
+VALUES ($1, $2)
+    ON CONFLICT DO NOTHING;
+
+-- name: RemoveCardAssignee :exec
+DELETE FROM card_assignees
+WHERE card_id = $1 AND user_id = $2;
+
+-- name: ListCardAssigneesByCardIDs :many
+SELECT ca.card_id, u.id AS user_id, u.name, u.email, ca.assigned_at
+FROM card_assignees ca
+         JOIN users u ON u.id = ca.user_id
+WHERE ca.card_id = ANY(sqlc.arg(card_ids)::int[])
+ORDER BY ca.assigned_at, u.name;
+
+-- name: DeleteBoardAssigneesForUser :many
+DELETE FROM card_assignees ca
+    USING cards c, lists l
+WHERE ca.card_id = c.id
+  AND c.list_id = l.id
+  AND l.board_id = $1
+  AND ca.user_id = $2
+    RETURNING ca.card_id;
+
+-- name: ListCardsAssignedToUser :many
+SELECT c.id, c.list_id, c.title, c.description, c.position, c.created_at, c.start_at, c.due_at, c.completed, c.archived_at, c.version,
+       l.board_id, b.name AS board_name, l.title AS list_title
+FROM card_assignees ca
+         JOIN cards c ON c.id = ca.card_id
+         JOIN lists l ON l.id = c.list_id
+         JOIN boards b ON b.id = l.board_id
+         JOIN board_members bm ON bm.board_id = l.board_id AND bm.user_id = ca.user_id
+WHERE ca.user_id = $1
+  AND c.archived_at IS NULL
+  AND l.archived_at IS NULL
+  AND b.archived_at IS NULL
+ORDER BY c.due_at NULLS LAST, c.created_at;
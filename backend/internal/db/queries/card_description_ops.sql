@@ -0,0 +1,34 @@
+-- name: GetCardDescriptionState :one
+SELECT c.description, l.board_id, COALESCE(r.revision, 0)::int AS revision
+FROM cards c
+         JOIN lists l ON l.id = c.list_id
+         LEFT JOIN card_description_revisions r ON r.card_id = c.id
+WHERE c.id = $1 AND c.archived_at IS NULL;
+
+-- name: ListCardDescriptionOpsSince :many
+SELECT card_id, revision, user_id, op, created_at
+FROM card_description_ops
+WHERE card_id = $1 AND revision > $2
+ORDER BY revision;
+
+-- name: AppendCardDescriptionOp :execrows
+INSERT INTO card_description_ops (card_id, revision, user_id, op)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (card_id, revision) DO NOTHING;
+
+-- name: SaveCardDescription :execrows
+WITH marker AS (
+    INSERT INTO card_description_revisions (card_id, revision)
+        VALUES ($1, $2)
+        ON CONFLICT (card_id) DO UPDATE SET revision = EXCLUDED.revision
+            WHERE card_description_revisions.revision < EXCLUDED.revision
+        RETURNING card_id
+)
+UPDATE cards
+SET description = $3,
+    version = CASE WHEN description IS DISTINCT FROM $3 THEN version + 1 ELSE version END
+WHERE id IN (SELECT card_id FROM marker);
+
+-- name: PruneCardDescriptionOps :exec
+DELETE FROM card_description_ops
+WHERE card_id = $1 AND revision <= $2;
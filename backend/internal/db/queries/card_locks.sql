@@ -0,0 +1,27 @@
+-- name: AcquireCardLock :one
+INSERT INTO card_locks (card_id, board_id, user_id, expires_at)
+VALUES ($1, $2, $3, NOW() + $4::INT * INTERVAL '1 second')
+ON CONFLICT (card_id) DO UPDATE
+    SET board_id = EXCLUDED.board_id, user_id = EXCLUDED.user_id, expires_at = EXCLUDED.expires_at
+WHERE card_locks.user_id = EXCLUDED.user_id OR card_locks.expires_at < NOW()
+    RETURNING card_id, board_id, user_id, expires_at;
+
+-- name: GetCardLock :one
+SELECT card_id, board_id, user_id, expires_at
+FROM card_locks
+WHERE card_id = $1 AND expires_at > NOW();
+
+-- name: ListCardLocksByBoard :many
+SELECT card_id, board_id, user_id, expires_at
+FROM card_locks
+WHERE board_id = $1 AND expires_at > NOW()
+ORDER BY card_id;
+
+-- name: ReleaseCardLock :execrows
+DELETE FROM card_locks
+WHERE card_id = $1 AND user_id = $2;
+
+-- name: ReleaseUserCardLocks :many
+DELETE FROM card_locks
+WHERE board_id = $1 AND user_id = $2
+    RETURNING card_id;
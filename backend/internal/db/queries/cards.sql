@@ -1,36 +1,107 @@
 -- name: CreateCard :one
 INSERT INTO cards (list_id, title, description, position)
 VALUES ($1, $2, $3, $4)
-    RETURNING id, list_id, title, description, position, created_at;
+    RETURNING id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version;
 
 -- name: GetCardByID :one
-SELECT id, list_id, title, description, position, created_at
+SELECT id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
 FROM cards
-WHERE id = $1;
+WHERE id = $1 AND archived_at IS NULL;
+
+-- name: GetArchivedCardByID :one
+SELECT id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
+FROM cards
+WHERE id = $1 AND archived_at IS NOT NULL;
 
 -- name: ListCardsByList :many
-SELECT id, list_id, title, description, position, created_at
+SELECT id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
 FROM cards
-WHERE list_id = $1
+WHERE list_id = $1 AND archived_at IS NULL
 ORDER BY position;
 
+-- name: ListCardsByBoard :many
+SELECT c.id, c.list_id, c.title, c.description, c.position, c.created_at, c.start_at, c.due_at, c.completed, c.archived_at, c.version
+FROM cards c
+         JOIN lists l ON l.id = c.list_id
+WHERE l.board_id = $1 AND l.archived_at IS NULL AND c.archived_at IS NULL
+ORDER BY l.position, c.position;
+
+-- name: ListArchivedCardsByBoard :many
+SELECT c.id, c.list_id, c.title, c.description, c.position, c.created_at, c.start_at, c.due_at, c.completed, c.archived_at, c.version,
+       l.title AS list_title
+FROM cards c
+         JOIN lists l ON l.id = c.list_id
+WHERE l.board_id = $1 AND c.archived_at IS NOT NULL
+ORDER BY c.archived_at DESC;
+
+-- name: ListOverdueCardsByList :many
+SELECT id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
+FROM cards
+WHERE list_id = sqlc.arg(list_id)
+  AND archived_at IS NULL
+  AND NOT completed
+  AND due_at < sqlc.arg(now)
+ORDER BY due_at, position;
+
+-- name: ListCardsDueBetween :many
+SELECT id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
+FROM cards
+WHERE list_id = sqlc.arg(list_id)
+  AND archived_at IS NULL
+  AND NOT completed
+  AND due_at >= sqlc.arg(due_from)
+  AND due_at < sqlc.arg(due_to)
+ORDER BY due_at, position;
+
 -- name: UpdateCard :one
 UPDATE cards
 SET title = $2,
     description = $3,
     position = $4,
-    list_id = $5
-WHERE id = $1
-    RETURNING id, list_id, title, description, position, created_at;
+    list_id = $5,
+    start_at = $6,
+    due_at = $7,
+    completed = $8,
+    version = version + 1
+WHERE id = $1 AND version = $9 AND archived_at IS NULL
+    RETURNING id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version;
+
+-- name: SetCardPosition :exec
+UPDATE cards SET position = $2
+WHERE id = $1;
 
 -- name: IncCardPosAfter :exec
 UPDATE cards SET position = position + 1
-WHERE list_id = $1 AND position >= $2;
+WHERE list_id = $1 AND position >= $2 AND archived_at IS NULL;
 
 -- name: DecCardPosAfter :exec
 UPDATE cards SET position = position - 1
-WHERE list_id = $1 AND position >  $2;
+WHERE list_id = $1 AND position >  $2 AND archived_at IS NULL;
 
--- name: DeleteCard :exec
-DELETE FROM cards
-WHERE id = $1;
+-- name: ArchiveCard :one
+UPDATE cards
+SET archived_at = NOW(), version = version + 1
+WHERE id = $1 AND archived_at IS NULL
+    RETURNING id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version;
+
+-- name: RestoreCard :one
+UPDATE cards
+SET archived_at = NULL, position = $2, version = version + 1
+WHERE id = $1 AND archived_at IS NOT NULL
+    RETURNING id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version;
+
+-- name: PurgeArchivedCards :many
+WITH purged AS (
+    DELETE FROM cards
+    WHERE archived_at < $1
+    RETURNING id
+)
+SELECT p.id, a.storage_key
+FROM purged p
+         LEFT JOIN card_attachments a ON a.card_id = p.id;
+
+-- name: GetCardBoardID :one
+SELECT l.board_id
+FROM cards c
+         JOIN lists l ON l.id = c.list_id
+WHERE c.id = $1;
@@ -0,0 +1,101 @@
+-- name: CreateChecklist :one
+INSERT INTO card_checklists (card_id, title, position)
+VALUES ($1, $2, $3)
+    RETURNING id, card_id, title, position, created_at;
+
+-- name: GetChecklistByID :one
+SELECT id, card_id, title, position, created_at
+FROM card_checklists
+WHERE id = $1;
+
+-- name: ListChecklistsByCard :many
+SELECT id, card_id, title, position, created_at
+FROM card_checklists
+WHERE card_id = $1
+ORDER BY position;
+
+-- name: CountChecklistsByCard :one
+SELECT COUNT(*)::int AS count
+FROM card_checklists
+WHERE card_id = $1;
+
+-- name: UpdateChecklistTitle :one
+UPDATE card_checklists
+SET title = $2
+WHERE id = $1
+    RETURNING id, card_id, title, position, created_at;
+
+-- name: DecChecklistPosAfter :exec
+UPDATE card_checklists SET position = position - 1
+WHERE card_id = $1 AND position >  $2;
+
+-- name: DeleteChecklist :exec
+DELETE FROM card_checklists
+WHERE id = $1;
+
+-- name: CreateChecklistItem :one
+INSERT INTO checklist_items (checklist_id, title, position)
+VALUES ($1, $2, $3)
+    RETURNING id, checklist_id, title, done, position, created_at;
+
+-- name: GetChecklistItemByID :one
+SELECT id, checklist_id, title, done, position, created_at
+FROM checklist_items
+WHERE id = $1;
+
+-- name: ListChecklistItemsByCard :many
+SELECT i.id, i.checklist_id, i.title, i.done, i.position, i.created_at
+FROM checklist_items i
+         JOIN card_checklists cl ON cl.id = i.checklist_id
+WHERE cl.card_id = $1
+ORDER BY cl.position, i.position;
+
+-- name: CountChecklistItems :one
+SELECT COUNT(*)::int AS count
+FROM checklist_items
+WHERE checklist_id = $1;
+
+-- name: UpdateChecklistItem :one
+UPDATE checklist_items
+SET title = $2,
+    done = $3,
+    position = $4,
+    checklist_id = $5
+WHERE id = $1
+    RETURNING id, checklist_id, title, done, position, created_at;
+
+-- name: IncChecklistItemPosAfter :exec
+UPDATE checklist_items SET position = position + 1
+WHERE checklist_id = $1 AND position >= $2;
+
+-- name: DecChecklistItemPosAfter :exec
+UPDATE checklist_items SET position = position - 1
+WHERE checklist_id = $1 AND position >  $2;
+
+-- name: DeleteChecklistItem :exec
+DELETE FROM checklist_items
+WHERE id = $1;
+
+-- name: ChecklistProgressByCardIDs :many
+SELECT cl.card_id,
+       COUNT(*) FILTER (WHERE i.done)::int AS done,
+       COUNT(*)::int AS total
+FROM checklist_items i
+         JOIN card_checklists cl ON cl.id = i.checklist_id
+WHERE cl.card_id = ANY(sqlc.arg(card_ids)::int[])
+GROUP BY cl.card_id;
+
+-- name: GetChecklistBoardID :one
+SELECT l.board_id
+FROM card_checklists cl
+         JOIN cards c ON c.id = cl.card_id
+         JOIN lists l ON l.id = c.list_id
+WHERE cl.id = $1;
+
+-- name: GetChecklistItemBoardID :one
+SELECT l.board_id
+FROM checklist_items ci
+         JOIN card_checklists cl ON cl.id = ci.checklist_id
+         JOIN cards c ON c.id = cl.card_id
+         JOIN lists l ON l.id = c.list_id
+WHERE ci.id = $1;
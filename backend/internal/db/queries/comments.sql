@@ -0,0 +1,52 @@
+-- name: CreateComment :one
+INSERT INTO card_comments (card_id, author_id, parent_id, body)
+VALUES ($1, $2, $3, $4)
+    RETURNING id, card_id, author_id, parent_id, body, created_at, updated_at, deleted_at;
+
+-- name: GetCommentByID :one
+SELECT id, card_id, author_id, parent_id, body, created_at, updated_at, deleted_at
+FROM card_comments
+WHERE id = $1;
+
+-- name: ListCommentsByCard :many
+SELECT c.id, c.card_id, c.author_id, c.parent_id, c.body, c.created_at, c.updated_at, c.deleted_at,
+       u.name AS author_name
+FROM card_comments c
+         JOIN users u ON u.id = c.author_id
+WHERE c.card_id = $1
+ORDER BY c.created_at, c.id;
+
+-- name: GetCommentForUpdate :one
+SELECT id, card_id, author_id, parent_id, body, created_at, updated_at, deleted_at
+FROM card_comments
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: UpdateCommentBody :one
+UPDATE card_comments
+SET body = $2, updated_at = NOW()
+WHERE id = $1
+    RETURNING id, card_id, author_id, parent_id, body, created_at, updated_at, deleted_at;
+
+-- name: SoftDeleteComment :one
+WITH purged AS (
+    -- earlier versions of the text go with the comment
+    DELETE FROM card_comment_revisions WHERE comment_id = $1
+)
+UPDATE card_comments
+SET body = '', deleted_at = NOW()
+WHERE id = $1
+    RETURNING id, card_id, author_id, parent_id, body, created_at, updated_at, deleted_at;
+
+-- name: CreateCommentRevision :one
+INSERT INTO card_comment_revisions (comment_id, editor_id, action, body)
+VALUES ($1, $2, $3, $4)
+    RETURNING id, comment_id, editor_id, action, body, created_at;
+
+-- name: ListCommentRevisions :many
+SELECT r.id, r.comment_id, r.editor_id, r.action, r.body, r.created_at,
+       u.name AS editor_name
+FROM card_comment_revisions r
+         JOIN users u ON u.id = r.editor_id
+WHERE r.comment_id = $1
+ORDER BY r.created_at, r.id;
@@ -0,0 +1,12 @@
+-- name: CreateEmailVerification :exec
+INSERT INTO email_verifications (token_hash, user_id, expires_at)
+VALUES ($1, $2, NOW() + $3::INT * INTERVAL '1 second');
+
+-- name: RedeemEmailVerification :one
+DELETE FROM email_verifications
+WHERE token_hash = $1 AND expires_at > NOW()
+    RETURNING user_id;
+
+-- name: DeleteEmailVerificationsByUser :exec
+DELETE FROM email_verifications
+WHERE user_id = $1;
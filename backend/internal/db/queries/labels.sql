@@ -0,0 +1,47 @@
+-- name: CreateLabel :one
+INSERT INTO labels (board_id, name, color)
+VALUES ($1, $2, $3)
+    RETURNING id, board_id, name, color, created_at;
+
+-- name: GetLabelByID :one
+SELECT id, board_id, name, color, created_at
+FROM labels
+WHERE id = $1;
+
+-- name: ListLabelsByBoard :many
+SELECT id, board_id, name, color, created_at
+FROM labels
+WHERE board_id = $1
+ORDER BY name;
+
+-- name: UpdateLabel :one
+UPDATE labels
+SET name = $2, color = $3
+WHERE id = $1
+    RETURNING id, board_id, name, color, created_at;
+
+-- name: DeleteLabel :exec
+DELETE FROM labels
+WHERE id = $1;
+
+-- name: AttachCardLabel :exec
+INSERT INTO card_labels (card_id, label_id)
+VALUES ($1, $2)
+    ON CONFLICT DO NOTHING;
+
+-- name: DetachCardLabel :exec
+DELETE FROM card_labels
+WHERE card_id = $1 AND label_id = $2;
+
+-- name: CopyCardLabels :exec
+INSERT INTO card_labels (card_id, label_id)
+SELECT sqlc.arg(dst_card_id)::int, cl.label_id
+FROM card_labels cl
+WHERE cl.card_id = sqlc.arg(src_card_id);
+
+-- name: ListCardLabelsByCardIDs :many
+SELECT cl.card_id, l.id, l.board_id, l.name, l.color, l.created_at
+FROM card_labels cl
+         JOIN labels l ON l.id = cl.label_id
+WHERE cl.card_id = ANY(sqlc.arg(card_ids)::int[])
+ORDER BY l.name;
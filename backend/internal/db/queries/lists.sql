@@ -1,33 +1,72 @@
 -- name: CreateList :one
 INSERT INTO lists (board_id, title, position)
 VALUES ($1, $2, $3)
-    RETURNING id, board_id, title, position, created_at;
+    RETURNING id, board_id, title, position, created_at, archived_at, version;
 
 -- name: GetListByID :one
-SELECT id, board_id, title, position, created_at
+SELECT id, board_id, title, position, created_at, archived_at, version
 FROM lists
-WHERE id = $1;
+WHERE id = $1 AND archived_at IS NULL;
+
+-- name: GetArchivedListByID :one
+SELECT id, board_id, title, position, created_at, archived_at, version
+FROM lists
+WHERE id = $1 AND archived_at IS NOT NULL;
 
 -- name: ListListsByBoard :many
-SELECT id, board_id, title, position, created_at
+SELECT id, board_id, title, position, created_at, archived_at, version
 FROM lists
-WHERE board_id = $1
+WHERE board_id = $1 AND archived_at IS NULL
 ORDER BY position;
 
+-- name: ListArchivedListsByBoard :many
+SELECT id, board_id, title, position, created_at, archived_at, version
+FROM lists
+WHERE board_id = $1 AND archived_at IS NOT NULL
+ORDER BY archived_at DESC;
+
 -- name: UpdateList :one
 UPDATE lists
-SET title = $2, position = $3
-WHERE id = $1
-    RETURNING id, board_id, title, position, created_at;
+SET title = $2, position = $3, version = version + 1
+WHERE id = $1 AND version = $4 AND archived_at IS NULL
+    RETURNING id, board_id, title, position, created_at, archived_at, version;
+
+-- name: SetListPosition :exec
+UPDATE lists SET position = $2
+WHERE id = $1;
 
 -- name: IncListPosAfter :exec
 UPDATE lists SET position = position + 1
-WHERE board_id = $1 AND position >= $2;
+WHERE board_id = $1 AND position >= $2 AND archived_at IS NULL;
 
 -- name: DecListPosAfter :exec
 UPDATE lists SET position = position - 1
-WHERE board_id = $1 AND position >  $2;
+WHERE board_id = $1 AND position >  $2 AND archived_at IS NULL;
 
--- name: DeleteList :exec
-DELETE FROM lists
+-- name: ArchiveList :one
+UPDATE lists
+SET archived_at = NOW(), version = version + 1
+WHERE id = $1 AND archived_at IS NULL
+    RETURNING id, board_id, title, position, created_at, archived_at, version;
+
+-- name: RestoreList :one
+UPDATE lists
+SET archived_at = NULL, position = $2, version = version + 1
+WHERE id = $1 AND archived_at IS NOT NULL
+    RETURNING id, board_id, title, position, created_at, archived_at, version;
+
+-- name: PurgeArchivedLists :many
+WITH purged AS (
+    DELETE FROM lists
+    WHERE archived_at < $1
+    RETURNING id
+)
+SELECT p.id, a.storage_key
+FROM purged p
+         LEFT JOIN cards c ON c.list_id = p.id
+         LEFT JOIN card_attachments a ON a.card_id = c.id;
+
+-- name: GetListBoardID :one
+SELECT board_id
+FROM lists
 WHERE id = $1;
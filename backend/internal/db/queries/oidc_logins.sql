@@ -0,0 +1,12 @@
+-- name: CreateOidcLogin :exec
+INSERT INTO oidc_logins (state_hash, nonce, code_verifier, expires_at)
+VALUES ($1, $2, $3, NOW() + $4::INT * INTERVAL '1 second');
+
+-- name: RedeemOidcLogin :one
+DELETE FROM oidc_logins
+WHERE state_hash = $1 AND expires_at > NOW()
+    RETURNING nonce, code_verifier;
+
+-- name: DeleteExpiredOidcLogins :exec
+DELETE FROM oidc_logins
+WHERE expires_at <= NOW();
@@ -0,0 +1,12 @@
+-- name: CreatePasswordReset :exec
+INSERT INTO password_resets (token_hash, user_id, expires_at)
+VALUES ($1, $2, NOW() + $3::INT * INTERVAL '1 second');
+
+-- name: RedeemPasswordReset :one
+DELETE FROM password_resets
+WHERE token_hash = $1 AND expires_at > NOW()
+    RETURNING user_id;
+
+-- name: DeletePasswordResetsByUser :exec
+DELETE FROM password_resets
+WHERE user_id = $1;
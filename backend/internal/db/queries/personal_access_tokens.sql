@@ -0,0 +1,28 @@
+-- name: CreatePersonalAccessToken :one
+INSERT INTO personal_access_tokens (user_id, name, token_hash, token_prefix, scopes, board_ids, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+    RETURNING id, user_id, name, token_hash, token_prefix, scopes, board_ids, created_at, last_used_at, expires_at;
+
+-- name: GetPersonalAccessTokenByHash :one
+SELECT id, user_id, name, token_hash, token_prefix, scopes, board_ids, created_at, last_used_at, expires_at
+FROM personal_access_tokens
+WHERE token_hash = $1 AND (expires_at IS NULL OR expires_at > NOW());
+
+-- name: ListPersonalAccessTokensByUser :many
+SELECT id, user_id, name, token_hash, token_prefix, scopes, board_ids, created_at, last_used_at, expires_at
+FROM personal_access_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: TouchPersonalAccessToken :exec
+UPDATE personal_access_tokens
+SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
+
+-- name: DeletePersonalAccessToken :execrows
+DELETE FROM personal_access_tokens
+WHERE id = $1 AND user_id = $2;
+
+-- name: DeletePersonalAccessTokensByUser :exec
+DELETE FROM personal_access_tokens
+WHERE user_id = $1;
@@ -0,0 +1,42 @@
+-- name: CreateSession :one
+INSERT INTO sessions (user_id, refresh_token_hash, user_agent, ip_address, expires_at)
+VALUES ($1, $2, $3, $4, NOW() + $5::INT * INTERVAL '1 second')
+    RETURNING id, user_id, refresh_token_hash, previous_token_hash, user_agent, ip_address, created_at, last_seen_at, expires_at;
+
+-- name: RotateSession :one
+UPDATE sessions
+SET refresh_token_hash = $2,
+    previous_token_hash = refresh_token_hash,
+    user_agent = $3,
+    ip_address = $4,
+    last_seen_at = NOW(),
+    expires_at = NOW() + $5::INT * INTERVAL '1 second'
+WHERE refresh_token_hash = $1 AND expires_at > NOW()
+    RETURNING id, user_id, refresh_token_hash, previous_token_hash, user_agent, ip_address, created_at, last_seen_at, expires_at;
+
+-- name: DeleteSessionByPreviousToken :execrows
+DELETE FROM sessions
+WHERE previous_token_hash = $1
+  AND last_seen_at < NOW() - $2::INT * INTERVAL '1 second';
+
+-- name: DeleteSessionByToken :execrows
+DELETE FROM sessions
+WHERE refresh_token_hash = $1;
+
+-- name: ListSessionsByUser :many
+SELECT id, user_id, refresh_token_hash, previous_token_hash, user_agent, ip_address, created_at, last_seen_at, expires_at
+FROM sessions
+WHERE user_id = $1 AND expires_at > NOW()
+ORDER BY last_seen_at DESC;
+
+-- name: DeleteSession :execrows
+DELETE FROM sessions
+WHERE id = $1 AND user_id = $2;
+
+-- name: DeleteOtherSessions :exec
+DELETE FROM sessions
+WHERE user_id = $1 AND id <> $2;
+
+-- name: DeleteExpiredSessions :exec
+DELETE FROM sessions
+WHERE user_id = $1 AND expires_at <= NOW();
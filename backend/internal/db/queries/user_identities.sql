@@ -0,0 +1,9 @@
+-- name: GetUserIdentity :one
+SELECT id, user_id, issuer, subject, email, created_at
+FROM user_identities
+WHERE issuer = $1 AND subject = $2;
+
+-- name: CreateUserIdentity :one
+INSERT INTO user_identities (user_id, issuer, subject, email)
+VALUES ($1, $2, $3, $4)
+    RETURNING id, user_id, issuer, subject, email, created_at;
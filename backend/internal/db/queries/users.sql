@@ -1,15 +1,15 @@
 -- name: CreateUser :one
 INSERT INTO users (name, email, password_hash)
 VALUES ($1, $2, $3)
-    RETURNING id, name, email, password_hash, created_at;
+    RETURNING id, name, email, password_hash, created_at, email_verified_at;
 
 -- name: GetUserByID :one
-SELECT id, name, email, password_hash, created_at
+SELECT id, name, email, password_hash, created_at, email_verified_at
 FROM users
 WHERE id = $1;
 
 -- name: GetUserByEmail :one
-SELECT id, name, email, password_hash, created_at
+SELECT id, name, email, password_hash, created_at, email_verified_at
 FROM users
 WHERE email = $1;
 
@@ -17,13 +17,18 @@ WHERE email = $1;
 UPDATE users
 SET name = $2, email = $3
 WHERE id = $1
-    RETURNING id, name, email, password_hash, created_at;
+    RETURNING id, name, email, password_hash, created_at, email_verified_at;
 
 -- name: UpdatePasswordHash :one
 UPDATE users
 SET password_hash = $2
 WHERE id = $1
-    RETURNING id, name, email, password_hash, created_at;
+    RETURNING id, name, email, password_hash, created_at, email_verified_at;
+
+-- name: MarkEmailVerified :exec
+UPDATE users
+SET email_verified_at = NOW()
+WHERE id = $1 AND email_verified_at IS NULL;
 
 -- name: DeleteUser :exec
 DELETE FROM users
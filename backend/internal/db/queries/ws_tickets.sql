@@ -0,0 +1,12 @@
+-- name: CreateWsTicket :exec
+INSERT INTO ws_tickets (token_hash, user_id, board_id, expires_at)
+VALUES ($1, $2, $3, NOW() + $4::INT * INTERVAL '1 second');
+
+-- name: RedeemWsTicket :one
+DELETE FROM ws_tickets
+WHERE token_hash = $1 AND expires_at > NOW()
+    RETURNING user_id, board_id;
+
+-- name: DeleteExpiredWsTickets :exec
+DELETE FROM ws_tickets
+WHERE expires_at <= NOW();
@@ -46,9 +46,10 @@ func (q *Queries) DeleteBoardMember(ctx context.Context, arg DeleteBoardMemberPa
 }
 
 const getBoardMember = `-- name: GetBoardMember :one
-SELECT board_id, user_id, role
-FROM board_members
-WHERE board_id = $1 AND user_id = $2
+SELECT bm.board_id, bm.user_id, bm.role
+FROM board_members bm
+         JOIN boards b ON b.id = bm.board_id
+WHERE bm.board_id = $1 AND bm.user_id = $2 AND b.archived_at IS NULL
 `
 
 type GetBoardMemberParams struct {
@@ -104,10 +105,10 @@ func (q *Queries) ListBoardMembers(ctx context.Context, boardID int32) ([]ListBo
 }
 
 const listBoardsByUser = `-- name: ListBoardsByUser :many
-SELECT b.id AS board_id, b.name, b.owner_id, b.created_at
+SELECT b.id AS board_id, b.name, b.owner_id, b.created_at, b.version
 FROM board_members bm
          JOIN boards b ON b.id = bm.board_id
-WHERE bm.user_id = $1
+WHERE bm.user_id = $1 AND b.archived_at IS NULL
 ORDER BY b.created_at
 `
 
@@ -116,6 +117,7 @@ type ListBoardsByUserRow struct {
 	Name      string
 	OwnerID   int32
 	CreatedAt pgtype.Timestamp
+	Version   int32
 }
 
 func (q *Queries) ListBoardsByUser(ctx context.Context, userID int32) ([]ListBoardsByUserRow, error) {
@@ -132,6 +134,7 @@ func (q *Queries) ListBoardsByUser(ctx context.Context, userID int32) ([]ListBoa
 			&i.Name,
 			&i.OwnerID,
 			&i.CreatedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -144,10 +147,10 @@ func (q *Queries) ListBoardsByUser(ctx context.Context, userID int32) ([]ListBoa
 }
 
 const listBoardsByUserAndRole = `-- name: ListBoardsByUserAndRole :many
-SELECT b.id AS board_id, b.name, b.owner_id, b.created_at, bm.role
+SELECT b.id AS board_id, b.name, b.owner_id, b.created_at, b.version, bm.role
 FROM board_members bm
          JOIN boards b ON b.id = bm.board_id
-WHERE bm.user_id = $1 AND bm.role = $2
+WHERE bm.user_id = $1 AND bm.role = $2 AND b.archived_at IS NULL
 ORDER BY b.created_at
 `
 
@@ -161,6 +164,7 @@ type ListBoardsByUserAndRoleRow struct {
 	Name      string
 	OwnerID   int32
 	CreatedAt pgtype.Timestamp
+	Version   int32
 	Role      string
 }
 
@@ -178,6 +182,7 @@ func (q *Queries) ListBoardsByUserAndRole(ctx context.Context, arg ListBoardsByU
 			&i.Name,
 			&i.OwnerID,
 			&i.CreatedAt,
+			&i.Version,
 			&i.Role,
 		); err != nil {
 			return nil, err
@@ -7,12 +7,35 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveBoard = `-- name: ArchiveBoard :one
+UPDATE boards
+SET archived_at = NOW(), version = version + 1
+WHERE id = $1 AND archived_at IS NULL
+    RETURNING id, name, owner_id, created_at, archived_at, version
+`
+
+func (q *Queries) ArchiveBoard(ctx context.Context, id int32) (Board, error) {
+	row := q.db.QueryRow(ctx, archiveBoard, id)
+	var i Board
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
 const createBoard = `-- name: CreateBoard :one
 INSERT INTO boards (name, owner_id)
 VALUES ($1, $2)
-    RETURNING id, name, owner_id, created_at
+    RETURNING id, name, owner_id, created_at, archived_at, version
 `
 
 type CreateBoardParams struct {
@@ -28,6 +51,8 @@ func (q *Queries) CreateBoard(ctx context.Context, arg CreateBoardParams) (Board
 		&i.Name,
 		&i.OwnerID,
 		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -43,7 +68,7 @@ func (q *Queries) DeleteBoard(ctx context.Context, id int32) error {
 }
 
 const getBoardByID = `-- name: GetBoardByID :one
-SELECT id, name, owner_id, created_at
+SELECT id, name, owner_id, created_at, archived_at, version
 FROM boards
 WHERE id = $1
 `
@@ -56,13 +81,50 @@ func (q *Queries) GetBoardByID(ctx context.Context, id int32) (Board, error) {
 		&i.Name,
 		&i.OwnerID,
 		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
+const listArchivedBoardsByOwner = `-- name: ListArchivedBoardsByOwner :many
+SELECT id, name, owner_id, created_at, archived_at, version
+FROM boards
+WHERE owner_id = $1 AND archived_at IS NOT NULL
+ORDER BY archived_at DESC
+`
+
+func (q *Queries) ListArchivedBoardsByOwner(ctx context.Context, ownerID int32) ([]Board, error) {
+	rows, err := q.db.Query(ctx, listArchivedBoardsByOwner, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Board
+	for rows.Next() {
+		var i Board
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.ArchivedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listBoards = `-- name: ListBoards :many
-SELECT id, name, owner_id, created_at
+SELECT id, name, owner_id, created_at, archived_at, version
 FROM boards
+WHERE archived_at IS NULL
 ORDER BY created_at
 `
 
@@ -80,6 +142,8 @@ func (q *Queries) ListBoards(ctx context.Context) ([]Board, error) {
 			&i.Name,
 			&i.OwnerID,
 			&i.CreatedAt,
+			&i.ArchivedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -92,10 +156,10 @@ func (q *Queries) ListBoards(ctx context.Context) ([]Board, error) {
 }
 
 const listBoardsByMember = `-- name: ListBoardsByMember :many
-SELECT b.id, b.name, b.owner_id, b.created_at
+SELECT b.id, b.name, b.owner_id, b.created_at, b.archived_at, b.version
 FROM boards b
          JOIN board_members bm ON bm.board_id = b.id
-WHERE bm.user_id = $1
+WHERE bm.user_id = $1 AND b.archived_at IS NULL
 ORDER BY b.created_at
 `
 
@@ -113,6 +177,8 @@ func (q *Queries) ListBoardsByMember(ctx context.Context, userID int32) ([]Board
 			&i.Name,
 			&i.OwnerID,
 			&i.CreatedAt,
+			&i.ArchivedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -124,26 +190,88 @@ func (q *Queries) ListBoardsByMember(ctx context.Context, userID int32) ([]Board
 	return items, nil
 }
 
+const purgeArchivedBoards = `-- name: PurgeArchivedBoards :many
+WITH purged AS (
+    DELETE FROM boards
+    WHERE archived_at < $1
+    RETURNING id
+)
+SELECT p.id, a.storage_key
+FROM purged p
+         LEFT JOIN lists l ON l.board_id = p.id
+         LEFT JOIN cards c ON c.list_id = l.id
+         LEFT JOIN card_attachments a ON a.card_id = c.id
+`
+
+type PurgeArchivedBoardsRow struct {
+	ID         int32
+	StorageKey pgtype.Text
+}
+
+func (q *Queries) PurgeArchivedBoards(ctx context.Context, archivedAt pgtype.Timestamp) ([]PurgeArchivedBoardsRow, error) {
+	rows, err := q.db.Query(ctx, purgeArchivedBoards, archivedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PurgeArchivedBoardsRow
+	for rows.Next() {
+		var i PurgeArchivedBoardsRow
+		if err := rows.Scan(&i.ID, &i.StorageKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreBoard = `-- name: RestoreBoard :one
+UPDATE boards
+SET archived_at = NULL, version = version + 1
+WHERE id = $1 AND archived_at IS NOT NULL
+    RETURNING id, name, owner_id, created_at, archived_at, version
+`
+
+func (q *Queries) RestoreBoard(ctx context.Context, id int32) (Board, error) {
+	row := q.db.QueryRow(ctx, restoreBoard, id)
+	var i Board
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
 const updateBoard = `-- name: UpdateBoard :one
 UPDATE boards
-SET name = $2
-WHERE id = $1
-    RETURNING id, name, owner_id, created_at
+SET name = $2, version = version + 1
+WHERE id = $1 AND version = $3 AND archived_at IS NULL
+    RETURNING id, name, owner_id, created_at, archived_at, version
 `
 
 type UpdateBoardParams struct {
-	ID   int32
-	Name string
+	ID      int32
+	Name    string
+	Version int32
 }
 
 func (q *Queries) UpdateBoard(ctx context.Context, arg UpdateBoardParams) (Board, error) {
-	row := q.db.QueryRow(ctx, updateBoard, arg.ID, arg.Name)
+	row := q.db.QueryRow(ctx, updateBoard, arg.ID, arg.Name, arg.Version)
 	var i Board
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.OwnerID,
 		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -11,10 +11,36 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveCard = `-- name: ArchiveCard :one
+UPDATE cards
+SET archived_at = NOW(), version = version + 1
+WHERE id = $1 AND archived_at IS NULL
+    RETURNING id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
+`
+
+func (q *Queries) ArchiveCard(ctx context.Context, id int32) (Card, error) {
+	row := q.db.QueryRow(ctx, archiveCard, id)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.ListID,
+		&i.Title,
+		&i.Description,
+		&i.Position,
+		&i.CreatedAt,
+		&i.StartAt,
+		&i.DueAt,
+		&i.Completed,
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
 const createCard = `-- name: CreateCard :one
 INSERT INTO cards (list_id, title, description, position)
 VALUES ($1, $2, $3, $4)
-    RETURNING id, list_id, title, description, position, created_at
+    RETURNING id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
 `
 
 type CreateCardParams struct {
@@ -39,13 +65,18 @@ func (q *Queries) CreateCard(ctx context.Context, arg CreateCardParams) (Card, e
 		&i.Description,
 		&i.Position,
 		&i.CreatedAt,
+		&i.StartAt,
+		&i.DueAt,
+		&i.Completed,
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const decCardPosAfter = `-- name: DecCardPosAfter :exec
 UPDATE cards SET position = position - 1
-WHERE list_id = $1 AND position >  $2
+WHERE list_id = $1 AND position >  $2 AND archived_at IS NULL
 `
 
 type DecCardPosAfterParams struct {
@@ -58,20 +89,49 @@ func (q *Queries) DecCardPosAfter(ctx context.Context, arg DecCardPosAfterParams
 	return err
 }
 
-const deleteCard = `-- name: DeleteCard :exec
-DELETE FROM cards
-WHERE id = $1
+const getArchivedCardByID = `-- name: GetArchivedCardByID :one
+SELECT id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
+FROM cards
+WHERE id = $1 AND archived_at IS NOT NULL
 `
 
-func (q *Queries) DeleteCard(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteCard, id)
-	return err
+func (q *Queries) GetArchivedCardByID(ctx context.Context, id int32) (Card, error) {
+	row := q.db.QueryRow(ctx, getArchivedCardByID, id)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.ListID,
+		&i.Title,
+		&i.Description,
+		&i.Position,
+		&i.CreatedAt,
+		&i.StartAt,
+		&i.DueAt,
+		&i.Completed,
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getCardBoardID = `-- name: GetCardBoardID :one
+SELECT l.board_id
+FROM cards c
+         JOIN lists l ON l.id = c.list_id
+WHERE c.id = $1
+`
+
+func (q *Queries) GetCardBoardID(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRow(ctx, getCardBoardID, id)
+	var boardID int32
+	err := row.Scan(&boardID)
+	return boardID, err
 }
 
 const getCardByID = `-- name: GetCardByID :one
-SELECT id, list_id, title, description, position, created_at
+SELECT id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
 FROM cards
-WHERE id = $1
+WHERE id = $1 AND archived_at IS NULL
 `
 
 func (q *Queries) GetCardByID(ctx context.Context, id int32) (Card, error) {
@@ -84,13 +144,18 @@ func (q *Queries) GetCardByID(ctx context.Context, id int32) (Card, error) {
 		&i.Description,
 		&i.Position,
 		&i.CreatedAt,
+		&i.StartAt,
+		&i.DueAt,
+		&i.Completed,
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const incCardPosAfter = `-- name: IncCardPosAfter :exec
 UPDATE cards SET position = position + 1
-WHERE list_id = $1 AND position >= $2
+WHERE list_id = $1 AND position >= $2 AND archived_at IS NULL
 `
 
 type IncCardPosAfterParams struct {
@@ -103,10 +168,107 @@ func (q *Queries) IncCardPosAfter(ctx context.Context, arg IncCardPosAfterParams
 	return err
 }
 
+const listArchivedCardsByBoard = `-- name: ListArchivedCardsByBoard :many
+SELECT c.id, c.list_id, c.title, c.description, c.position, c.created_at, c.start_at, c.due_at, c.completed, c.archived_at, c.version,
+       l.title AS list_title
+FROM cards c
+         JOIN lists l ON l.id = c.list_id
+WHERE l.board_id = $1 AND c.archived_at IS NOT NULL
+ORDER BY c.archived_at DESC
+`
+
+type ListArchivedCardsByBoardRow struct {
+	ID          int32
+	ListID      int32
+	Title       string
+	Description pgtype.Text
+	Position    int32
+	CreatedAt   pgtype.Timestamp
+	StartAt     pgtype.Timestamp
+	DueAt       pgtype.Timestamp
+	Completed   bool
+	ArchivedAt  pgtype.Timestamp
+	Version     int32
+	ListTitle   string
+}
+
+func (q *Queries) ListArchivedCardsByBoard(ctx context.Context, boardID int32) ([]ListArchivedCardsByBoardRow, error) {
+	rows, err := q.db.Query(ctx, listArchivedCardsByBoard, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchivedCardsByBoardRow
+	for rows.Next() {
+		var i ListArchivedCardsByBoardRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ListID,
+			&i.Title,
+			&i.Description,
+			&i.Position,
+			&i.CreatedAt,
+			&i.StartAt,
+			&i.DueAt,
+			&i.Completed,
+			&i.ArchivedAt,
+			&i.Version,
+			&i.ListTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardsByBoard = `-- name: ListCardsByBoard :many
+SELECT c.id, c.list_id, c.title, c.description, c.position, c.created_at, c.start_at, c.due_at, c.completed, c.archived_at, c.version
+FROM cards c
+         JOIN lists l ON l.id = c.list_id
+WHERE l.board_id = $1 AND l.archived_at IS NULL AND c.archived_at IS NULL
+ORDER BY l.position, c.position
+`
+
+func (q *Queries) ListCardsByBoard(ctx context.Context, boardID int32) ([]Card, error) {
+	rows, err := q.db.Query(ctx, listCardsByBoard, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.ListID,
+			&i.Title,
+			&i.Description,
+			&i.Position,
+			&i.CreatedAt,
+			&i.StartAt,
+			&i.DueAt,
+			&i.Completed,
+			&i.ArchivedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCardsByList = `-- name: ListCardsByList :many
-SELECT id, list_id, title, description, position, created_at
+SELECT id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
 FROM cards
-WHERE list_id = $1
+WHERE list_id = $1 AND archived_at IS NULL
 ORDER BY position
 `
 
@@ -126,6 +288,11 @@ func (q *Queries) ListCardsByList(ctx context.Context, listID int32) ([]Card, er
 			&i.Description,
 			&i.Position,
 			&i.CreatedAt,
+			&i.StartAt,
+			&i.DueAt,
+			&i.Completed,
+			&i.ArchivedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -137,14 +304,196 @@ func (q *Queries) ListCardsByList(ctx context.Context, listID int32) ([]Card, er
 	return items, nil
 }
 
+const listCardsDueBetween = `-- name: ListCardsDueBetween :many
+SELECT id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
+FROM cards
+WHERE list_id = $1
+  AND archived_at IS NULL
+  AND NOT completed
+  AND due_at >= $2
+  AND due_at < $3
+ORDER BY due_at, position
+`
+
+type ListCardsDueBetweenParams struct {
+	ListID  int32
+	DueFrom pgtype.Timestamp
+	DueTo   pgtype.Timestamp
+}
+
+func (q *Queries) ListCardsDueBetween(ctx context.Context, arg ListCardsDueBetweenParams) ([]Card, error) {
+	rows, err := q.db.Query(ctx, listCardsDueBetween, arg.ListID, arg.DueFrom, arg.DueTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.ListID,
+			&i.Title,
+			&i.Description,
+			&i.Position,
+			&i.CreatedAt,
+			&i.StartAt,
+			&i.DueAt,
+			&i.Completed,
+			&i.ArchivedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverdueCardsByList = `-- name: ListOverdueCardsByList :many
+SELECT id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
+FROM cards
+WHERE list_id = $1
+  AND archived_at IS NULL
+  AND NOT completed
+  AND due_at < $2
+ORDER BY due_at, position
+`
+
+type ListOverdueCardsByListParams struct {
+	ListID int32
+	Now    pgtype.Timestamp
+}
+
+func (q *Queries) ListOverdueCardsByList(ctx context.Context, arg ListOverdueCardsByListParams) ([]Card, error) {
+	rows, err := q.db.Query(ctx, listOverdueCardsByList, arg.ListID, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.ListID,
+			&i.Title,
+			&i.Description,
+			&i.Position,
+			&i.CreatedAt,
+			&i.StartAt,
+			&i.DueAt,
+			&i.Completed,
+			&i.ArchivedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeArchivedCards = `-- name: PurgeArchivedCards :many
+WITH purged AS (
+    DELETE FROM cards
+    WHERE archived_at < $1
+    RETURNING id
+)
+SELECT p.id, a.storage_key
+FROM purged p
+         LEFT JOIN card_attachments a ON a.card_id = p.id
+`
+
+type PurgeArchivedCardsRow struct {
+	ID         int32
+	StorageKey pgtype.Text
+}
+
+func (q *Queries) PurgeArchivedCards(ctx context.Context, archivedAt pgtype.Timestamp) ([]PurgeArchivedCardsRow, error) {
+	rows, err := q.db.Query(ctx, purgeArchivedCards, archivedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PurgeArchivedCardsRow
+	for rows.Next() {
+		var i PurgeArchivedCardsRow
+		if err := rows.Scan(&i.ID, &i.StorageKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreCard = `-- name: RestoreCard :one
+UPDATE cards
+SET archived_at = NULL, position = $2, version = version + 1
+WHERE id = $1 AND archived_at IS NOT NULL
+    RETURNING id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
+`
+
+type RestoreCardParams struct {
+	ID       int32
+	Position int32
+}
+
+func (q *Queries) RestoreCard(ctx context.Context, arg RestoreCardParams) (Card, error) {
+	row := q.db.QueryRow(ctx, restoreCard, arg.ID, arg.Position)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.ListID,
+		&i.Title,
+		&i.Description,
+		&i.Position,
+		&i.CreatedAt,
+		&i.StartAt,
+		&i.DueAt,
+		&i.Completed,
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const setCardPosition = `-- name: SetCardPosition :exec
+UPDATE cards SET position = $2
+WHERE id = $1
+`
+
+type SetCardPositionParams struct {
+	ID       int32
+	Position int32
+}
+
+func (q *Queries) SetCardPosition(ctx context.Context, arg SetCardPositionParams) error {
+	_, err := q.db.Exec(ctx, setCardPosition, arg.ID, arg.Position)
+	return err
+}
+
 const updateCard = `-- name: UpdateCard :one
 UPDATE cards
 SET title = $2,
     description = $3,
     position = $4,
-    list_id = $5
-WHERE id = $1
-    RETURNING id, list_id, title, description, position, created_at
+    list_id = $5,
+    start_at = $6,
+    due_at = $7,
+    completed = $8,
+    version = version + 1
+WHERE id = $1 AND version = $9 AND archived_at IS NULL
+    RETURNING id, list_id, title, description, position, created_at, start_at, due_at, completed, archived_at, version
 `
 
 type UpdateCardParams struct {
@@ -153,6 +502,10 @@ type UpdateCardParams struct {
 	Description pgtype.Text
 	Position    int32
 	ListID      int32
+	StartAt     pgtype.Timestamp
+	DueAt       pgtype.Timestamp
+	Completed   bool
+	Version     int32
 }
 
 func (q *Queries) UpdateCard(ctx context.Context, arg UpdateCardParams) (Card, error) {
@@ -162,6 +515,10 @@ func (q *Queries) UpdateCard(ctx context.Context, arg UpdateCardParams) (Card, e
 		arg.Description,
 		arg.Position,
 		arg.ListID,
+		arg.StartAt,
+		arg.DueAt,
+		arg.Completed,
+		arg.Version,
 	)
 	var i Card
 	err := row.Scan(
@@ -171,6 +528,11 @@ func (q *Queries) UpdateCard(ctx context.Context, arg UpdateCardParams) (Card, e
 		&i.Description,
 		&i.Position,
 		&i.CreatedAt,
+		&i.StartAt,
+		&i.DueAt,
+		&i.Completed,
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -0,0 +1,135 @@
+// internal/db/sqlc/columns_test.go
+package db
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sqlComment = regexp.MustCompile(`--[^\n]*`)
+	returning  = regexp.MustCompile(`(?i)\bRETURNING\b`)
+	selectList = regexp.MustCompile(`(?is)\bSELECT\b(.*?)(?:\bFROM\b|$)`)
+)
+
+// resultColumns returns how many columns a query returns, judging by its
+// outermost RETURNING or SELECT list. Parenthesized parts (CTE bodies,
+// subqueries, function arguments) are skipped.
+func resultColumns(sql string) int {
+	sql = sqlComment.ReplaceAllString(sql, "")
+	var top strings.Builder
+	depth := 0
+	for _, r := range sql {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case depth == 0:
+			top.WriteRune(r)
+		}
+	}
+	s := top.String()
+
+	var list string
+	if loc := returning.FindAllStringIndex(s, -1); loc != nil {
+		list = s[loc[len(loc)-1][1]:]
+	} else if m := selectList.FindAllStringSubmatch(s, -1); m != nil {
+		list = m[len(m)-1][1]
+	} else {
+		return 0
+	}
+	return strings.Count(list, ",") + 1
+}
+
+// Generated code must scan exactly the columns its query returns, or pgx
+// fails on every non-empty result.
+func TestQueries_ScanMatchesColumns(t *testing.T) {
+	files, err := filepath.Glob("*.sql.go")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	fset := token.NewFileSet()
+	checked := 0
+	for _, path := range files {
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		require.NoError(t, err)
+
+		queries := map[string]string{}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				lit, ok := vs.Values[0].(*ast.BasicLit)
+				if !ok {
+					continue
+				}
+				sql, err := strconv.Unquote(lit.Value)
+				require.NoError(t, err)
+				queries[vs.Names[0].Name] = sql
+			}
+		}
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil {
+				continue
+			}
+			var query string
+			scans := -1
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				switch sel.Sel.Name {
+				case "Query", "QueryRow":
+					if id, ok := call.Args[1].(*ast.Ident); ok {
+						query = id.Name
+					}
+				case "Scan":
+					scans = len(call.Args)
+				}
+				return true
+			})
+			if query == "" || scans < 0 {
+				continue
+			}
+			assert.Equal(t, resultColumns(queries[query]), scans, "%s: columns vs Scan targets", fn.Name.Name)
+			checked++
+		}
+	}
+	assert.NotZero(t, checked)
+}
+
+func TestResultColumns(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want int
+	}{
+		{"SELECT id, name FROM boards WHERE id = $1", 2},
+		{"SELECT COALESCE((SELECT revision FROM r WHERE id = $1), 0)::BIGINT AS revision", 1},
+		{"UPDATE cards SET title = $2 WHERE id = $1\n    RETURNING id, title, version", 3},
+		{"WITH rev AS (\n    INSERT INTO r (a, b) VALUES ($1, 1)\n    RETURNING revision\n)\nINSERT INTO e (a, b)\nSELECT $1, revision\nFROM rev\n    RETURNING revision", 1},
+		{"SELECT id, -- the key\n       name\nFROM boards", 2},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, resultColumns(tc.sql), tc.sql)
+	}
+}
@@ -7,12 +7,36 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveList = `-- name: ArchiveList :one
+UPDATE lists
+SET archived_at = NOW(), version = version + 1
+WHERE id = $1 AND archived_at IS NULL
+    RETURNING id, board_id, title, position, created_at, archived_at, version
+`
+
+func (q *Queries) ArchiveList(ctx context.Context, id int32) (List, error) {
+	row := q.db.QueryRow(ctx, archiveList, id)
+	var i List
+	err := row.Scan(
+		&i.ID,
+		&i.BoardID,
+		&i.Title,
+		&i.Position,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
 const createList = `-- name: CreateList :one
 INSERT INTO lists (board_id, title, position)
 VALUES ($1, $2, $3)
-    RETURNING id, board_id, title, position, created_at
+    RETURNING id, board_id, title, position, created_at, archived_at, version
 `
 
 type CreateListParams struct {
@@ -30,13 +54,15 @@ func (q *Queries) CreateList(ctx context.Context, arg CreateListParams) (List, e
 		&i.Title,
 		&i.Position,
 		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const decListPosAfter = `-- name: DecListPosAfter :exec
 UPDATE lists SET position = position - 1
-WHERE board_id = $1 AND position >  $2
+WHERE board_id = $1 AND position >  $2 AND archived_at IS NULL
 `
 
 type DecListPosAfterParams struct {
@@ -49,20 +75,44 @@ func (q *Queries) DecListPosAfter(ctx context.Context, arg DecListPosAfterParams
 	return err
 }
 
-const deleteList = `-- name: DeleteList :exec
-DELETE FROM lists
+const getArchivedListByID = `-- name: GetArchivedListByID :one
+SELECT id, board_id, title, position, created_at, archived_at, version
+FROM lists
+WHERE id = $1 AND archived_at IS NOT NULL
+`
+
+func (q *Queries) GetArchivedListByID(ctx context.Context, id int32) (List, error) {
+	row := q.db.QueryRow(ctx, getArchivedListByID, id)
+	var i List
+	err := row.Scan(
+		&i.ID,
+		&i.BoardID,
+		&i.Title,
+		&i.Position,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getListBoardID = `-- name: GetListBoardID :one
+SELECT board_id
+FROM lists
 WHERE id = $1
 `
 
-func (q *Queries) DeleteList(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteList, id)
-	return err
+func (q *Queries) GetListBoardID(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRow(ctx, getListBoardID, id)
+	var boardID int32
+	err := row.Scan(&boardID)
+	return boardID, err
 }
 
 const getListByID = `-- name: GetListByID :one
-SELECT id, board_id, title, position, created_at
+SELECT id, board_id, title, position, created_at, archived_at, version
 FROM lists
-WHERE id = $1
+WHERE id = $1 AND archived_at IS NULL
 `
 
 func (q *Queries) GetListByID(ctx context.Context, id int32) (List, error) {
@@ -74,13 +124,15 @@ func (q *Queries) GetListByID(ctx context.Context, id int32) (List, error) {
 		&i.Title,
 		&i.Position,
 		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const incListPosAfter = `-- name: IncListPosAfter :exec
 UPDATE lists SET position = position + 1
-WHERE board_id = $1 AND position >= $2
+WHERE board_id = $1 AND position >= $2 AND archived_at IS NULL
 `
 
 type IncListPosAfterParams struct {
@@ -93,10 +145,45 @@ func (q *Queries) IncListPosAfter(ctx context.Context, arg IncListPosAfterParams
 	return err
 }
 
+const listArchivedListsByBoard = `-- name: ListArchivedListsByBoard :many
+SELECT id, board_id, title, position, created_at, archived_at, version
+FROM lists
+WHERE board_id = $1 AND archived_at IS NOT NULL
+ORDER BY archived_at DESC
+`
+
+func (q *Queries) ListArchivedListsByBoard(ctx context.Context, boardID int32) ([]List, error) {
+	rows, err := q.db.Query(ctx, listArchivedListsByBoard, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []List
+	for rows.Next() {
+		var i List
+		if err := rows.Scan(
+			&i.ID,
+			&i.BoardID,
+			&i.Title,
+			&i.Position,
+			&i.CreatedAt,
+			&i.ArchivedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listListsByBoard = `-- name: ListListsByBoard :many
-SELECT id, board_id, title, position, created_at
+SELECT id, board_id, title, position, created_at, archived_at, version
 FROM lists
-WHERE board_id = $1
+WHERE board_id = $1 AND archived_at IS NULL
 ORDER BY position
 `
 
@@ -115,6 +202,8 @@ func (q *Queries) ListListsByBoard(ctx context.Context, boardID int32) ([]List,
 			&i.Title,
 			&i.Position,
 			&i.CreatedAt,
+			&i.ArchivedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -126,21 +215,106 @@ func (q *Queries) ListListsByBoard(ctx context.Context, boardID int32) ([]List,
 	return items, nil
 }
 
-const updateList = `-- name: UpdateList :one
+const purgeArchivedLists = `-- name: PurgeArchivedLists :many
+WITH purged AS (
+    DELETE FROM lists
+    WHERE archived_at < $1
+    RETURNING id
+)
+SELECT p.id, a.storage_key
+FROM purged p
+         LEFT JOIN cards c ON c.list_id = p.id
+         LEFT JOIN card_attachments a ON a.card_id = c.id
+`
+
+type PurgeArchivedListsRow struct {
+	ID         int32
+	StorageKey pgtype.Text
+}
+
+func (q *Queries) PurgeArchivedLists(ctx context.Context, archivedAt pgtype.Timestamp) ([]PurgeArchivedListsRow, error) {
+	rows, err := q.db.Query(ctx, purgeArchivedLists, archivedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PurgeArchivedListsRow
+	for rows.Next() {
+		var i PurgeArchivedListsRow
+		if err := rows.Scan(&i.ID, &i.StorageKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreList = `-- name: RestoreList :one
 UPDATE lists
-SET title = $2, position = $3
+SET archived_at = NULL, position = $2, version = version + 1
+WHERE id = $1 AND archived_at IS NOT NULL
+    RETURNING id, board_id, title, position, created_at, archived_at, version
+`
+
+type RestoreListParams struct {
+	ID       int32
+	Position int32
+}
+
+func (q *Queries) RestoreList(ctx context.Context, arg RestoreListParams) (List, error) {
+	row := q.db.QueryRow(ctx, restoreList, arg.ID, arg.Position)
+	var i List
+	err := row.Scan(
+		&i.ID,
+		&i.BoardID,
+		&i.Title,
+		&i.Position,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const setListPosition = `-- name: SetListPosition :exec
+UPDATE lists SET position = $2
 WHERE id = $1
-    RETURNING id, board_id, title, position, created_at
+`
+
+type SetListPositionParams struct {
+	ID       int32
+	Position int32
+}
+
+func (q *Queries) SetListPosition(ctx context.Context, arg SetListPositionParams) error {
+	_, err := q.db.Exec(ctx, setListPosition, arg.ID, arg.Position)
+	return err
+}
+
+const updateList = `-- name: UpdateList :one
+UPDATE lists
+SET title = $2, position = $3, version = version + 1
+WHERE id = $1 AND version = $4 AND archived_at IS NULL
+    RETURNING id, board_id, title, position, created_at, archived_at, version
 `
 
 type UpdateListParams struct {
 	ID       int32
 	Title    string
 	Position int32
+	Version  int32
 }
 
 func (q *Queries) UpdateList(ctx context.Context, arg UpdateListParams) (List, error) {
-	row := q.db.QueryRow(ctx, updateList, arg.ID, arg.Title, arg.Position)
+	row := q.db.QueryRow(ctx, updateList,
+		arg.ID,
+		arg.Title,
+		arg.Position,
+		arg.Version,
+	)
 	var i List
 	err := row.Scan(
 		&i.ID,
@@ -148,6 +322,8 @@ func (q *Queries) UpdateList(ctx context.Context, arg UpdateListParams) (List, e
 		&i.Title,
 		&i.Position,
 		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.Version,
 	)
 	return i, err
 }
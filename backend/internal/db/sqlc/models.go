@@ -8,10 +8,32 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Activity struct {
+	ID         int32
+	BoardID    int32
+	ActorID    pgtype.Int4
+	Action     string
+	EntityType string
+	EntityID   int32
+	Before     []byte
+	After      []byte
+	CreatedAt  pgtype.Timestamp
+}
+
 type Board struct {
-	ID        int32
-	Name      string
-	OwnerID   int32
+	ID         int32
+	Name       string
+	OwnerID    int32
+	CreatedAt  pgtype.Timestamp
+	ArchivedAt pgtype.Timestamp
+	Version    int32
+}
+
+type BoardEvent struct {
+	BoardID   int32
+	Revision  int64
+	Event     string
+	Payload   []byte
 	CreatedAt pgtype.Timestamp
 }
 
@@ -21,6 +43,11 @@ type BoardMember struct {
 	Role    string
 }
 
+type BoardRevision struct {
+	BoardID  int32
+	Revision int64
+}
+
 type Card struct {
 	ID          int32
 	ListID      int32
@@ -28,20 +55,177 @@ type Card struct {
 	Description pgtype.Text
 	Position    int32
 	CreatedAt   pgtype.Timestamp
+	StartAt     pgtype.Timestamp
+	DueAt       pgtype.Timestamp
+	Completed   bool
+	ArchivedAt  pgtype.Timestamp
+	Version     int32
 }
 
-type List struct {
+type CardAssignee struct {
+	CardID     int32
+	UserID     int32
+	AssignedAt pgtype.Timestamp
+}
+
+type CardAttachment struct {
+	ID          int32
+	CardID      int32
+	UploaderID  int32
+	Filename    string
+	ContentType string
+	Size        int64
+	StorageKey  string
+	CreatedAt   pgtype.Timestamp
+}
+
+type CardChecklist struct {
 	ID        int32
-	BoardID   int32
+	CardID    int32
 	Title     string
 	Position  int32
 	CreatedAt pgtype.Timestamp
 }
 
+type CardComment struct {
+	ID        int32
+	CardID    int32
+	AuthorID  int32
+	ParentID  pgtype.Int4
+	Body      string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+	DeletedAt pgtype.Timestamp
+}
+
+type CardCommentRevision struct {
+	ID        int32
+	CommentID int32
+	EditorID  int32
+	Action    string
+	Body      string
+	CreatedAt pgtype.Timestamp
+}
+
+type CardDescriptionOp struct {
+	CardID    int32
+	Revision  int32
+	UserID    pgtype.Int4
+	Op        []byte
+	CreatedAt pgtype.Timestamp
+}
+
+type CardDescriptionRevision struct {
+	CardID   int32
+	Revision int32
+}
+
+type CardLabel struct {
+	CardID  int32
+	LabelID int32
+}
+
+type CardLock struct {
+	CardID    int32
+	BoardID   int32
+	UserID    int32
+	ExpiresAt pgtype.Timestamp
+}
+
+type ChecklistItem struct {
+	ID          int32
+	ChecklistID int32
+	Title       string
+	Done        bool
+	Position    int32
+	CreatedAt   pgtype.Timestamp
+}
+
+type EmailVerification struct {
+	TokenHash string
+	UserID    int32
+	CreatedAt pgtype.Timestamp
+	ExpiresAt pgtype.Timestamp
+}
+
+type Label struct {
+	ID        int32
+	BoardID   int32
+	Name      string
+	Color     string
+	CreatedAt pgtype.Timestamp
+}
+
+type List struct {
+	ID         int32
+	BoardID    int32
+	Title      string
+	Position   int32
+	CreatedAt  pgtype.Timestamp
+	ArchivedAt pgtype.Timestamp
+	Version    int32
+}
+
+type OidcLogin struct {
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    pgtype.Timestamp
+}
+
+type PasswordReset struct {
+	TokenHash string
+	UserID    int32
+	CreatedAt pgtype.Timestamp
+	ExpiresAt pgtype.Timestamp
+}
+
+type PersonalAccessToken struct {
+	ID          int32
+	UserID      int32
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scopes      []string
+	BoardIds    []int32
+	CreatedAt   pgtype.Timestamp
+	LastUsedAt  pgtype.Timestamp
+	ExpiresAt   pgtype.Timestamp
+}
+
+type Session struct {
+	ID                int32
+	UserID            int32
+	RefreshTokenHash  string
+	PreviousTokenHash pgtype.Text
+	UserAgent         string
+	IpAddress         string
+	CreatedAt         pgtype.Timestamp
+	LastSeenAt        pgtype.Timestamp
+	ExpiresAt         pgtype.Timestamp
+}
+
 type User struct {
-	ID           int32
-	Name         string
-	Email        string
-	PasswordHash string
-	CreatedAt    pgtype.Timestamp
+	ID              int32
+	Name            string
+	Email           string
+	PasswordHash    string
+	CreatedAt       pgtype.Timestamp
+	EmailVerifiedAt pgtype.Timestamp
+}
+
+type UserIdentity struct {
+	ID        int32
+	UserID    int32
+	Issuer    string
+	Subject   string
+	Email     string
+	CreatedAt pgtype.Timestamp
+}
+
+type WsTicket struct {
+	TokenHash string
+	UserID    int32
+	BoardID   pgtype.Int4
+	ExpiresAt pgtype.Timestamp
 }
@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/internal/collab"
+	"backend/internal/logger"
+)
+
+// DescriptionSaver is a background job that writes card descriptions edited
+// through the collaborative editor back to the cards table
+type DescriptionSaver struct {
+	collab      *collab.Service
+	interval    time.Duration
+	stopChan    chan struct{}
+	wg          sync.WaitGroup
+	isRunning   bool
+	runningLock sync.Mutex
+}
+
+// NewDescriptionSaver creates a new description saver job
+func NewDescriptionSaver(svc *collab.Service, interval time.Duration) *DescriptionSaver {
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return &DescriptionSaver{
+		collab:   svc,
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the background job
+func (s *DescriptionSaver) Start() {
+	s.runningLock.Lock()
+	defer s.runningLock.Unlock()
+
+	if s.isRunning {
+		logger.Warn("Description saver is already running")
+		return
+	}
+
+	s.isRunning = true
+	s.wg.Add(1)
+	go s.run()
+	logger.Info("Description saver started", "interval", s.interval)
+}
+
+// Stop halts the background job after a final save
+func (s *DescriptionSaver) Stop() {
+	s.runningLock.Lock()
+	defer s.runningLock.Unlock()
+
+	if !s.isRunning {
+		logger.Warn("Description saver is not running")
+		return
+	}
+
+	close(s.stopChan)
+	s.wg.Wait()
+	s.isRunning = false
+	logger.Info("Description saver stopped")
+}
+
+// run is the main loop of the background job
+func (s *DescriptionSaver) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush()
+		case <-s.stopChan:
+			logger.Info("Description saver received stop signal")
+			s.flush()
+			return
+		}
+	}
+}
+
+func (s *DescriptionSaver) flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	s.collab.Flush(ctx)
+}
@@ -0,0 +1,175 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"backend/internal/attachments"
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+)
+
+// TrashPurger is a background job that permanently deletes boards, lists and
+// cards that have been in the trash for longer than the retention period
+type TrashPurger struct {
+	queries     *db.Queries
+	storage     attachments.Storage
+	retention   time.Duration
+	interval    time.Duration
+	stopChan    chan struct{}
+	wg          sync.WaitGroup
+	isRunning   bool
+	runningLock sync.Mutex
+}
+
+// NewTrashPurger creates a new trash purger job
+func NewTrashPurger(queries *db.Queries, storage attachments.Storage, retention, interval time.Duration) *TrashPurger {
+	if interval < time.Minute {
+		interval = time.Minute
+	}
+	return &TrashPurger{
+		queries:   queries,
+		storage:   storage,
+		retention: retention,
+		interval:  interval,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// Start begins the background job
+func (p *TrashPurger) Start() {
+	p.runningLock.Lock()
+	defer p.runningLock.Unlock()
+
+	if p.isRunning {
+		logger.Warn("Trash purger is already running")
+		return
+	}
+
+	p.isRunning = true
+	p.wg.Add(1)
+	go p.run()
+	logger.Info("Trash purger started", "interval", p.interval, "retention", p.retention)
+}
+
+// Stop halts the background job
+func (p *TrashPurger) Stop() {
+	p.runningLock.Lock()
+	defer p.runningLock.Unlock()
+
+	if !p.isRunning {
+		logger.Warn("Trash purger is not running")
+		return
+	}
+
+	close(p.stopChan)
+	p.wg.Wait()
+	p.isRunning = false
+	logger.Info("Trash purger stopped")
+}
+
+// run is the main loop of the background job
+func (p *TrashPurger) run() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.purge()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.purge()
+		case <-p.stopChan:
+			logger.Info("Trash purger received stop signal")
+			return
+		}
+	}
+}
+
+// purge deletes everything archived before the retention cutoff. Cards go
+// first, then lists, then boards, so each step only removes what is itself
+// expired; the cascades take care of the rest. Each step reports the
+// attachment files of exactly the rows it deleted, so a card restored in
+// the meantime keeps its files.
+func (p *TrashPurger) purge() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cutoff := pgtype.Timestamp{Time: time.Now().UTC().Add(-p.retention), Valid: true}
+	var cards, lists, boards purged
+	defer func() {
+		if cards.count()+lists.count()+boards.count() > 0 {
+			logger.Info("Purged trash",
+				"boards", boards.count(),
+				"lists", lists.count(),
+				"cards", cards.count(),
+				"attachments", len(cards.keys)+len(lists.keys)+len(boards.keys),
+			)
+		}
+	}()
+
+	cardRows, err := p.queries.PurgeArchivedCards(ctx, cutoff)
+	if err != nil {
+		logger.Error("Error purging archived cards", "error", err)
+		return
+	}
+	for _, r := range cardRows {
+		cards.add(r.ID, r.StorageKey)
+	}
+	p.removeFiles(ctx, cards.keys)
+
+	listRows, err := p.queries.PurgeArchivedLists(ctx, cutoff)
+	if err != nil {
+		logger.Error("Error purging archived lists", "error", err)
+		return
+	}
+	for _, r := range listRows {
+		lists.add(r.ID, r.StorageKey)
+	}
+	p.removeFiles(ctx, lists.keys)
+
+	boardRows, err := p.queries.PurgeArchivedBoards(ctx, cutoff)
+	if err != nil {
+		logger.Error("Error purging archived boards", "error", err)
+		return
+	}
+	for _, r := range boardRows {
+		boards.add(r.ID, r.StorageKey)
+	}
+	p.removeFiles(ctx, boards.keys)
+}
+
+// removeFiles deletes the content of purged attachments from storage.
+func (p *TrashPurger) removeFiles(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := p.storage.Delete(ctx, key); err != nil {
+			logger.Error("Error removing purged attachment content", "key", key, "error", err)
+		}
+	}
+}
+
+// purged collects what one purge step deleted: a row per attachment of
+// each deleted entity, or a single row without a key if it had none.
+type purged struct {
+	ids  map[int32]bool
+	keys []string
+}
+
+func (p *purged) add(id int32, key pgtype.Text) {
+	if p.ids == nil {
+		p.ids = make(map[int32]bool)
+	}
+	p.ids[id] = true
+	if key.Valid {
+		p.keys = append(p.keys, key.String)
+	}
+}
+
+func (p *purged) count() int {
+	return len(p.ids)
+}
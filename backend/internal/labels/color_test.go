@@ -0,0 +1,32 @@
+// internal/labels/color_test.go
+package labels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLabel(t *testing.T) {
+	tests := []struct {
+		name      string
+		inName    string
+		inColor   string
+		wantName  string
+		wantColor string
+		wantErr   error
+	}{
+		{"Valid", "bug", "#eb5a46", "bug", "#eb5a46", nil},
+		{"TrimsAndLowercases", "  feature ", "#61BD4F", "feature", "#61bd4f", nil},
+		{"EmptyName", "   ", "#61bd4f", "", "", ErrEmptyName},
+		{"ShortHex", "bug", "#fff", "", "", ErrInvalidColor},
+		{"NamedColor", "bug", "red", "", "", ErrInvalidColor},
+	}
+
+	for _, tc := range tests {
+		name, color, err := normalizeLabel(tc.inName, tc.inColor)
+		assert.Equal(t, tc.wantErr, err, tc.name)
+		assert.Equal(t, tc.wantName, name, tc.name)
+		assert.Equal(t, tc.wantColor, color, tc.name)
+	}
+}
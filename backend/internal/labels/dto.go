@@ -0,0 +1,35 @@
+package labels
+
+import "time"
+
+// CreateLabelRequest represents the request body for creating a label
+type CreateLabelRequest struct {
+	Name  string `json:"name" binding:"required" example:"bug"`
+	Color string `json:"color" binding:"required" example:"#eb5a46"`
+}
+
+// UpdateLabelRequest represents the request body for updating a label
+type UpdateLabelRequest struct {
+	Name  string `json:"name" binding:"required" example:"blocked"`
+	Color string `json:"color" binding:"required" example:"#c377e0"`
+}
+
+// LabelResponse documents a label in API responses. Handlers return
+// db.Label, which has no JSON tags, so fields keep their Go names on the wire.
+type LabelResponse struct {
+	ID        int32     `json:"ID" example:"1"`
+	BoardID   int32     `json:"BoardID" example:"1"`
+	Name      string    `json:"Name" example:"bug"`
+	Color     string    `json:"Color" example:"#eb5a46"`
+	CreatedAt time.Time `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error" example:"Label not found"`
+}
+
+// MessageResponse represents a simple message response
+type MessageResponse struct {
+	Message string `json:"message" example:"label deleted"`
+}
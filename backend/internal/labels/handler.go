@@ -0,0 +1,153 @@
+package labels
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup, svc *Service) {
+	g := r.Group("/boards/:boardId/labels")
+	g.POST("", createLabelHandler(svc))
+	g.GET("", listLabelsHandler(svc))
+	g.PUT("/:id", updateLabelHandler(svc))
+	g.DELETE("/:id", deleteLabelHandler(svc))
+}
+
+// errorStatus maps service errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotMember):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidColor), errors.Is(err, ErrEmptyName):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// createLabelHandler creates a new label on a board
+//
+//	@Summary		Create a new label
+//	@Description	Create a colored label in the board palette
+//	@Tags			Labels
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId	path		int					true	"Board ID"
+//	@Param			request	body		CreateLabelRequest	true	"Label details"
+//	@Success		201		{object}	LabelResponse		"Label created successfully"
+//	@Failure		400		{object}	ErrorResponse		"Invalid request"
+//	@Failure		401		{object}	ErrorResponse		"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse		"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse		"Internal server error"
+//	@Router			/api/boards/{boardId}/labels [post]
+func createLabelHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		boardID, _ := strconv.Atoi(c.Param("boardId"))
+		var req CreateLabelRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		userID := int32(c.GetInt("userID"))
+		lbl, err := svc.Create(c.Request.Context(), userID, int32(boardID), req.Name, req.Color)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, lbl)
+	}
+}
+
+// listLabelsHandler gets the label palette of a board
+//
+//	@Summary		Get board labels
+//	@Description	Get all labels defined on a specific board
+//	@Tags			Labels
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId	path		int				true	"Board ID"
+//	@Success		200		{array}		LabelResponse	"List of labels"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/boards/{boardId}/labels [get]
+func listLabelsHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		boardID, _ := strconv.Atoi(c.Param("boardId"))
+		userID := int32(c.GetInt("userID"))
+
+		lbls, err := svc.ListByBoard(c.Request.Context(), userID, int32(boardID))
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, lbls)
+	}
+}
+
+// updateLabelHandler updates a label
+//
+//	@Summary		Update label
+//	@Description	Rename a label or change its color
+//	@Tags			Labels
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId	path		int					true	"Board ID"
+//	@Param			id		path		int					true	"Label ID"
+//	@Param			request	body		UpdateLabelRequest	true	"Label update details"
+//	@Success		200		{object}	LabelResponse		"Label updated successfully"
+//	@Failure		400		{object}	ErrorResponse		"Invalid request"
+//	@Failure		401		{object}	ErrorResponse		"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse		"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse		"Internal server error"
+//	@Router			/api/boards/{boardId}/labels/{id} [put]
+func updateLabelHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		var req UpdateLabelRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		lbl, err := svc.Update(c.Request.Context(), userID, int32(id), req.Name, req.Color)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, lbl)
+	}
+}
+
+// deleteLabelHandler deletes a label
+//
+//	@Summary		Delete label
+//	@Description	Delete a label from the board and detach it from all cards
+//	@Tags			Labels
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId	path		int				true	"Board ID"
+//	@Param			id		path		int				true	"Label ID"
+//	@Success		200		{object}	MessageResponse	"Label deleted successfully"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Forbidden - not a board member"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/boards/{boardId}/labels/{id} [delete]
+func deleteLabelHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		if err := svc.Delete(c.Request.Context(), userID, int32(id)); err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "label deleted"})
+	}
+}
@@ -0,0 +1,25 @@
+package labels
+
+import (
+	"context"
+
+	db "backend/internal/db/sqlc"
+)
+
+type Repository struct{ q *db.Queries }
+
+func NewRepository(q *db.Queries) *Repository { return &Repository{q: q} }
+
+func (r *Repository) Create(ctx context.Context, arg db.CreateLabelParams) (db.Label, error) {
+	return r.q.CreateLabel(ctx, arg)
+}
+func (r *Repository) Get(ctx context.Context, id int32) (db.Label, error) {
+	return r.q.GetLabelByID(ctx, id)
+}
+func (r *Repository) Update(ctx context.Context, arg db.UpdateLabelParams) (db.Label, error) {
+	return r.q.UpdateLabel(ctx, arg)
+}
+func (r *Repository) Delete(ctx context.Context, id int32) error { return r.q.DeleteLabel(ctx, id) }
+func (r *Repository) ListByBoard(ctx context.Context, boardID int32) ([]db.Label, error) {
+	return r.q.ListLabelsByBoard(ctx, boardID)
+}
@@ -0,0 +1,115 @@
+package labels
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/websocket"
+)
+
+type Service struct {
+	repo *Repository
+	q    *db.Queries // for cross‑repo checks
+	hub  *websocket.Hub
+}
+
+func NewService(repo *Repository, q *db.Queries, hub *websocket.Hub) *Service {
+	return &Service{repo: repo, q: q, hub: hub}
+}
+
+var (
+	ErrNotMember    = errors.New("not a member")
+	ErrInvalidColor = errors.New("invalid color, must be a hex value like #61bd4f")
+	ErrEmptyName    = errors.New("label name is required")
+)
+
+var colorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// normalizeLabel trims the name and lower-cases the color so the palette
+// stays consistent regardless of how clients spell it.
+func normalizeLabel(name, color string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", "", ErrEmptyName
+	}
+	if !colorRe.MatchString(color) {
+		return "", "", ErrInvalidColor
+	}
+	return name, strings.ToLower(color), nil
+}
+
+func (s *Service) Create(ctx context.Context, userID, boardID int32, name, color string) (db.Label, error) {
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{BoardID: boardID, UserID: userID}); err != nil {
+		return db.Label{}, ErrNotMember
+	}
+	name, color, err := normalizeLabel(name, color)
+	if err != nil {
+		return db.Label{}, err
+	}
+	lbl, err := s.repo.Create(ctx, db.CreateLabelParams{BoardID: boardID, Name: name, Color: color})
+	if err != nil {
+		logger.WithContext(ctx).Error("Failed to create label",
+			"user_id", userID,
+			"board_id", boardID,
+			"error", err,
+		)
+		return db.Label{}, err
+	}
+	s.hub.Broadcast(boardID, websocket.EventMessage{Event: "label_created", Data: lbl})
+	return lbl, nil
+}
+
+func (s *Service) ListByBoard(ctx context.Context, userID, boardID int32) ([]db.Label, error) {
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: boardID, UserID: userID,
+	}); err != nil {
+		return nil, ErrNotMember
+	}
+	return s.repo.ListByBoard(ctx, boardID)
+}
+
+func (s *Service) Update(ctx context.Context, userID, labelID int32, name, color string) (db.Label, error) {
+	lbl, err := s.repo.Get(ctx, labelID)
+	if err != nil {
+		return db.Label{}, err
+	}
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: lbl.BoardID, UserID: userID,
+	}); err != nil {
+		return db.Label{}, ErrNotMember
+	}
+	name, color, err = normalizeLabel(name, color)
+	if err != nil {
+		return db.Label{}, err
+	}
+	updated, err := s.repo.Update(ctx, db.UpdateLabelParams{ID: labelID, Name: name, Color: color})
+	if err == nil {
+		s.hub.Broadcast(lbl.BoardID, websocket.EventMessage{Event: "label_updated", Data: updated})
+	}
+	return updated, err
+}
+
+// Delete removes the label from the board; card_labels rows go with it
+// via ON DELETE CASCADE, so clients drop it from every card on label_deleted.
+func (s *Service) Delete(ctx context.Context, userID, labelID int32) error {
+	lbl, err := s.repo.Get(ctx, labelID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: lbl.BoardID, UserID: userID,
+	}); err != nil {
+		return ErrNotMember
+	}
+	if err := s.repo.Delete(ctx, labelID); err != nil {
+		return err
+	}
+	s.hub.Broadcast(lbl.BoardID, websocket.EventMessage{
+		Event: "label_deleted", Data: map[string]int32{"id": labelID},
+	})
+	return nil
+}
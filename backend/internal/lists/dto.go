@@ -12,11 +12,14 @@ type CreateListRequest struct {
 type UpdateListRequest struct {
 	Title    string `json:"title" example:"In Progress"`
 	Position *int32 `json:"position" example:"2"`
+	// Version the update is based on; may be sent as If-Match instead.
+	Version *int32 `json:"version" example:"3"`
 }
 
 // MoveListRequest represents the request body for moving a list
 type MoveListRequest struct {
 	Position float64 `json:"position" binding:"required" example:"1.5"`
+	Version  *int32  `json:"version" example:"3"`
 }
 
 // ListResponse represents a list in API responses
@@ -26,6 +29,7 @@ type ListResponse struct {
 	Title     string    `json:"title" example:"To Do"`
 	Position  int32     `json:"position" example:"1"`
 	CreatedAt time.Time `json:"createdAt" example:"2023-01-01T00:00:00Z"`
+	Version   int32     `json:"version" example:"3"`
 }
 
 // NormalizePositionsResponse represents the response for position normalization
@@ -2,7 +2,9 @@ package lists
 
 import (
 	db "backend/internal/db/sqlc"
+	"backend/internal/versioning"
 	"bytes"
+	"errors"
 	"io"
 	"log"
 	"math"
@@ -19,6 +21,7 @@ func RegisterRoutes(r *gin.RouterGroup, svc *Service) {
 	g.PUT("/:id", updateListHandler(svc))
 	g.PUT("/:id/move", moveListHandler(svc))
 	g.DELETE("/:id", deleteListHandler(svc))
+	g.POST("/:id/restore", restoreListHandler(svc))
 	g.POST("/normalize", normalizePositionsHandler(svc))
 }
 
@@ -87,18 +90,21 @@ func listHandler(svc *Service) gin.HandlerFunc {
 // updateListHandler updates a list
 //
 //	@Summary		Update list
-//	@Description	Update list title and/or position
+//	@Description	Update list title and/or position. The expected version is taken from the body or the If-Match header
 //	@Tags			Lists
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			boardId	path		int					true	"Board ID"
-//	@Param			id		path		int					true	"List ID"
-//	@Param			request	body		UpdateListRequest	true	"List update details"
-//	@Success		200		{object}	ListResponse		"List updated successfully"
-//	@Failure		400		{object}	ErrorResponse		"Invalid request"
-//	@Failure		401		{object}	ErrorResponse		"Unauthorized"
-//	@Failure		500		{object}	ErrorResponse		"Internal server error"
+//	@Param			boardId		path		int							true	"Board ID"
+//	@Param			id			path		int							true	"List ID"
+//	@Param			If-Match	header		string						false	"Expected list version, if not in the body"
+//	@Param			request		body		UpdateListRequest			true	"List update details"
+//	@Success		200			{object}	ListResponse				"List updated successfully"
+//	@Failure		400			{object}	ErrorResponse				"Invalid request"
+//	@Failure		401			{object}	ErrorResponse				"Unauthorized"
+//	@Failure		409			{object}	versioning.ConflictResponse	"List was changed by someone else"
+//	@Failure		428			{object}	ErrorResponse				"Version missing"
+//	@Failure		500			{object}	ErrorResponse				"Internal server error"
 //	@Router			/api/boards/{boardId}/lists/{id} [put]
 func updateListHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -108,11 +114,17 @@ func updateListHandler(svc *Service) gin.HandlerFunc {
 		var req struct {
 			Title    string `json:"title"`
 			Position *int32 `json:"position"`
+			Version  *int32 `json:"version"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		version, err := versioning.Expected(c, req.Version)
+		if err != nil {
+			c.JSON(versioning.Status(err), gin.H{"error": err.Error()})
+			return
+		}
 
 		// Get the current list to preserve its position if not explicitly provided
 		currentList, err := svc.GetListByID(c.Request.Context(), int32(id))
@@ -126,6 +138,7 @@ func updateListHandler(svc *Service) gin.HandlerFunc {
 			ID:       int32(id),
 			Title:    req.Title,
 			Position: currentList.Position, // Preserve the current position
+			Version:  version,
 		}
 
 		// Only update position if explicitly provided
@@ -134,10 +147,15 @@ func updateListHandler(svc *Service) gin.HandlerFunc {
 		}
 
 		lst, err := svc.Update(c.Request.Context(), userID, p)
+		if errors.Is(err, versioning.ErrConflict) {
+			c.JSON(http.StatusConflict, versioning.ConflictResponse{Error: err.Error(), Current: lst})
+			return
+		}
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		versioning.SetETag(c, lst.Version)
 		c.JSON(http.StatusOK, lst)
 	}
 }
@@ -145,18 +163,21 @@ func updateListHandler(svc *Service) gin.HandlerFunc {
 // moveListHandler moves a list to a new position
 //
 //	@Summary		Move list
-//	@Description	Move a list to a new position within the board
+//	@Description	Move a list to a new position within the board. The expected version is taken from the body or the If-Match header
 //	@Tags			Lists
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			boardId	path		int				true	"Board ID"
-//	@Param			id		path		int				true	"List ID"
-//	@Param			request	body		MoveListRequest	true	"New position"
-//	@Success		200		{object}	ListResponse	"List moved successfully"
-//	@Failure		400		{object}	ErrorResponse	"Invalid request"
-//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
-//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Param			boardId		path		int							true	"Board ID"
+//	@Param			id			path		int							true	"List ID"
+//	@Param			If-Match	header		string						false	"Expected list version, if not in the body"
+//	@Param			request		body		MoveListRequest				true	"New position"
+//	@Success		200			{object}	ListResponse				"List moved successfully"
+//	@Failure		400			{object}	ErrorResponse				"Invalid request"
+//	@Failure		401			{object}	ErrorResponse				"Unauthorized"
+//	@Failure		409			{object}	versioning.ConflictResponse	"List was changed by someone else"
+//	@Failure		428			{object}	ErrorResponse				"Version missing"
+//	@Failure		500			{object}	ErrorResponse				"Internal server error"
 //	@Router			/api/boards/{boardId}/lists/{id}/move [put]
 func moveListHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -174,6 +195,7 @@ func moveListHandler(svc *Service) gin.HandlerFunc {
 
 		var req struct {
 			Position float64 `json:"position"` // Changed to float64 to handle decimal positions
+			Version  *int32  `json:"version"`
 		}
 
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -184,12 +206,22 @@ func moveListHandler(svc *Service) gin.HandlerFunc {
 
 		log.Printf("Move list parsed position: %f", req.Position)
 
+		version, err := versioning.Expected(c, req.Version)
+		if err != nil {
+			c.JSON(versioning.Status(err), gin.H{"error": err.Error()})
+			return
+		}
+
 		// Convert float64 to int32 for the database
 		position := int32(math.Round(req.Position))
 		log.Printf("Move list rounded position: %d", position)
 
 		userID := int32(c.GetInt("userID"))
-		lst, err := svc.Move(c.Request.Context(), userID, int32(id), position)
+		lst, err := svc.Move(c.Request.Context(), userID, int32(id), position, version)
+		if errors.Is(err, versioning.ErrConflict) {
+			c.JSON(http.StatusConflict, versioning.ConflictResponse{Error: err.Error(), Current: lst})
+			return
+		}
 		if err != nil {
 			log.Printf("Move list service error: %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -197,6 +229,7 @@ func moveListHandler(svc *Service) gin.HandlerFunc {
 		}
 
 		log.Printf("Move list success: list=%+v", lst)
+		versioning.SetETag(c, lst.Version)
 		c.JSON(http.StatusOK, lst)
 	}
 }
@@ -204,7 +237,7 @@ func moveListHandler(svc *Service) gin.HandlerFunc {
 // deleteListHandler deletes a list
 //
 //	@Summary		Delete list
-//	@Description	Delete a list and all its cards from the board
+//	@Description	Move a list and its cards to the board's trash. It can be restored until the trash is purged
 //	@Tags			Lists
 //	@Produce		json
 //	@Security		BearerAuth
@@ -227,6 +260,38 @@ func deleteListHandler(svc *Service) gin.HandlerFunc {
 	}
 }
 
+// restoreListHandler restores a list from the trash
+//
+//	@Summary		Restore list
+//	@Description	Put an archived list back on the board, at its old position if possible
+//	@Tags			Lists
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId	path		int				true	"Board ID"
+//	@Param			id		path		int				true	"List ID"
+//	@Success		200		{object}	ListResponse	"List restored successfully"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404		{object}	ErrorResponse	"List is not in the trash"
+//	@Failure		500		{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/boards/{boardId}/lists/{id}/restore [post]
+func restoreListHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+
+		lst, err := svc.Restore(c.Request.Context(), userID, int32(id))
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrNotArchived) {
+				status = http.StatusNotFound
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, lst)
+	}
+}
+
 // normalizePositionsHandler normalizes list positions
 //
 //	@Summary		Normalize list positions
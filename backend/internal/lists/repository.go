@@ -16,7 +16,18 @@ func (r *Repository) Create(ctx context.Context, arg db.CreateListParams) (db.Li
 func (r *Repository) Update(ctx context.Context, arg db.UpdateListParams) (db.List, error) {
 	return r.q.UpdateList(ctx, arg)
 }
-func (r *Repository) Delete(ctx context.Context, id int32) error { return r.q.DeleteList(ctx, id) }
+func (r *Repository) SetPosition(ctx context.Context, id, position int32) error {
+	return r.q.SetListPosition(ctx, db.SetListPositionParams{ID: id, Position: position})
+}
+func (r *Repository) Archive(ctx context.Context, id int32) (db.List, error) {
+	return r.q.ArchiveList(ctx, id)
+}
+func (r *Repository) Restore(ctx context.Context, arg db.RestoreListParams) (db.List, error) {
+	return r.q.RestoreList(ctx, arg)
+}
+func (r *Repository) GetArchived(ctx context.Context, id int32) (db.List, error) {
+	return r.q.GetArchivedListByID(ctx, id)
+}
 func (r *Repository) ListByBoard(ctx context.Context, boardID int32) ([]db.List, error) {
 	return r.q.ListListsByBoard(ctx, boardID)
 }
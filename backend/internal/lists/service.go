@@ -6,19 +6,24 @@ import (
 	"log"
 	"sort"
 
+	"github.com/jackc/pgx/v5"
+
+	"backend/internal/activity"
 	db "backend/internal/db/sqlc"
 	"backend/internal/logger"
+	"backend/internal/versioning"
 	"backend/internal/websocket"
 )
 
 type Service struct {
-	repo *Repository
-	q    *db.Queries // for cross‑repo checks
-	hub  *websocket.Hub
+	repo     *Repository
+	q        *db.Queries // for cross‑repo checks
+	hub      *websocket.Hub
+	activity *activity.Recorder
 }
 
-func NewService(repo *Repository, q *db.Queries, hub *websocket.Hub) *Service {
-	return &Service{repo: repo, q: q, hub: hub}
+func NewService(repo *Repository, q *db.Queries, hub *websocket.Hub, rec *activity.Recorder) *Service {
+	return &Service{repo: repo, q: q, hub: hub, activity: rec}
 }
 
 func (s *Service) Create(ctx context.Context, userID, boardID int32, title string, position int32) (db.List, error) {
@@ -47,6 +52,10 @@ func (s *Service) Create(ctx context.Context, userID, boardID int32, title strin
 			"board_id", boardID,
 			"title", title,
 		)
+		s.activity.Record(ctx, activity.Entry{
+			BoardID: boardID, ActorID: userID, Action: activity.ListCreated,
+			EntityType: activity.EntityList, EntityID: lst.ID, After: lst,
+		})
 		s.hub.Broadcast(boardID, websocket.EventMessage{Event: "list_created", Data: lst})
 	} else {
 		logger.WithContext(ctx).Error("Failed to create list",
@@ -72,6 +81,8 @@ func (s *Service) GetListByID(ctx context.Context, listID int32) (db.List, error
 	return s.q.GetListByID(ctx, listID)
 }
 
+// Update overwrites the list if it is still at arg.Version. Otherwise it
+// returns the current list together with versioning.ErrConflict.
 func (s *Service) Update(ctx context.Context, userID int32, arg db.UpdateListParams) (db.List, error) {
 	lst, err := s.q.GetListByID(ctx, arg.ID)
 	if err != nil {
@@ -82,13 +93,24 @@ func (s *Service) Update(ctx context.Context, userID int32, arg db.UpdateListPar
 	}); err != nil {
 		return db.List{}, errors.New("not a member")
 	}
+	if lst.Version != arg.Version {
+		return lst, versioning.ErrConflict
+	}
 	updated, err := s.repo.Update(ctx, arg)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.conflict(ctx, arg.ID)
+	}
 	if err == nil {
+		s.activity.Record(ctx, activity.Entry{
+			BoardID: lst.BoardID, ActorID: userID, Action: activity.ListUpdated,
+			EntityType: activity.EntityList, EntityID: lst.ID, Before: lst, After: updated,
+		})
 		s.hub.Broadcast(lst.BoardID, websocket.EventMessage{Event: "list_updated", Data: updated})
 	}
 	return updated, err
 }
 
+// Delete moves a list, together with its cards, to the board's trash.
 func (s *Service) Delete(ctx context.Context, userID, listID int32) error {
 	lst, err := s.q.GetListByID(ctx, listID)
 	if err != nil {
@@ -99,15 +121,33 @@ func (s *Service) Delete(ctx context.Context, userID, listID int32) error {
 	}); err != nil {
 		return errors.New("not a member")
 	}
-	if err := s.repo.Delete(ctx, listID); err != nil {
+	archived, err := s.repo.Archive(ctx, listID)
+	if err != nil {
 		return err
 	}
+	if err := s.repo.ShiftLeft(ctx, lst.BoardID, lst.Position); err != nil {
+		return err
+	}
+	s.activity.Record(ctx, activity.Entry{
+		BoardID: lst.BoardID, ActorID: userID, Action: activity.ListArchived,
+		EntityType: activity.EntityList, EntityID: lst.ID, Before: lst, After: archived,
+	})
 	s.hub.Broadcast(lst.BoardID, websocket.EventMessage{
-		Event: "list_deleted", Data: map[string]int32{"id": listID},
+		Event: "list_deleted", Data: map[string]int32{"id": listID, "version": archived.Version},
 	})
 	return nil
 }
 
+// conflict returns the current state of a list that was changed by someone
+// else after the version check.
+func (s *Service) conflict(ctx context.Context, listID int32) (db.List, error) {
+	lst, err := s.q.GetListByID(ctx, listID)
+	if err != nil {
+		return db.List{}, err
+	}
+	return lst, versioning.ErrConflict
+}
+
 // NormalizeListPositions ensures all lists have sequential positions starting from 1
 // This is exported so it can be called by background jobs and API endpoints
 func (s *Service) NormalizeListPositions(ctx context.Context, boardID int32) error {
@@ -135,12 +175,8 @@ func (s *Service) NormalizeListPositions(ctx context.Context, boardID int32) err
 		newPosition := int32(i + 1)
 		if list.Position != newPosition {
 			log.Printf("Updating list %d position from %d to %d", list.ID, list.Position, newPosition)
-			_, err := s.repo.Update(ctx, db.UpdateListParams{
-				ID:       list.ID,
-				Title:    list.Title,
-				Position: newPosition,
-			})
-			if err != nil {
+			// Not a change to the list itself, so the version stays.
+			if err := s.repo.SetPosition(ctx, list.ID, newPosition); err != nil {
 				logger.WithContext(ctx).Error("Error updating list position during normalization",
 					"list_id", list.ID,
 					"new_position", newPosition,
@@ -155,7 +191,9 @@ func (s *Service) NormalizeListPositions(ctx context.Context, boardID int32) err
 	return nil
 }
 
-func (s *Service) Move(ctx context.Context, userID, listID, newPos int32) (db.List, error) {
+// Move puts the list at newPos if it is still at version. Otherwise it
+// returns the current list together with versioning.ErrConflict.
+func (s *Service) Move(ctx context.Context, userID, listID, newPos, version int32) (db.List, error) {
 	// Log the input parameters
 	logger.WithContext(ctx).Info("List move operation started",
 		"user_id", userID,
@@ -188,6 +226,9 @@ func (s *Service) Move(ctx context.Context, userID, listID, newPos int32) (db.Li
 		)
 		return db.List{}, errors.New("not a member")
 	}
+	if lst.Version != version {
+		return lst, versioning.ErrConflict
+	}
 
 	// Validate the new position
 	if newPos <= 0 {
@@ -300,7 +341,26 @@ func (s *Service) Move(ctx context.Context, userID, listID, newPos int32) (db.Li
 		"new_position", newPos,
 	)
 
-	// First remove the list from its current position
+	// Claim the list first, parked at position 0, so a concurrent update
+	// cannot slip in between the shifts below. Keep the original title.
+	updated, err := s.repo.Update(ctx, db.UpdateListParams{
+		ID:       listID,
+		Title:    lst.Title,
+		Position: 0,
+		Version:  version,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.conflict(ctx, listID)
+	}
+	if err != nil {
+		logger.WithContext(ctx).Error("Error updating list position",
+			"list_id", listID,
+			"error", err,
+		)
+		return db.List{}, err
+	}
+
+	// Remove the list from its old position
 	if err := s.repo.ShiftLeft(ctx, lst.BoardID, lst.Position); err != nil {
 		logger.WithContext(ctx).Error("Error shifting lists left",
 			"board_id", lst.BoardID,
@@ -320,20 +380,15 @@ func (s *Service) Move(ctx context.Context, userID, listID, newPos int32) (db.Li
 		return db.List{}, err
 	}
 
-	// Update the list with the new position, keeping the original title
-	updated, err := s.repo.Update(ctx, db.UpdateListParams{
-		ID:       listID,
-		Title:    lst.Title, // Keep the original title
-		Position: newPos,
-	})
-
-	if err != nil {
+	// Put the list at its new position
+	if err := s.repo.SetPosition(ctx, listID, newPos); err != nil {
 		logger.WithContext(ctx).Error("Error updating list position",
 			"list_id", listID,
 			"error", err,
 		)
 		return db.List{}, err
 	}
+	updated.Position = newPos
 
 	logger.WithContext(ctx).Info("List moved successfully",
 		"list_id", listID,
@@ -341,6 +396,11 @@ func (s *Service) Move(ctx context.Context, userID, listID, newPos int32) (db.Li
 		"board_id", lst.BoardID,
 	)
 
+	s.activity.Record(ctx, activity.Entry{
+		BoardID: lst.BoardID, ActorID: userID, Action: activity.ListMoved,
+		EntityType: activity.EntityList, EntityID: lst.ID, Before: lst, After: updated,
+	})
+
 	// Broadcast the change to all connected clients
 	s.hub.Broadcast(lst.BoardID, websocket.EventMessage{Event: "list_moved", Data: updated})
 
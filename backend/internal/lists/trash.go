@@ -0,0 +1,54 @@
+package lists
+
+import (
+	"context"
+	"errors"
+
+	"backend/internal/activity"
+	db "backend/internal/db/sqlc"
+	"backend/internal/websocket"
+)
+
+var ErrNotArchived = errors.New("list is not in the trash")
+
+// RestorePosition returns where a restored list or card goes: back to its
+// old position if that still exists, otherwise to the end (count+1).
+func RestorePosition(old, count int32) int32 {
+	if old < 1 || old > count+1 {
+		return count + 1
+	}
+	return old
+}
+
+// Restore takes a list out of the trash. It goes back to its old position
+// if that still exists, otherwise to the end of the board.
+func (s *Service) Restore(ctx context.Context, userID, listID int32) (db.List, error) {
+	lst, err := s.repo.GetArchived(ctx, listID)
+	if err != nil {
+		return db.List{}, ErrNotArchived
+	}
+	if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{
+		BoardID: lst.BoardID, UserID: userID,
+	}); err != nil {
+		return db.List{}, errors.New("not a member")
+	}
+
+	active, err := s.repo.ListByBoard(ctx, lst.BoardID)
+	if err != nil {
+		return db.List{}, err
+	}
+	pos := RestorePosition(lst.Position, int32(len(active)))
+	if err := s.repo.ShiftRight(ctx, lst.BoardID, pos); err != nil {
+		return db.List{}, err
+	}
+	restored, err := s.repo.Restore(ctx, db.RestoreListParams{ID: listID, Position: pos})
+	if err != nil {
+		return db.List{}, err
+	}
+	s.activity.Record(ctx, activity.Entry{
+		BoardID: lst.BoardID, ActorID: userID, Action: activity.ListRestored,
+		EntityType: activity.EntityList, EntityID: lst.ID, Before: lst, After: restored,
+	})
+	s.hub.Broadcast(lst.BoardID, websocket.EventMessage{Event: "list_restored", Data: restored})
+	return restored, nil
+}
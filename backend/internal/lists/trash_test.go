@@ -0,0 +1,19 @@
+// internal/lists/trash_test.go
+package lists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestorePosition(t *testing.T) {
+	// Old position still fits: the card goes back where it was.
+	assert.Equal(t, int32(2), RestorePosition(2, 4))
+	assert.Equal(t, int32(1), RestorePosition(1, 0))
+	// Right after the last card is still a valid slot.
+	assert.Equal(t, int32(5), RestorePosition(5, 4))
+	// The list shrank meanwhile: append at the end.
+	assert.Equal(t, int32(3), RestorePosition(7, 2))
+	assert.Equal(t, int32(3), RestorePosition(0, 2))
+}
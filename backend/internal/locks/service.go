@@ -0,0 +1,141 @@
+// Package locks implements soft edit locks on cards and "is typing"
+// indicators. Locks are advisory: they let the UI warn that someone else is
+// editing a card, the REST API does not enforce them.
+package locks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/websocket"
+)
+
+// TTL is how long a lock lasts without renewal. Clients renew by acquiring
+// the lock again, well before it runs out.
+const TTL = 30 * time.Second
+
+var (
+	ErrCardNotFound = errors.New("card not found")
+	ErrNotLocked    = errors.New("card is not locked by you")
+)
+
+// LockedError is returned when another user holds the lock.
+type LockedError struct {
+	UserID int32
+	Name   string
+}
+
+func (e *LockedError) Error() string {
+	return "card is being edited by " + e.Name
+}
+
+type Service struct {
+	q   *db.Queries
+	hub *websocket.Hub
+}
+
+func NewService(q *db.Queries, hub *websocket.Hub) *Service {
+	return &Service{q: q, hub: hub}
+}
+
+// checkCard makes sure the card lives on boardID, the board the client is
+// connected to. Membership was checked when the socket was opened.
+func (s *Service) checkCard(ctx context.Context, boardID, cardID int32) error {
+	card, err := s.q.GetCardByID(ctx, cardID)
+	if err != nil {
+		return ErrCardNotFound
+	}
+	lst, err := s.q.GetListByID(ctx, card.ListID)
+	if err != nil || lst.BoardID != boardID {
+		return ErrCardNotFound
+	}
+	return nil
+}
+
+// Acquire takes or renews the edit lock of a card. It fails with a
+// *LockedError while another user holds an unexpired lock.
+func (s *Service) Acquire(ctx context.Context, userID, boardID, cardID int32) (db.CardLock, error) {
+	if err := s.checkCard(ctx, boardID, cardID); err != nil {
+		return db.CardLock{}, err
+	}
+	lock, err := s.q.AcquireCardLock(ctx, db.AcquireCardLockParams{
+		CardID:     cardID,
+		BoardID:    boardID,
+		UserID:     userID,
+		TtlSeconds: int32(TTL / time.Second),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.CardLock{}, s.lockedBy(ctx, cardID)
+	}
+	if err != nil {
+		return db.CardLock{}, err
+	}
+	s.hub.Notify(boardID, websocket.EventMessage{Event: "card_locked", Data: lock})
+	return lock, nil
+}
+
+func (s *Service) lockedBy(ctx context.Context, cardID int32) error {
+	lock, err := s.q.GetCardLock(ctx, cardID)
+	if err != nil {
+		// Released or expired in the meantime; let the client retry.
+		return &LockedError{Name: "another user"}
+	}
+	e := &LockedError{UserID: lock.UserID, Name: "another user"}
+	if u, err := s.q.GetUserByID(ctx, lock.UserID); err == nil {
+		e.Name = u.Name
+	}
+	return e
+}
+
+// Release gives up a lock held by userID.
+func (s *Service) Release(ctx context.Context, userID, boardID, cardID int32) error {
+	n, err := s.q.ReleaseCardLock(ctx, db.ReleaseCardLockParams{CardID: cardID, UserID: userID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotLocked
+	}
+	s.hub.Notify(boardID, websocket.EventMessage{
+		Event: "card_unlocked", Data: map[string]int32{"cardId": cardID, "userId": userID},
+	})
+	return nil
+}
+
+// ReleaseAll drops every lock of a user on a board. The hub calls it when
+// the user's last connection to the board closes.
+func (s *Service) ReleaseAll(boardID, userID int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ids, err := s.q.ReleaseUserCardLocks(ctx, db.ReleaseUserCardLocksParams{BoardID: boardID, UserID: userID})
+	if err != nil {
+		logger.Error("Failed to release card locks",
+			"board_id", boardID,
+			"user_id", userID,
+			"error", err,
+		)
+		return
+	}
+	for _, id := range ids {
+		s.hub.Notify(boardID, websocket.EventMessage{
+			Event: "card_unlocked", Data: map[string]int32{"cardId": id, "userId": userID},
+		})
+	}
+}
+
+// Typing tells the board that userID is typing in a card. Nothing is
+// stored; clients show the indicator for a few seconds after each event.
+func (s *Service) Typing(ctx context.Context, userID, boardID, cardID int32) error {
+	if err := s.checkCard(ctx, boardID, cardID); err != nil {
+		return err
+	}
+	s.hub.Notify(boardID, websocket.EventMessage{
+		Event: "card_typing", Data: map[string]int32{"cardId": cardID, "userId": userID},
+	})
+	return nil
+}
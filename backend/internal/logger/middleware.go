@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -26,7 +27,7 @@ func RequestLogging() gin.HandlerFunc {
 		WithRequestID(requestID).Info("HTTP request started",
 			"method", c.Request.Method,
 			"path", c.Request.URL.Path,
-			"query", c.Request.URL.RawQuery,
+			"query", redactQuery(c.Request.URL.RawQuery),
 			"user_agent", c.Request.UserAgent(),
 			"remote_addr", c.ClientIP(),
 		)
@@ -86,3 +87,30 @@ func Recovery() gin.HandlerFunc {
 		c.AbortWithStatus(500)
 	})
 }
+
+// secretParams are query params that carry credentials, such as the JWT of
+// a WebSocket connection or the OIDC authorization code, and must not end up
+// in the logs.
+var secretParams = []string{"token", "ticket", "code", "state"}
+
+// redactQuery masks the values of secretParams in a raw query string.
+func redactQuery(raw string) string {
+	if raw == "" {
+		return raw
+	}
+	q, err := url.ParseQuery(raw)
+	if err != nil {
+		return "[unparsable]"
+	}
+	changed := false
+	for _, k := range secretParams {
+		if _, ok := q[k]; ok {
+			q.Set(k, "REDACTED")
+			changed = true
+		}
+	}
+	if !changed {
+		return raw
+	}
+	return q.Encode()
+}
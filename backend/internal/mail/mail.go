@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Mailer delivers email. Implementations must be safe for concurrent use.
+type Mailer interface {
+	Send(ctx context.Context, m Message) error
+}
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+var ErrInvalidHeader = errors.New("invalid mail header")
+
+// format renders m as an RFC 5322 message from the given sender, with CRLF
+// line endings.
+func (m Message) format(from string, date time.Time) ([]byte, error) {
+	for _, h := range []string{from, m.To, m.Subject} {
+		if strings.ContainsAny(h, "\r\n") {
+			return nil, ErrInvalidHeader
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + m.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", m.Subject) + "\r\n")
+	b.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	body := strings.ReplaceAll(m.Body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	if !strings.HasSuffix(body, "\n") {
+		b.WriteString("\r\n")
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,61 @@
+// internal/mail/mail_test.go
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_Format(t *testing.T) {
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	msg, err := Message{To: "ivan@example.com", Subject: "Сброс пароля", Body: "line one\nline two"}.
+		format("CollabBoard <no-reply@example.com>", date)
+	require.NoError(t, err)
+
+	s := string(msg)
+	assert.True(t, strings.HasPrefix(s, "From: CollabBoard <no-reply@example.com>\r\nTo: ivan@example.com\r\n"))
+	assert.Contains(t, s, "Subject: =?utf-8?q?")
+	assert.Contains(t, s, "Date: Fri, 01 Mar 2024 12:00:00 +0000\r\n")
+	assert.True(t, strings.HasSuffix(s, "\r\n\r\nline one\r\nline two\r\n"))
+}
+
+func TestMessage_FormatRejectsHeaderInjection(t *testing.T) {
+	for _, m := range []Message{
+		{To: "a@example.com\r\nBcc: b@example.com", Subject: "hi"},
+		{To: "a@example.com", Subject: "hi\nBcc: b@example.com"},
+	} {
+		_, err := m.format("no-reply@example.com", time.Now())
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	}
+}
+
+func TestOutbox_WritesMessages(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	o, err := NewOutbox(dir, "no-reply@example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, o.Send(context.Background(), Message{To: "ivan@example.com", Subject: "Hello", Body: "Hi"}))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.True(t, strings.HasSuffix(files[0].Name(), "-ivan@example.com.eml"))
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "To: ivan@example.com\r\n")
+	assert.Contains(t, string(data), "\r\n\r\nHi\r\n")
+}
+
+func TestOutbox_LogOnly(t *testing.T) {
+	o, err := NewOutbox("", "no-reply@example.com")
+	require.NoError(t, err)
+	assert.NoError(t, o.Send(context.Background(), Message{To: "ivan@example.com", Subject: "Hello", Body: "Hi"}))
+	assert.ErrorIs(t, o.Send(context.Background(), Message{To: "a\nb", Subject: "Hello"}), ErrInvalidHeader)
+}
@@ -0,0 +1,69 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/internal/logger"
+)
+
+// Outbox is a mailer for development and tests: instead of sending mail it
+// writes each message to a .eml file in a directory, or only logs it when
+// the directory is empty.
+type Outbox struct {
+	dir  string
+	from string
+}
+
+// NewOutbox creates the directory if needed.
+func NewOutbox(dir, from string) (*Outbox, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, err
+		}
+	}
+	return &Outbox{dir: dir, from: from}, nil
+}
+
+func (o *Outbox) Send(ctx context.Context, m Message) error {
+	now := time.Now()
+	msg, err := m.format(o.from, now)
+	if err != nil {
+		return err
+	}
+	if o.dir == "" {
+		logger.WithContext(ctx).Info("Mail not sent, outbox only",
+			"to", m.To,
+			"subject", m.Subject,
+			"body", m.Body,
+		)
+		return nil
+	}
+
+	name := strconv.FormatInt(now.UnixNano(), 10) + "-" + fileSafe(m.To) + ".eml"
+	path := filepath.Join(o.dir, name)
+	if err := os.WriteFile(path, msg, 0o640); err != nil {
+		return err
+	}
+	logger.WithContext(ctx).Info("Mail written to outbox",
+		"to", m.To,
+		"subject", m.Subject,
+		"path", path,
+	)
+	return nil
+}
+
+// fileSafe keeps the characters of an address that are safe in file names.
+func fileSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '@', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, s)
+}
@@ -0,0 +1,76 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPMailer sends mail through an SMTP server, upgrading the connection
+// with STARTTLS when the server offers it.
+type SMTPMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPMailer creates a mailer for the server at host:port. With an empty
+// username no authentication is attempted.
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{host: host, port: port, username: username, password: password, from: from}
+}
+
+func (s *SMTPMailer) Send(ctx context.Context, m Message) error {
+	msg, err := m.format(s.from, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(s.host, strconv.Itoa(s.port)))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(m.To); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"backend/internal/logger"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,8 +11,22 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// Auth verifies JWT and stores userID in context (key: "userID").
-func Auth(secret string) gin.HandlerFunc {
+// AccessTokenPrefix starts every personal access token, which tells them
+// apart from JWTs.
+const AccessTokenPrefix = "cbp_"
+
+// ErrTokenForbidden is returned by an AccessTokenFunc for a valid token that
+// does not grant access to the requested route.
+var ErrTokenForbidden = errors.New("token does not grant access to this resource")
+
+// AccessTokenFunc authenticates a personal access token for the request in
+// c and returns the user it belongs to.
+type AccessTokenFunc func(c *gin.Context, token string) (int32, error)
+
+// Auth verifies JWT and stores userID in context (key: "userID"). Tokens
+// issued for a session also store its ID (key: "sessionID"). If accessTokens
+// is not nil, personal access tokens are accepted too and checked by it.
+func Auth(secret string, accessTokens AccessTokenFunc) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := logger.GetRequestID(c.Request.Context())
 
@@ -34,6 +49,29 @@ func Auth(secret string) gin.HandlerFunc {
 			return
 		}
 		tokenStr := parts[1]
+		if accessTokens != nil && strings.HasPrefix(tokenStr, AccessTokenPrefix) {
+			uid, err := accessTokens(c, tokenStr)
+			if errors.Is(err, ErrTokenForbidden) {
+				logger.WithRequestID(requestID).Warn("Authentication failed: access token not allowed",
+					"path", c.Request.URL.Path,
+					"remote_addr", c.ClientIP(),
+				)
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+				return
+			}
+			if err != nil {
+				logger.WithRequestID(requestID).Warn("Authentication failed: invalid access token",
+					"path", c.Request.URL.Path,
+					"remote_addr", c.ClientIP(),
+					"error", err,
+				)
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+				return
+			}
+			authenticated(c, requestID, int(uid))
+			return
+		}
+
 		token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
 			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, jwt.ErrTokenUnverifiable
@@ -78,18 +116,26 @@ func Auth(secret string) gin.HandlerFunc {
 			return
 		}
 
-		// Store user ID in Gin context
-		c.Set("userID", uid)
+		if sid, ok := claims["sid"].(float64); ok {
+			c.Set("sessionID", int(sid))
+		}
+		authenticated(c, requestID, uid)
+	}
+}
+
+// authenticated records the user of the request and continues the chain.
+func authenticated(c *gin.Context, requestID string, uid int) {
+	// Store user ID in Gin context
+	c.Set("userID", uid)
 
-		// Add user ID to request context for logging
-		ctx := logger.WithUserIDContext(c.Request.Context(), int32(uid))
-		c.Request = c.Request.WithContext(ctx)
+	// Add user ID to request context for logging
+	ctx := logger.WithUserIDContext(c.Request.Context(), int32(uid))
+	c.Request = c.Request.WithContext(ctx)
 
-		logger.WithRequestID(requestID).Debug("Authentication successful",
-			"user_id", uid,
-			"path", c.Request.URL.Path,
-		)
+	logger.WithRequestID(requestID).Debug("Authentication successful",
+		"user_id", uid,
+		"path", c.Request.URL.Path,
+	)
 
-		c.Next()
-	}
+	c.Next()
 }
@@ -2,6 +2,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -43,7 +44,7 @@ func TestAuth_Middleware(t *testing.T) {
 		}
 
 		router := gin.New()
-		router.Use(Auth(secret))
+		router.Use(Auth(secret, nil))
 		router.GET("/protected", func(c *gin.Context) {
 			uid, _ := c.Get("userID")
 			c.JSON(http.StatusOK, gin.H{"userID": uid})
@@ -57,3 +58,86 @@ func TestAuth_Middleware(t *testing.T) {
 		}
 	}
 }
+
+func TestAuth_SessionID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	secret := "topsecret"
+	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "123", "sid": 7})
+	s, err := tok.SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	router := gin.New()
+	router.Use(Auth(secret, nil))
+	router.GET("/protected", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"sessionID": c.GetInt("sessionID")})
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"WithSession", "Bearer " + s, `"sessionID":7`},
+		{"WithoutSession", "Bearer " + token(t, secret, "123"), `"sessionID":0`},
+	}
+
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		r.Header.Set("Authorization", tc.header)
+		router.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code, tc.name)
+		assert.Contains(t, w.Body.String(), tc.want, tc.name)
+	}
+}
+
+func TestAuth_AccessTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	accessTokens := func(c *gin.Context, token string) (int32, error) {
+		switch token {
+		case AccessTokenPrefix + "good":
+			return 42, nil
+		case AccessTokenPrefix + "narrow":
+			return 0, ErrTokenForbidden
+		}
+		return 0, errors.New("unknown token")
+	}
+
+	router := gin.New()
+	router.Use(Auth("topsecret", accessTokens))
+	router.GET("/protected", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"userID": c.GetInt("userID")})
+	})
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{"Valid", AccessTokenPrefix + "good", http.StatusOK},
+		{"Forbidden", AccessTokenPrefix + "narrow", http.StatusForbidden},
+		{"Unknown", AccessTokenPrefix + "nope", http.StatusUnauthorized},
+		{"JWTStillWorks", token(t, "topsecret", "7"), http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		r.Header.Set("Authorization", "Bearer "+tc.token)
+		router.ServeHTTP(w, r)
+		assert.Equal(t, tc.expectedStatus, w.Code, tc.name)
+	}
+
+	// Without an AccessTokenFunc only JWTs are accepted.
+	jwtOnly := gin.New()
+	jwtOnly.Use(Auth("topsecret", nil))
+	jwtOnly.GET("/protected", func(c *gin.Context) {})
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+	r.Header.Set("Authorization", "Bearer "+AccessTokenPrefix+"good")
+	jwtOnly.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
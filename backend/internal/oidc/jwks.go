@@ -0,0 +1,150 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/http"
+	"sync"
+)
+
+// jwk is a public key of a JSON Web Key Set (RFC 7517).
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// keySet caches the signing keys of a provider. Keys are fetched on first
+// use and again whenever a token names a key the set does not know, which is
+// how providers roll their keys.
+type keySet struct {
+	client *http.Client
+	uri    string
+
+	mu   sync.Mutex
+	keys map[string]any
+}
+
+func newKeySet(client *http.Client, uri string) *keySet {
+	return &keySet{client: client, uri: uri}
+}
+
+var errUnknownKey = errors.New("unknown signing key")
+
+// key returns the public key with the given ID. An empty kid matches the
+// only key of a single-key set.
+func (s *keySet) key(ctx context.Context, kid string) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if k, ok := s.lookup(kid); ok {
+		return k, nil
+	}
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if k, ok := s.lookup(kid); ok {
+		return k, nil
+	}
+	return nil, errUnknownKey
+}
+
+func (s *keySet) lookup(kid string) (any, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, k := range s.keys {
+			return k, true
+		}
+	}
+	k, ok := s.keys[kid]
+	return k, ok
+}
+
+func (s *keySet) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.uri, nil)
+	if err != nil {
+		return err
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := getJSON(s.client, req, &set); err != nil {
+		return err
+	}
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		// Keys of unsupported types are skipped rather than failing the set.
+		if pub, err := k.publicKey(); err == nil {
+			keys[k.Kid] = pub
+		}
+	}
+	s.keys = keys
+	return nil
+}
+
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("rsa exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.New("unsupported curve")
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, errors.New("unsupported key type")
+	}
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
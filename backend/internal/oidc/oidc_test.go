@@ -0,0 +1,152 @@
+// internal/oidc/oidc_test.go
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"backend/internal/oidc/oidctest"
+)
+
+const redirectURL = "http://localhost:8080/auth/oidc/callback"
+
+func discover(t *testing.T, iss *oidctest.Issuer) *Provider {
+	t.Helper()
+	p, err := Discover(context.Background(), Config{
+		Issuer:       iss.URL,
+		ClientID:     iss.ClientID,
+		ClientSecret: iss.ClientSecret,
+		RedirectURL:  redirectURL,
+	})
+	require.NoError(t, err)
+	return p
+}
+
+// authorize follows the authorization URL and returns the code and state of
+// the callback.
+func authorize(t *testing.T, authURL string) (code, state string) {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return loc.Query().Get("code"), loc.Query().Get("state")
+}
+
+func TestProvider_LoginFlow(t *testing.T) {
+	iss := oidctest.NewIssuer("collabboard", "s3cret")
+	defer iss.Close()
+	iss.SignInAs(oidctest.User{Subject: "u-1", Email: "ivan@example.com", EmailVerified: true, Name: "Ivan"})
+	p := discover(t, iss)
+
+	authURL := p.AuthCodeURL("state-1", "nonce-1", "verifier-verifier-verifier-verifier-verifier")
+	q := mustQuery(t, authURL)
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+	assert.Equal(t, redirectURL, q.Get("redirect_uri"))
+
+	code, state := authorize(t, authURL)
+	assert.Equal(t, "state-1", state)
+
+	raw, err := p.Exchange(context.Background(), code, "verifier-verifier-verifier-verifier-verifier")
+	require.NoError(t, err)
+	id, err := p.Verify(context.Background(), raw, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, Identity{Issuer: iss.URL, Subject: "u-1", Email: "ivan@example.com", EmailVerified: true, Name: "Ivan"}, id)
+
+	_, err = p.Exchange(context.Background(), code, "verifier-verifier-verifier-verifier-verifier")
+	assert.ErrorIs(t, err, ErrExchange, "codes work once")
+}
+
+func TestProvider_ExchangeChecksPKCE(t *testing.T) {
+	iss := oidctest.NewIssuer("collabboard", "s3cret")
+	defer iss.Close()
+	p := discover(t, iss)
+
+	code, _ := authorize(t, p.AuthCodeURL("state", "nonce", "the-right-verifier"))
+	_, err := p.Exchange(context.Background(), code, "another-verifier")
+	assert.ErrorIs(t, err, ErrExchange)
+}
+
+func TestProvider_VerifyRejects(t *testing.T) {
+	iss := oidctest.NewIssuer("collabboard", "s3cret")
+	defer iss.Close()
+	p := discover(t, iss)
+
+	valid := func() jwt.MapClaims {
+		now := time.Now()
+		return jwt.MapClaims{
+			"iss": iss.URL, "sub": "u-1", "aud": "collabboard", "nonce": "nonce",
+			"iat": now.Unix(), "exp": now.Add(time.Hour).Unix(),
+		}
+	}
+	_, err := p.Verify(context.Background(), iss.Sign(valid()), "nonce")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(jwt.MapClaims)
+	}{
+		{"WrongIssuer", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{"WrongAudience", func(c jwt.MapClaims) { c["aud"] = "someone-else" }},
+		{"Expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{"NoExpiry", func(c jwt.MapClaims) { delete(c, "exp") }},
+		{"WrongNonce", func(c jwt.MapClaims) { c["nonce"] = "replayed" }},
+		{"NoSubject", func(c jwt.MapClaims) { delete(c, "sub") }},
+		{"ForeignAuthorizedParty", func(c jwt.MapClaims) { c["aud"] = []string{"collabboard", "other"}; c["azp"] = "other" }},
+	}
+	for _, tc := range tests {
+		c := valid()
+		tc.modify(c)
+		_, err := p.Verify(context.Background(), iss.Sign(c), "nonce")
+		assert.ErrorIs(t, err, ErrInvalidToken, tc.name)
+	}
+
+	hmac, err := jwt.NewWithClaims(jwt.SigningMethodHS256, valid()).SignedString([]byte("s3cret"))
+	require.NoError(t, err)
+	_, err = p.Verify(context.Background(), hmac, "nonce")
+	assert.ErrorIs(t, err, ErrInvalidToken, "tokens signed with the client secret")
+}
+
+func TestVerify_EmailVerifiedAsString(t *testing.T) {
+	iss := oidctest.NewIssuer("collabboard", "s3cret")
+	defer iss.Close()
+	p := discover(t, iss)
+
+	now := time.Now()
+	id, err := p.Verify(context.Background(), iss.Sign(jwt.MapClaims{
+		"iss": iss.URL, "sub": "u-1", "aud": "collabboard", "nonce": "nonce",
+		"iat": now.Unix(), "exp": now.Add(time.Hour).Unix(), "email_verified": "true",
+	}), "nonce")
+	require.NoError(t, err)
+	assert.True(t, id.EmailVerified)
+}
+
+func TestDiscover_IssuerMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issuer":"https://evil.example.com","authorization_endpoint":"a","token_endpoint":"t","jwks_uri":"j"}`))
+	}))
+	defer srv.Close()
+
+	_, err := Discover(context.Background(), Config{Issuer: srv.URL, ClientID: "collabboard"})
+	assert.ErrorIs(t, err, ErrDiscovery)
+}
+
+func mustQuery(t *testing.T, raw string) url.Values {
+	t.Helper()
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	return u.Query()
+}
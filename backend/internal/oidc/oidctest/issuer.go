@@ -0,0 +1,202 @@
+// Package oidctest provides an in-process OpenID Connect provider for tests.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeyID is the kid of the key the issuer signs ID tokens with.
+const KeyID = "oidctest"
+
+// User is who signs in at the issuer.
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// grant is an issued authorization code.
+type grant struct {
+	user        User
+	clientID    string
+	redirectURI string
+	nonce       string
+	challenge   string
+}
+
+// Issuer is a minimal OpenID Connect provider: discovery, JWKS, an
+// authorization endpoint that signs in User without asking, and a token
+// endpoint that checks the client credentials and PKCE.
+type Issuer struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
+
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	user  User
+	codes map[string]grant
+}
+
+// NewIssuer starts an issuer for one registered client. Close it when done.
+func NewIssuer(clientID, clientSecret string) *Issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	i := &Issuer{ClientID: clientID, ClientSecret: clientSecret, key: key, codes: map[string]grant{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", i.discovery)
+	mux.HandleFunc("/jwks", i.jwks)
+	mux.HandleFunc("/authorize", i.authorize)
+	mux.HandleFunc("/token", i.token)
+	i.server = httptest.NewServer(mux)
+	i.URL = i.server.URL
+	return i
+}
+
+func (i *Issuer) Close() {
+	i.server.Close()
+}
+
+// SignInAs sets the user the authorization endpoint signs in.
+func (i *Issuer) SignInAs(u User) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.user = u
+}
+
+// Sign signs arbitrary ID token claims with the issuer's key.
+func (i *Issuer) Sign(claims jwt.MapClaims) string {
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	t.Header["kid"] = KeyID
+	s, err := t.SignedString(i.key)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (i *Issuer) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 i.URL,
+		"authorization_endpoint": i.URL + "/authorize",
+		"token_endpoint":         i.URL + "/token",
+		"jwks_uri":               i.URL + "/jwks",
+	})
+}
+
+func (i *Issuer) jwks(w http.ResponseWriter, _ *http.Request) {
+	pub := i.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": KeyID,
+		"use": "sig",
+		"alg": "RS256",
+		"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}}})
+}
+
+// authorize signs in the current user and redirects back with a code.
+func (i *Issuer) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != i.ClientID || q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	code := randomString()
+	i.mu.Lock()
+	i.codes[code] = grant{
+		user:        i.user,
+		clientID:    q.Get("client_id"),
+		redirectURI: q.Get("redirect_uri"),
+		nonce:       q.Get("nonce"),
+		challenge:   q.Get("code_challenge"),
+	}
+	i.mu.Unlock()
+
+	back, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	bq := back.Query()
+	bq.Set("code", code)
+	bq.Set("state", q.Get("state"))
+	back.RawQuery = bq.Encode()
+	http.Redirect(w, r, back.String(), http.StatusFound)
+}
+
+func (i *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != i.ClientID || secret != i.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	i.mu.Lock()
+	g, ok := i.codes[code]
+	delete(i.codes, code)
+	i.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || g.clientID != id || g.redirectURI != r.PostForm.Get("redirect_uri") ||
+		base64.RawURLEncoding.EncodeToString(sum[:]) != g.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	idToken := i.Sign(jwt.MapClaims{
+		"iss":            i.URL,
+		"sub":            g.user.Subject,
+		"aud":            i.ClientID,
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          g.nonce,
+		"email":          g.user.Email,
+		"email_verified": g.user.EmailVerified,
+		"name":           g.user.Name,
+	})
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,161 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Config describes the client registration at an OpenID Connect provider.
+type Config struct {
+	// Issuer is the issuer URL of the provider, such as
+	// "https://login.example.com/realms/acme".
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the callback URL registered with the provider.
+	RedirectURL string
+	Scopes      []string
+}
+
+// metadata is the part of the provider's discovery document the login flow
+// needs.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider runs the authorization code flow with PKCE against one OpenID
+// Connect provider and validates the ID tokens it issues.
+type Provider struct {
+	cfg    Config
+	meta   metadata
+	client *http.Client
+	keys   *keySet
+}
+
+var (
+	ErrDiscovery    = errors.New("oidc discovery failed")
+	ErrExchange     = errors.New("oidc code exchange failed")
+	ErrInvalidToken = errors.New("invalid id token")
+)
+
+// Discover fetches the discovery document of cfg.Issuer and returns a
+// provider for it.
+func Discover(ctx context.Context, cfg Config) (*Provider, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	issuer := strings.TrimRight(cfg.Issuer, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	var meta metadata
+	if err := getJSON(client, req, &meta); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscovery, err)
+	}
+	// The document must be about the issuer we asked for, or ID tokens of
+	// another issuer would be accepted.
+	if strings.TrimRight(meta.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("%w: issuer %q does not match %q", ErrDiscovery, meta.Issuer, cfg.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: incomplete discovery document", ErrDiscovery)
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	return &Provider{cfg: cfg, meta: meta, client: client, keys: newKeySet(client, meta.JWKSURI)}, nil
+}
+
+// Issuer is the issuer of the provider as stated in its discovery document.
+func (p *Provider) Issuer() string {
+	return p.meta.Issuer
+}
+
+// AuthCodeURL is where the browser is sent to sign in. state and nonce come
+// back in the callback and the ID token; verifier is the PKCE code verifier
+// that Exchange needs.
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(p.meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.meta.AuthorizationEndpoint + sep + q.Encode()
+}
+
+// tokenResponse is the answer of the token endpoint, successful or not.
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange trades an authorization code for the raw ID token.
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	var tr tokenResponse
+	if err := getJSON(p.client, req, &tr); err != nil && tr.Error == "" {
+		return "", fmt.Errorf("%w: %v", ErrExchange, err)
+	}
+	if tr.Error != "" {
+		return "", fmt.Errorf("%w: %s %s", ErrExchange, tr.Error, tr.ErrorDescription)
+	}
+	if tr.IDToken == "" {
+		return "", fmt.Errorf("%w: no id_token in response", ErrExchange)
+	}
+	return tr.IDToken, nil
+}
+
+// getJSON sends req and decodes the JSON body into v. The body is decoded
+// even for error statuses, as OAuth errors come as JSON.
+func getJSON(client *http.Client, req *http.Request, v any) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	jsonErr := json.Unmarshal(body, v)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return jsonErr
+}
@@ -0,0 +1,79 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Identity is the user an ID token vouches for.
+type Identity struct {
+	Issuer  string
+	Subject string
+	Email   string
+	// EmailVerified is true when the provider vouches that the user owns
+	// Email.
+	EmailVerified bool
+	Name          string
+}
+
+// idClaims are the claims of an ID token the login flow reads.
+type idClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce"`
+	AuthorizedBy  string `json:"azp"`
+	Email         string `json:"email"`
+	EmailVerified any    `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+// signingMethods are the asymmetric algorithms ID tokens may be signed
+// with. HMAC is left out: its key would be the client secret.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// clockSkew is how far the clocks of the provider and this server may
+// differ.
+const clockSkew = time.Minute
+
+// Verify checks the signature, issuer, audience, expiry and nonce of a raw
+// ID token and returns the identity it carries.
+func (p *Provider) Verify(ctx context.Context, rawIDToken, nonce string) (Identity, error) {
+	var claims idClaims
+	_, err := jwt.ParseWithClaims(rawIDToken, &claims,
+		func(t *jwt.Token) (any, error) {
+			kid, _ := t.Header["kid"].(string)
+			return p.keys.key(ctx, kid)
+		},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(p.meta.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	// A token for several audiences must have been issued to us
+	if len(claims.Audience) > 1 && claims.AuthorizedBy != p.cfg.ClientID {
+		return Identity{}, fmt.Errorf("%w: azp does not match client", ErrInvalidToken)
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return Identity{}, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+	if claims.Subject == "" {
+		return Identity{}, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return Identity{
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+		Email:   claims.Email,
+		// Some providers send the flag as a string
+		EmailVerified: claims.EmailVerified == true || claims.EmailVerified == "true",
+		Name:          claims.Name,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package snapshot
+
+import (
+	"time"
+
+	"backend/internal/cards"
+)
+
+// SnapshotResponse documents a full board snapshot. Handlers send Snapshot,
+// whose fields have no JSON tags, so they keep their Go names on the wire.
+type SnapshotResponse struct {
+	Board    SnapshotBoard    `json:"Board"`
+	Revision int64            `json:"Revision" example:"42"`
+	Role     string           `json:"Role" example:"owner"`
+	Members  []SnapshotMember `json:"Members"`
+	Labels   []SnapshotLabel  `json:"Labels"`
+	Lists    []SnapshotList   `json:"Lists"`
+	Locks    []SnapshotLock   `json:"Locks"`
+}
+
+// SnapshotLock represents a card edit lock in a snapshot
+type SnapshotLock struct {
+	CardID    int32     `json:"CardID" example:"7"`
+	BoardID   int32     `json:"BoardID" example:"1"`
+	UserID    int32     `json:"UserID" example:"2"`
+	ExpiresAt time.Time `json:"ExpiresAt" example:"2023-01-01T00:00:30Z"`
+}
+
+// SnapshotBoard represents the board in a snapshot
+type SnapshotBoard struct {
+	ID         int32      `json:"ID" example:"1"`
+	Name       string     `json:"Name" example:"My Project Board"`
+	OwnerID    int32      `json:"OwnerID" example:"1"`
+	CreatedAt  time.Time  `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+	ArchivedAt *time.Time `json:"ArchivedAt"`
+	Version    int32      `json:"Version" example:"3"`
+}
+
+// SnapshotMember represents a board member in a snapshot
+type SnapshotMember struct {
+	UserID int32  `json:"UserID" example:"2"`
+	Name   string `json:"Name" example:"John Doe"`
+	Email  string `json:"Email" example:"john@example.com"`
+	Role   string `json:"Role" example:"member"`
+}
+
+// SnapshotLabel represents a board label in a snapshot
+type SnapshotLabel struct {
+	ID        int32     `json:"ID" example:"1"`
+	BoardID   int32     `json:"BoardID" example:"1"`
+	Name      string    `json:"Name" example:"bug"`
+	Color     string    `json:"Color" example:"#eb5a46"`
+	CreatedAt time.Time `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+}
+
+// SnapshotList represents a list with its cards in a snapshot. Cards are
+// sent as everywhere else.
+type SnapshotList struct {
+	ID         int32                `json:"ID" example:"1"`
+	BoardID    int32                `json:"BoardID" example:"1"`
+	Title      string               `json:"Title" example:"To Do"`
+	Position   int32                `json:"Position" example:"1"`
+	CreatedAt  time.Time            `json:"CreatedAt" example:"2023-01-01T00:00:00Z"`
+	ArchivedAt *time.Time           `json:"ArchivedAt"`
+	Version    int32                `json:"Version" example:"2"`
+	Cards      []cards.CardResponse `json:"Cards"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error" example:"not a member"`
+}
@@ -0,0 +1,34 @@
+// internal/snapshot/dto_test.go
+package snapshot
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"backend/internal/cards"
+	db "backend/internal/db/sqlc"
+)
+
+// keys returns the top-level JSON keys of v.
+func keys(t *testing.T, v any) []string {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	var m map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(b, &m))
+	return slices.Sorted(maps.Keys(m))
+}
+
+// The Swagger DTOs must describe what the handler actually sends.
+func TestSnapshotResponse_MatchesPayload(t *testing.T) {
+	assert.Equal(t, keys(t, Snapshot{}), keys(t, SnapshotResponse{}))
+	assert.Equal(t, keys(t, db.Board{}), keys(t, SnapshotBoard{}))
+	assert.Equal(t, keys(t, db.ListBoardMembersRow{}), keys(t, SnapshotMember{}))
+	assert.Equal(t, keys(t, db.Label{}), keys(t, SnapshotLabel{}))
+	assert.Equal(t, keys(t, List{Cards: []cards.CardDetails{}}), keys(t, SnapshotList{}))
+	assert.Equal(t, keys(t, db.CardLock{}), keys(t, SnapshotLock{}))
+}
@@ -0,0 +1,25 @@
+// internal/snapshot/etag_test.go
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	a := ETag([]byte(`{"Board":{"ID":1}}`))
+	assert.Equal(t, a, ETag([]byte(`{"Board":{"ID":1}}`)))
+	assert.NotEqual(t, a, ETag([]byte(`{"Board":{"ID":2}}`)))
+	assert.Len(t, a, 34)
+}
+
+func TestMatchETag(t *testing.T) {
+	etag := `"abc"`
+	assert.True(t, matchETag(`"abc"`, etag))
+	assert.True(t, matchETag(`W/"abc"`, etag))
+	assert.True(t, matchETag(`"x", "abc"`, etag))
+	assert.True(t, matchETag(`*`, etag))
+	assert.False(t, matchETag(``, etag))
+	assert.False(t, matchETag(`"abcd"`, etag))
+}
@@ -0,0 +1,60 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup, svc *Service) {
+	r.GET("/boards/:boardId/snapshot", snapshotHandler(svc))
+}
+
+// snapshotHandler gets a full board snapshot
+//
+//	@Summary		Get board snapshot
+//	@Description	Get the board, its members, labels, lists and cards in one consistent read. Send the returned ETag in If-None-Match to revalidate
+//	@Tags			Boards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId			path		int					true	"Board ID"
+//	@Param			If-None-Match	header		string				false	"ETag of a previously loaded snapshot"
+//	@Success		200				{object}	SnapshotResponse	"Board snapshot"
+//	@Success		304				"Snapshot has not changed"
+//	@Failure		401				{object}	ErrorResponse		"Unauthorized"
+//	@Failure		403				{object}	ErrorResponse		"Forbidden - not a board member"
+//	@Failure		500				{object}	ErrorResponse		"Internal server error"
+//	@Router			/api/boards/{boardId}/snapshot [get]
+func snapshotHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		boardID, _ := strconv.Atoi(c.Param("boardId"))
+		userID := int32(c.GetInt("userID"))
+
+		snap, err := svc.Load(c.Request.Context(), userID, int32(boardID))
+		if err != nil {
+			if errors.Is(err, ErrNotMember) {
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			return
+		}
+		body, err := json.Marshal(snap)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		etag := ETag(body)
+		c.Header("ETag", etag)
+		c.Header("Cache-Control", "private, no-cache")
+		if matchETag(c.GetHeader("If-None-Match"), etag) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	}
+}
@@ -0,0 +1,152 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"backend/internal/cards"
+	db "backend/internal/db/sqlc"
+)
+
+var ErrNotMember = errors.New("not a member")
+
+// Service loads whole boards in one go. It needs the pool rather than
+// *db.Queries because every read has to happen in the same transaction.
+type Service struct {
+	pool *pgxpool.Pool
+}
+
+func NewService(pool *pgxpool.Pool) *Service {
+	return &Service{pool: pool}
+}
+
+// Snapshot is everything a client needs to render a board.
+type Snapshot struct {
+	Board db.Board
+	// Revision is the board revision the snapshot was read at; pass it to
+	// GET /boards/:boardId/changes to catch up from here.
+	Revision int64
+	Role     string
+	Members  []db.ListBoardMembersRow
+	Labels   []db.Label
+	Lists    []List
+	// Locks are the cards someone is editing right now.
+	Locks []db.CardLock
+}
+
+// List is a list together with its cards in position order.
+type List struct {
+	db.List
+	Cards []cards.CardDetails
+}
+
+// Load reads the board, its members, labels, lists and cards in a single
+// read-only repeatable read transaction, so the result is consistent even
+// while other users keep editing the board.
+func (s *Service) Load(ctx context.Context, userID, boardID int32) (Snapshot, error) {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return Snapshot{}, err
+	}
+	defer tx.Rollback(ctx)
+	q := db.New(tx)
+
+	member, err := q.GetBoardMember(ctx, db.GetBoardMemberParams{BoardID: boardID, UserID: userID})
+	if err != nil {
+		return Snapshot{}, ErrNotMember
+	}
+	board, err := q.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	rev, err := q.GetBoardRevision(ctx, boardID)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	members, err := q.ListBoardMembers(ctx, boardID)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	labels, err := q.ListLabelsByBoard(ctx, boardID)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	ls, err := q.ListListsByBoard(ctx, boardID)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	cs, err := q.ListCardsByBoard(ctx, boardID)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	details, err := cards.WithDetails(ctx, q, cs)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	cardLocks, err := q.ListCardLocksByBoard(ctx, boardID)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return Snapshot{}, err
+	}
+
+	out := Snapshot{
+		Board:    board,
+		Revision: rev,
+		Role:     member.Role,
+		Members:  members,
+		Labels:   labels,
+		Lists:    make([]List, len(ls)),
+		Locks:    cardLocks,
+	}
+	if out.Members == nil {
+		out.Members = []db.ListBoardMembersRow{}
+	}
+	if out.Labels == nil {
+		out.Labels = []db.Label{}
+	}
+	if out.Locks == nil {
+		out.Locks = []db.CardLock{}
+	}
+	index := make(map[int32]int, len(ls))
+	for i, l := range ls {
+		index[l.ID] = i
+		out.Lists[i] = List{List: l, Cards: []cards.CardDetails{}}
+	}
+	// Cards come ordered by list and position, so appending keeps the order.
+	for _, c := range details {
+		if i, ok := index[c.ListID]; ok {
+			out.Lists[i].Cards = append(out.Lists[i].Cards, c)
+		}
+	}
+	return out, nil
+}
+
+// ETag returns a strong entity tag for an encoded snapshot.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// matchETag reports whether an If-None-Match header value matches etag.
+// Weak tags compare equal to their strong counterpart, as RFC 9110 requires
+// for If-None-Match.
+func matchETag(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
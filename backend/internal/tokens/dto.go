@@ -0,0 +1,36 @@
+package tokens
+
+import "time"
+
+// CreateTokenRequest represents the request body for creating a personal access token
+type CreateTokenRequest struct {
+	Name   string   `json:"name" binding:"required,max=100" example:"CI pipeline"`
+	Scopes []string `json:"scopes" binding:"required,min=1" example:"cards:write"`
+	// BoardIDs restricts the token to these boards; empty allows all boards of the user
+	BoardIDs  []int32    `json:"boardIds" example:"1"`
+	ExpiresAt *time.Time `json:"expiresAt" example:"2025-01-01T00:00:00Z"`
+}
+
+// TokenResponse represents a personal access token in API responses
+type TokenResponse struct {
+	ID          int32      `json:"id" example:"1"`
+	Name        string     `json:"name" example:"CI pipeline"`
+	TokenPrefix string     `json:"tokenPrefix" example:"cbp_q3Zb"`
+	Scopes      []string   `json:"scopes" example:"cards:write"`
+	BoardIDs    []int32    `json:"boardIds" example:"1"`
+	CreatedAt   time.Time  `json:"createdAt" example:"2024-01-01T00:00:00Z"`
+	LastUsedAt  *time.Time `json:"lastUsedAt"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+}
+
+// CreatedTokenResponse is returned once, when a token is created; the token
+// itself cannot be retrieved later
+type CreatedTokenResponse struct {
+	TokenResponse
+	Token string `json:"token" example:"cbp_q3Zb0Yl2..."`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error" example:"Token not found"`
+}
@@ -0,0 +1,109 @@
+package tokens
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.RouterGroup, svc *Service) {
+	g := r.Group("/tokens")
+	g.GET("", listTokensHandler(svc))
+	g.POST("", createTokenHandler(svc))
+	g.DELETE("/:id", revokeTokenHandler(svc))
+}
+
+// errorStatus maps service errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotMember):
+		return http.StatusForbidden
+	case errors.Is(err, ErrTokenNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidScope), errors.Is(err, ErrInvalidExpiry):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// listTokensHandler lists the personal access tokens of the current user
+//
+//	@Summary		List personal access tokens
+//	@Description	Get the personal access tokens of the current user, newest first. The tokens themselves are never returned, only their prefix
+//	@Tags			Access Tokens
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		TokenResponse	"List of tokens"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/tokens [get]
+func listTokensHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := int32(c.GetInt("userID"))
+		tokens, err := svc.List(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, tokens)
+	}
+}
+
+// createTokenHandler issues a personal access token
+//
+//	@Summary		Create a personal access token
+//	@Description	Issue a token for scripts and CI with the given scopes (boards:read, boards:write, cards:read, cards:write), optionally restricted to some boards and with an expiry. The token is returned only in this response
+//	@Tags			Access Tokens
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		CreateTokenRequest		true	"Token details"
+//	@Success		201		{object}	CreatedTokenResponse	"Token created successfully"
+//	@Failure		400		{object}	ErrorResponse			"Invalid request, unknown scope or expiry in the past"
+//	@Failure		401		{object}	ErrorResponse			"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse			"Forbidden - not a member of a listed board"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/api/tokens [post]
+func createTokenHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req CreateTokenRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		userID := int32(c.GetInt("userID"))
+		token, err := svc.Create(c.Request.Context(), userID, req)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, token)
+	}
+}
+
+// revokeTokenHandler revokes a personal access token
+//
+//	@Summary		Revoke a personal access token
+//	@Description	Delete a personal access token of the current user; requests made with it fail from then on
+//	@Tags			Access Tokens
+//	@Security		BearerAuth
+//	@Param			id	path	int	true	"Token ID"
+//	@Success		204	"Token revoked"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404	{object}	ErrorResponse	"Token not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal server error"
+//	@Router			/api/tokens/{id} [delete]
+func revokeTokenHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		userID := int32(c.GetInt("userID"))
+		if err := svc.Revoke(c.Request.Context(), userID, int32(id)); err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,27 @@
+package tokens
+
+import (
+	"context"
+
+	db "backend/internal/db/sqlc"
+)
+
+type Repository struct{ q *db.Queries }
+
+func NewRepository(q *db.Queries) *Repository { return &Repository{q: q} }
+
+func (r *Repository) Create(ctx context.Context, arg db.CreatePersonalAccessTokenParams) (db.PersonalAccessToken, error) {
+	return r.q.CreatePersonalAccessToken(ctx, arg)
+}
+func (r *Repository) GetByHash(ctx context.Context, hash string) (db.PersonalAccessToken, error) {
+	return r.q.GetPersonalAccessTokenByHash(ctx, hash)
+}
+func (r *Repository) ListByUser(ctx context.Context, userID int32) ([]db.PersonalAccessToken, error) {
+	return r.q.ListPersonalAccessTokensByUser(ctx, userID)
+}
+func (r *Repository) Touch(ctx context.Context, id int32) error {
+	return r.q.TouchPersonalAccessToken(ctx, id)
+}
+func (r *Repository) Delete(ctx context.Context, id, userID int32) (int64, error) {
+	return r.q.DeletePersonalAccessToken(ctx, db.DeletePersonalAccessTokenParams{ID: id, UserID: userID})
+}
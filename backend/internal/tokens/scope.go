@@ -0,0 +1,69 @@
+package tokens
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Scopes a personal access token can be granted. Write scopes include the
+// matching read scope.
+const (
+	BoardsRead  = "boards:read"
+	BoardsWrite = "boards:write"
+	CardsRead   = "cards:read"
+	CardsWrite  = "cards:write"
+)
+
+var validScopes = map[string]bool{BoardsRead: true, BoardsWrite: true, CardsRead: true, CardsWrite: true}
+
+// resources maps route prefixes to the resource that guards them. Boards
+// cover the board itself, its lists, labels, members and history; cards
+// cover cards and everything attached to them. Routes not listed here, such
+// as token management and connection tickets, cannot be used with personal
+// access tokens at all.
+var resources = []struct {
+	prefix   string
+	resource string
+}{
+	{"/api/boards/:boardId/ws-ticket", ""},
+	{"/api/boards", "boards"},
+	{"/api/lists/:listId/cards", "cards"},
+	{"/api/cards/", "cards"},
+	{"/api/checklists/", "cards"},
+	{"/api/checklist-items/", "cards"},
+	{"/api/me/cards", "cards"},
+}
+
+// requiredScope returns the scope a token needs for a route, given as its
+// method and gin full path. ok is false for routes tokens may not use; an
+// empty scope means any token may.
+func requiredScope(method, path string) (scope string, ok bool) {
+	if path == "/api/me" {
+		return "", true
+	}
+	for _, r := range resources {
+		if !strings.HasPrefix(path, r.prefix) {
+			continue
+		}
+		if r.resource == "" {
+			return "", false
+		}
+		if method == http.MethodGet || method == http.MethodHead {
+			return r.resource + ":read", true
+		}
+		return r.resource + ":write", true
+	}
+	return "", false
+}
+
+// grants reports whether scopes include scope, directly or through the
+// matching write scope.
+func grants(scopes []string, scope string) bool {
+	write := strings.TrimSuffix(scope, ":read") + ":write"
+	for _, s := range scopes {
+		if s == scope || s == write {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+// internal/tokens/scope_test.go
+package tokens
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method, path string
+		scope        string
+		ok           bool
+	}{
+		{"GET", "/api/me", "", true},
+		{"GET", "/api/boards", BoardsRead, true},
+		{"POST", "/api/boards", BoardsWrite, true},
+		{"GET", "/api/boards/:boardId/lists", BoardsRead, true},
+		{"DELETE", "/api/boards/:boardId/members/:userId", BoardsWrite, true},
+		{"GET", "/api/lists/:listId/cards", CardsRead, true},
+		{"PUT", "/api/lists/:listId/cards/:id/move", CardsWrite, true},
+		{"POST", "/api/cards/:id/comments", CardsWrite, true},
+		{"PUT", "/api/checklist-items/:id", CardsWrite, true},
+		{"GET", "/api/me/cards", CardsRead, true},
+		{"POST", "/api/boards/:boardId/ws-ticket", "", false},
+		{"POST", "/api/me/ws-ticket", "", false},
+		{"GET", "/api/tokens", "", false},
+		{"DELETE", "/api/tokens/:id", "", false},
+	}
+	for _, tc := range tests {
+		scope, ok := requiredScope(tc.method, tc.path)
+		assert.Equal(t, tc.ok, ok, "%s %s", tc.method, tc.path)
+		assert.Equal(t, tc.scope, scope, "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestGrants(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+		scope  string
+		want   bool
+	}{
+		{"Exact", []string{CardsRead}, CardsRead, true},
+		{"WriteImpliesRead", []string{CardsWrite}, CardsRead, true},
+		{"ReadNotWrite", []string{CardsRead}, CardsWrite, false},
+		{"OtherResource", []string{BoardsWrite}, CardsRead, false},
+		{"None", nil, BoardsRead, false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, grants(tc.scopes, tc.scope), tc.name)
+	}
+}
@@ -0,0 +1,204 @@
+package tokens
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+	"backend/internal/middleware"
+)
+
+type Service struct {
+	repo *Repository
+	q    *db.Queries
+}
+
+func NewService(repo *Repository, q *db.Queries) *Service {
+	return &Service{repo: repo, q: q}
+}
+
+var (
+	ErrInvalidScope  = errors.New("invalid scope")
+	ErrInvalidExpiry = errors.New("expiry must be in the future")
+	ErrNotMember     = errors.New("forbidden: not a board member")
+	ErrTokenNotFound = errors.New("token not found")
+	errUnknownToken  = errors.New("unknown or expired token")
+	errBoardUnknown  = errors.New("request does not name a board")
+)
+
+// Create issues a personal access token for the user. The returned token is
+// shown once; only its hash is stored.
+func (s *Service) Create(ctx context.Context, userID int32, req CreateTokenRequest) (CreatedTokenResponse, error) {
+	for _, sc := range req.Scopes {
+		if !validScopes[sc] {
+			return CreatedTokenResponse{}, ErrInvalidScope
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return CreatedTokenResponse{}, ErrInvalidExpiry
+	}
+	for _, boardID := range req.BoardIDs {
+		if _, err := s.q.GetBoardMember(ctx, db.GetBoardMemberParams{BoardID: boardID, UserID: userID}); err != nil {
+			return CreatedTokenResponse{}, ErrNotMember
+		}
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return CreatedTokenResponse{}, err
+	}
+	token := middleware.AccessTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	arg := db.CreatePersonalAccessTokenParams{
+		UserID:      userID,
+		Name:        req.Name,
+		TokenHash:   hashToken(token),
+		TokenPrefix: token[:len(middleware.AccessTokenPrefix)+4],
+		Scopes:      slices.Compact(slices.Sorted(slices.Values(req.Scopes))),
+	}
+	if len(req.BoardIDs) > 0 {
+		arg.BoardIds = slices.Compact(slices.Sorted(slices.Values(req.BoardIDs)))
+	}
+	if req.ExpiresAt != nil {
+		arg.ExpiresAt = pgtype.Timestamp{Time: req.ExpiresAt.UTC(), Valid: true}
+	}
+	t, err := s.repo.Create(ctx, arg)
+	if err != nil {
+		return CreatedTokenResponse{}, err
+	}
+	return CreatedTokenResponse{TokenResponse: toResponse(t), Token: token}, nil
+}
+
+// List returns the tokens of the user, newest first, expired ones included.
+func (s *Service) List(ctx context.Context, userID int32) ([]TokenResponse, error) {
+	ts, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]TokenResponse, len(ts))
+	for i, t := range ts {
+		out[i] = toResponse(t)
+	}
+	return out, nil
+}
+
+// Revoke deletes a token of the user. It stops working immediately.
+func (s *Service) Revoke(ctx context.Context, userID, id int32) error {
+	n, err := s.repo.Delete(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+// Authenticate is a middleware.AccessTokenFunc: it looks the token up and
+// checks that its scopes and boards allow the route of c.
+func (s *Service) Authenticate(c *gin.Context, token string) (int32, error) {
+	ctx := c.Request.Context()
+	t, err := s.repo.GetByHash(ctx, hashToken(token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, errUnknownToken
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	scope, ok := requiredScope(c.Request.Method, c.FullPath())
+	if !ok || (scope != "" && !grants(t.Scopes, scope)) {
+		return 0, middleware.ErrTokenForbidden
+	}
+	if len(t.BoardIds) > 0 && scope != "" {
+		boardID, err := s.boardOf(c)
+		if err != nil || !slices.Contains(t.BoardIds, boardID) {
+			return 0, middleware.ErrTokenForbidden
+		}
+	}
+
+	// Last use is informational, so a failed update does not fail the request
+	if err := s.repo.Touch(ctx, t.ID); err != nil {
+		logger.WithContext(ctx).Warn("Failed to record access token use", "token_id", t.ID, "error", err)
+	}
+	return t.UserID, nil
+}
+
+// boardOf returns the board the request in c acts on, found from the IDs in
+// its path.
+func (s *Service) boardOf(c *gin.Context) (int32, error) {
+	ctx := c.Request.Context()
+	path := c.FullPath()
+	param := func(name string) (int32, error) {
+		id, err := strconv.ParseInt(c.Param(name), 10, 32)
+		return int32(id), err
+	}
+
+	switch {
+	case c.Param("boardId") != "":
+		return param("boardId")
+	case strings.HasPrefix(path, "/api/lists/:listId"):
+		id, err := param("listId")
+		if err != nil {
+			return 0, err
+		}
+		return s.q.GetListBoardID(ctx, id)
+	case strings.HasPrefix(path, "/api/cards/:id"):
+		id, err := param("id")
+		if err != nil {
+			return 0, err
+		}
+		return s.q.GetCardBoardID(ctx, id)
+	case strings.HasPrefix(path, "/api/checklists/:id"):
+		id, err := param("id")
+		if err != nil {
+			return 0, err
+		}
+		return s.q.GetChecklistBoardID(ctx, id)
+	case strings.HasPrefix(path, "/api/checklist-items/:id"):
+		id, err := param("id")
+		if err != nil {
+			return 0, err
+		}
+		return s.q.GetChecklistItemBoardID(ctx, id)
+	}
+	// Routes across boards, such as board lists, are off limits for tokens
+	// restricted to some boards
+	return 0, errBoardUnknown
+}
+
+func toResponse(t db.PersonalAccessToken) TokenResponse {
+	r := TokenResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		TokenPrefix: t.TokenPrefix,
+		Scopes:      t.Scopes,
+		BoardIDs:    t.BoardIds,
+		CreatedAt:   t.CreatedAt.Time,
+	}
+	if t.LastUsedAt.Valid {
+		r.LastUsedAt = &t.LastUsedAt.Time
+	}
+	if t.ExpiresAt.Valid {
+		r.ExpiresAt = &t.ExpiresAt.Time
+	}
+	return r
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,72 @@
+// Package versioning implements the optimistic concurrency checks shared by
+// boards, lists and cards. Every update names the version it is based on,
+// either in the request body or in an If-Match header, and fails with
+// 409 Conflict and the current entity if someone changed it in the meantime.
+package versioning
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrConflict = errors.New("version conflict")
+	ErrRequired = errors.New("version is required: send it in the body or an If-Match header")
+	ErrInvalid  = errors.New("invalid If-Match header")
+)
+
+// ConflictResponse is the body of a 409 reply. Current is the entity as it
+// is now, so the client can merge and retry without reloading it.
+type ConflictResponse struct {
+	Error   string      `json:"error" example:"version conflict"`
+	Current interface{} `json:"current"`
+}
+
+// Expected returns the version a request is based on. A version in the body
+// takes precedence over the If-Match header.
+func Expected(c *gin.Context, body *int32) (int32, error) {
+	if body != nil {
+		if *body < 1 {
+			return 0, ErrRequired
+		}
+		return *body, nil
+	}
+	h := c.GetHeader("If-Match")
+	if h == "" {
+		return 0, ErrRequired
+	}
+	return Parse(h)
+}
+
+// Parse reads a version from an If-Match value: 3, "3" and W/"3" are all
+// accepted since clients echo back the ETag we sent.
+func Parse(h string) (int32, error) {
+	h = strings.TrimPrefix(strings.TrimSpace(h), "W/")
+	h = strings.Trim(h, `"`)
+	v, err := strconv.ParseInt(h, 10, 32)
+	if err != nil || v < 1 {
+		return 0, ErrInvalid
+	}
+	return int32(v), nil
+}
+
+// SetETag exposes the version of the returned entity as its ETag.
+func SetETag(c *gin.Context, version int32) {
+	c.Header("ETag", `"`+strconv.Itoa(int(version))+`"`)
+}
+
+// Status maps the errors of Expected to HTTP status codes.
+func Status(err error) int {
+	switch {
+	case errors.Is(err, ErrRequired):
+		return http.StatusPreconditionRequired
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
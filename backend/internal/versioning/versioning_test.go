@@ -0,0 +1,48 @@
+// internal/versioning/versioning_test.go
+package versioning
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for _, h := range []string{`3`, `"3"`, `W/"3"`, ` "3" `} {
+		v, err := Parse(h)
+		assert.NoError(t, err, h)
+		assert.Equal(t, int32(3), v, h)
+	}
+	for _, h := range []string{`*`, `"abc"`, `"0"`, `"-1"`, `"99999999999"`} {
+		_, err := Parse(h)
+		assert.ErrorIs(t, err, ErrInvalid, h)
+	}
+}
+
+func TestExpected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := func(ifMatch string) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPut, "/", nil)
+		if ifMatch != "" {
+			c.Request.Header.Set("If-Match", ifMatch)
+		}
+		return c
+	}
+	five := int32(5)
+
+	v, err := Expected(ctx(`"4"`), &five)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), v, "body wins over If-Match")
+
+	v, err = Expected(ctx(`"4"`), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), v)
+
+	_, err = Expected(ctx(""), nil)
+	assert.ErrorIs(t, err, ErrRequired)
+	assert.Equal(t, http.StatusPreconditionRequired, Status(err))
+}
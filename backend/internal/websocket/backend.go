@@ -0,0 +1,28 @@
+package websocket
+
+import "context"
+
+// Backend carries broadcasts between API instances so clients connected to
+// different replicas see the same events. A hub without a backend only
+// reaches its own clients.
+type Backend interface {
+	// Publish hands a locally broadcast message to the other instances.
+	Publish(ctx context.Context, boardID int32, revision int64, message []byte) error
+	// PublishUser hands a message for the personal connections of a user to
+	// the other instances.
+	PublishUser(ctx context.Context, userID int32, message []byte) error
+	// PublishRevoke asks the other instances to close the connections of a
+	// user to a board, or of all its users when userID is 0, or every
+	// connection of the user when boardID is 0.
+	PublishRevoke(ctx context.Context, boardID, userID int32) error
+	// Subscribe delivers what other instances published to r until ctx is
+	// done. Messages published by this instance are not delivered back.
+	Subscribe(ctx context.Context, r Receiver)
+}
+
+// Receiver takes the messages a Backend relays from other instances.
+type Receiver struct {
+	Board  func(boardID int32, revision int64, message []byte)
+	User   func(userID int32, message []byte)
+	Revoke func(boardID, userID int32)
+}
@@ -8,18 +8,33 @@ import (
 )
 
 const (
-	writeWait      = 10 * time.Second
-	pongWait       = 60 * time.Second
-	pingPeriod     = (pongWait * 9) / 10
-	maxMessageSize = 512
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = (pongWait * 9) / 10
 )
 
+// MaxMessageSize bounds a message read from a client. It leaves room for a
+// command that inserts a whole card description (collab.MaxLength code
+// points) even when every one of them is escaped in the JSON.
+const MaxMessageSize = 1 << 20
+
 type Client struct {
 	hub     *Hub
 	conn    *websocket.Conn
 	send    chan []byte
 	boardID int32
 	userID  int32
+	name    string
+	// since is the last revision the client saw before reconnecting; events
+	// after it are replayed on register when replay is set.
+	since  int64
+	replay bool
+	// journaled is the newest revision of the board when the client
+	// connected, or -1 if it could not be read.
+	journaled int64
+	// personal is set for connections of the user channel, which are not
+	// tied to a board (boardID is 0) and accept no commands.
+	personal bool
 }
 
 func (c *Client) readPump() {
@@ -31,11 +46,12 @@ func (c *Client) readPump() {
 		c.hub.unregister <- c
 		c.conn.Close()
 	}()
-	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadLimit(MaxMessageSize)
 	c.conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetPongHandler(func(string) error { c.conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
 	for {
-		if _, _, err := c.conn.ReadMessage(); err != nil {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				logger.Warn("WebSocket unexpected close error",
 					"board_id", c.boardID,
@@ -43,8 +59,9 @@ func (c *Client) readPump() {
 					"error", err,
 				)
 			}
-			break // ignore incoming payload for now
+			break
 		}
+		c.handleCommand(data)
 	}
 }
 
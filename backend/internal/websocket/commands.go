@@ -0,0 +1,111 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"backend/internal/logger"
+)
+
+// commandTimeout bounds how long a single client command may run.
+const commandTimeout = 10 * time.Second
+
+var (
+	ErrMalformedCommand = errors.New("malformed command")
+	ErrUnknownCommand   = errors.New("unknown command")
+)
+
+// CommandFunc executes a client command for userID, who is connected to
+// boardID, and returns the result sent back in the ack. Broadcasts caused
+// by the command reach the client before the ack.
+type CommandFunc func(ctx context.Context, userID, boardID int32, data json.RawMessage) (interface{}, error)
+
+// Command is a client-to-server frame. ID is chosen by the client and
+// echoed in the reply so it can match replies to requests.
+type Command struct {
+	ID      string          `json:"id"`
+	Command string          `json:"command"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Ack is the data of an "ack" reply.
+type Ack struct {
+	ID     string      `json:"id"`
+	Result interface{} `json:"result"`
+}
+
+// CommandError is the data of an "error" reply.
+type CommandError struct {
+	ID      string      `json:"id"`
+	Error   string      `json:"error"`
+	Current interface{} `json:"current,omitempty"`
+}
+
+// StateError is returned by commands that failed because the client's copy
+// of an entity is stale. Current is sent along with the error.
+type StateError struct {
+	Err     error
+	Current interface{}
+}
+
+func (e *StateError) Error() string { return e.Err.Error() }
+func (e *StateError) Unwrap() error { return e.Err }
+
+type directMessage struct {
+	client  *Client
+	message []byte
+}
+
+// Handle registers fn for the command name. Register all commands before
+// the server starts accepting connections.
+func (h *Hub) Handle(name string, fn CommandFunc) {
+	h.commands[name] = fn
+}
+
+// reply sends a message to a single client through the hub goroutine, which
+// knows whether the client is still connected.
+func (h *Hub) reply(c *Client, event string, data interface{}) {
+	msg, err := json.Marshal(EventMessage{Event: event, Data: data})
+	if err != nil {
+		logger.Error("Failed to marshal WebSocket reply", "event", event, "error", err)
+		return
+	}
+	h.direct <- directMessage{client: c, message: msg}
+}
+
+// handleCommand runs one inbound frame. Commands of a client run one at a
+// time, in the order they were sent.
+func (c *Client) handleCommand(raw []byte) {
+	var cmd Command
+	if err := json.Unmarshal(raw, &cmd); err != nil || cmd.Command == "" {
+		c.hub.reply(c, "error", CommandError{ID: cmd.ID, Error: ErrMalformedCommand.Error()})
+		return
+	}
+	fn, ok := c.hub.commands[cmd.Command]
+	if !ok || c.personal {
+		c.hub.reply(c, "error", CommandError{ID: cmd.ID, Error: ErrUnknownCommand.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	result, err := fn(ctx, c.userID, c.boardID, cmd.Data)
+	if err != nil {
+		logger.Debug("WebSocket command failed",
+			"board_id", c.boardID,
+			"user_id", c.userID,
+			"command", cmd.Command,
+			"error", err,
+		)
+		reply := CommandError{ID: cmd.ID, Error: err.Error()}
+		var se *StateError
+		if errors.As(err, &se) {
+			reply.Current = se.Current
+		}
+		c.hub.reply(c, "error", reply)
+		return
+	}
+	c.hub.reply(c, "ack", Ack{ID: cmd.ID, Result: result})
+}
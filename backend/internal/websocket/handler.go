@@ -2,8 +2,11 @@ package websocket
 
 import (
 	"backend/internal/logger"
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	db "backend/internal/db/sqlc"
 
@@ -13,39 +16,69 @@ import (
 )
 
 var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool { return true }, // allow any origin; adjust in prod
+	CheckOrigin: checkOrigin,
 }
 
-// ServeBoardWS upgrades the HTTP request to WebSocket and registers the client to the hub.
-// Auth via JWT in `token` query param.
-func ServeBoardWS(c *gin.Context, hub *Hub, q *db.Queries, jwtSecret string) {
-	boardID64, err := strconv.ParseInt(c.Param("id"), 10, 32)
-	if err != nil {
-		logger.Warn("WebSocket connection failed: invalid board ID",
-			"board_id_param", c.Param("id"),
-			"remote_addr", c.ClientIP(),
-			"error", err,
-		)
-		c.AbortWithStatus(http.StatusBadRequest)
-		return
+// allowedOrigins are the origins browsers may connect from, set by
+// AllowOrigins.
+var allowedOrigins []string
+
+// AllowOrigins sets the origins, such as "https://app.example.com", that
+// browsers may open WebSocket and SSE connections from. "*" allows any
+// origin; with no origins only same-origin pages may connect. Call it before
+// the server starts accepting connections.
+func AllowOrigins(origins []string) {
+	allowedOrigins = origins
+}
+
+// checkOrigin accepts requests without an Origin header, same-origin
+// requests and the allowed origins.
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// Intentional: browsers always send Origin on WebSocket handshakes
+		// and cross-origin EventSource requests, so a request without one
+		// comes from a non-browser client (a CLI, a mobile app, another
+		// service). Those are not exposed to cross-site requests and still
+		// have to authenticate.
+		return true
 	}
-	boardID := int32(boardID64)
+	for _, o := range allowedOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
 
-	tokenStr := c.Query("token")
-	if tokenStr == "" {
-		// fallback to header if provided
-		tokenStr = c.GetHeader("Authorization")
-		if len(tokenStr) > 7 && tokenStr[:7] == "Bearer " {
-			tokenStr = tokenStr[7:]
+var (
+	errMissingToken   = errors.New("missing token")
+	errInvalidToken   = errors.New("invalid token")
+	errInvalidSubject = errors.New("invalid subject")
+	errInvalidTicket  = errors.New("invalid or expired ticket")
+)
+
+// authenticate returns the user of a connection to boardID, or to the
+// personal channel when boardID is 0. The user is identified by a ticket
+// (see IssueTicket) in the `ticket` query param, or else by the JWT in the
+// Authorization header. Browsers cannot set that header on a WebSocket, and
+// a JWT in the URL would end up in proxy logs, so they use tickets.
+func authenticate(c *gin.Context, q *db.Queries, jwtSecret string, boardID int32) (int32, error) {
+	if ticket := c.Query("ticket"); ticket != "" {
+		row, err := q.RedeemWsTicket(c.Request.Context(), hashTicket(ticket))
+		if err != nil || row.BoardID.Int32 != boardID {
+			return 0, errInvalidTicket
 		}
+		return row.UserID, nil
+	}
+
+	tokenStr := c.GetHeader("Authorization")
+	if len(tokenStr) > 7 && tokenStr[:7] == "Bearer " {
+		tokenStr = tokenStr[7:]
 	}
 	if tokenStr == "" {
-		logger.Warn("WebSocket connection failed: missing token",
-			"board_id", boardID,
-			"remote_addr", c.ClientIP(),
-		)
-		c.AbortWithStatus(http.StatusUnauthorized)
-		return
+		return 0, errMissingToken
 	}
 	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -54,61 +87,121 @@ func ServeBoardWS(c *gin.Context, hub *Hub, q *db.Queries, jwtSecret string) {
 		return []byte(jwtSecret), nil
 	})
 	if err != nil || !token.Valid {
-		logger.Warn("WebSocket connection failed: invalid token",
-			"board_id", boardID,
-			"remote_addr", c.ClientIP(),
-			"error", err,
-		)
-		c.AbortWithStatus(http.StatusUnauthorized)
-		return
+		return 0, errInvalidToken
 	}
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		logger.Warn("WebSocket connection failed: invalid claims",
-			"board_id", boardID,
-			"remote_addr", c.ClientIP(),
-		)
-		c.AbortWithStatus(http.StatusUnauthorized)
-		return
+		return 0, errInvalidToken
 	}
 	sub, ok := claims["sub"].(string)
 	if !ok {
-		logger.Warn("WebSocket connection failed: invalid subject",
-			"board_id", boardID,
+		return 0, errInvalidSubject
+	}
+	uid64, err := strconv.ParseInt(sub, 10, 32)
+	if err != nil {
+		return 0, errInvalidSubject
+	}
+	return int32(uid64), nil
+}
+
+// boardClient authenticates a request for the events of the board in the
+// `id` path param and returns an unconnected client for it. since is the
+// last revision the client saw, or "" for none. On failure it aborts the
+// request and returns nil.
+func boardClient(c *gin.Context, hub *Hub, q *db.Queries, jwtSecret, transport, since string) *Client {
+	boardID64, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Warn(transport+" connection failed: invalid board ID",
+			"board_id_param", c.Param("id"),
 			"remote_addr", c.ClientIP(),
+			"error", err,
 		)
-		c.AbortWithStatus(http.StatusUnauthorized)
-		return
+		c.AbortWithStatus(http.StatusBadRequest)
+		return nil
 	}
-	uid64, err := strconv.ParseInt(sub, 10, 32)
+	boardID := int32(boardID64)
+
+	client := &Client{hub: hub, send: make(chan []byte, 256), boardID: boardID, replay: since != ""}
+	if client.replay {
+		client.since, err = strconv.ParseInt(since, 10, 64)
+		if err != nil || client.since < 0 {
+			logger.Warn(transport+" connection failed: invalid since",
+				"board_id", boardID,
+				"remote_addr", c.ClientIP(),
+			)
+			c.AbortWithStatus(http.StatusBadRequest)
+			return nil
+		}
+	}
+
+	userID, err := authenticate(c, q, jwtSecret, boardID)
 	if err != nil {
-		logger.Warn("WebSocket connection failed: invalid subject format",
+		logger.Warn(transport+" connection failed: "+err.Error(),
 			"board_id", boardID,
 			"remote_addr", c.ClientIP(),
-			"error", err,
 		)
 		c.AbortWithStatus(http.StatusUnauthorized)
-		return
+		return nil
+	}
+	client.userID = userID
+
+	// deleted boards cannot be watched
+	if b, err := q.GetBoardByID(c.Request.Context(), boardID); err != nil || b.ArchivedAt.Valid {
+		logger.Warn(transport+" connection failed: board not found",
+			"board_id", boardID,
+			"user_id", userID,
+			"remote_addr", c.ClientIP(),
+		)
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil
 	}
-	userID := int32(uid64)
 
 	// ensure user is member of board
 	if _, err := q.GetBoardMember(c.Request.Context(), db.GetBoardMemberParams{BoardID: boardID, UserID: userID}); err != nil {
-		logger.Warn("WebSocket connection failed: user not member of board",
+		logger.Warn(transport+" connection failed: user not member of board",
 			"board_id", boardID,
 			"user_id", userID,
 			"remote_addr", c.ClientIP(),
 			"error", err,
 		)
 		c.AbortWithStatus(http.StatusForbidden)
+		return nil
+	}
+
+	if client.replay {
+		client.journaled, err = hub.revision(c.Request.Context(), boardID)
+		if err != nil {
+			// Without it an empty replay buffer cannot tell whether the
+			// client missed anything, so it is told to resync.
+			logger.Warn(transport+" connection: failed to read board revision",
+				"board_id", boardID,
+				"error", err,
+			)
+			client.journaled = -1
+		}
+	}
+
+	// The name is only used for presence, so a failed lookup is not fatal.
+	if u, err := q.GetUserByID(c.Request.Context(), userID); err == nil {
+		client.name = u.Name
+	}
+	return client
+}
+
+// ServeBoardWS upgrades the HTTP request to WebSocket and registers the client to the hub.
+// Auth via a ticket in the `ticket` query param or a JWT in the Authorization header. A reconnecting client passes the last
+// revision it saw as `since` to get the missed events first.
+func ServeBoardWS(c *gin.Context, hub *Hub, q *db.Queries, jwtSecret string) {
+	client := boardClient(c, hub, q, jwtSecret, "WebSocket", c.Query("since"))
+	if client == nil {
 		return
 	}
 
 	ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		logger.Error("WebSocket upgrade failed",
-			"board_id", boardID,
-			"user_id", userID,
+			"board_id", client.boardID,
+			"user_id", client.userID,
 			"remote_addr", c.ClientIP(),
 			"error", err,
 		)
@@ -116,12 +209,48 @@ func ServeBoardWS(c *gin.Context, hub *Hub, q *db.Queries, jwtSecret string) {
 	}
 
 	logger.Info("WebSocket connection established",
-		"board_id", boardID,
+		"board_id", client.boardID,
+		"user_id", client.userID,
+		"remote_addr", c.ClientIP(),
+	)
+
+	client.conn = ws
+	hub.register <- client
+
+	go client.writePump()
+	go client.readPump()
+}
+
+// ServeUserWS upgrades the HTTP request to the personal WebSocket of the
+// authenticated user, which receives events about the user rather than a
+// board: invitations, removals, assignments, mentions and deleted boards.
+// Auth works as for ServeBoardWS.
+func ServeUserWS(c *gin.Context, hub *Hub, q *db.Queries, jwtSecret string) {
+	userID, err := authenticate(c, q, jwtSecret, 0)
+	if err != nil {
+		logger.Warn("WebSocket connection failed: "+err.Error(),
+			"remote_addr", c.ClientIP(),
+		)
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logger.Error("WebSocket upgrade failed",
+			"user_id", userID,
+			"remote_addr", c.ClientIP(),
+			"error", err,
+		)
+		return
+	}
+
+	logger.Info("Personal WebSocket connection established",
 		"user_id", userID,
 		"remote_addr", c.ClientIP(),
 	)
 
-	client := &Client{hub: hub, conn: ws, send: make(chan []byte, 256), boardID: boardID, userID: userID}
+	client := &Client{hub: hub, conn: ws, send: make(chan []byte, 256), userID: userID, personal: true}
 	hub.register <- client
 
 	go client.writePump()
@@ -2,47 +2,117 @@ package websocket
 
 import (
 	"backend/internal/logger"
+	"context"
 	"encoding/json"
 	"sync"
+	"time"
 )
 
+// Journal numbers board events with a per-board revision and keeps them so
+// clients can fetch what they missed.
+type Journal interface {
+	Append(ctx context.Context, boardID int32, event string, data []byte) (int64, error)
+	// Revision returns the newest revision of the board, 0 if it has none.
+	Revision(ctx context.Context, boardID int32) (int64, error)
+}
+
 // Hub maintains active connections grouped by boardID.
 // Use Broadcast(boardID, msg) to push an event to all subscribers of the board.
 // Register clients via hub.register channel (called from ServeBoardWS).
+// Personal connections (ServeUserWS) are grouped by userID instead and
+// receive events sent with NotifyUser.
 type Hub struct {
 	mu         sync.RWMutex
 	rooms      map[int32]map[*Client]bool // boardID → set of clients
+	users      map[int32]map[*Client]bool // userID → set of personal clients
 	register   chan *Client
 	unregister chan *Client
 	broadcast  chan broadcastRequest
+	personal   chan userMessage
+	revoke     chan revokeRequest
+	direct     chan directMessage
+	commands   map[string]CommandFunc
+	onLeave    []func(boardID, userID int32)
+
+	journal Journal
+	backend Backend
+	// order serializes journaling and queueing per board (striped by board
+	// ID) so clients receive events in revision order.
+	order [64]sync.Mutex
+	// seq numbers events per board when there is no journal.
+	seqMu sync.Mutex
+	seq   map[int32]int64
+	// history holds the recent events of each board for replay; guarded by mu.
+	history map[int32]*ring
+	// reorder puts journaled events back in revision order; nil without a
+	// journal, when revisions are not shared between instances.
+	reorder *reorderBuffer
 }
 
 type broadcastRequest struct {
-	boardID int32
-	message []byte
+	boardID  int32
+	revision int64
+	message  []byte
 }
 
-func NewHub() *Hub {
-	return &Hub{
+// NewHub creates a hub. Without a journal events are numbered by an
+// in-memory counter; without a backend they only reach local clients.
+// Both may be nil.
+func NewHub(journal Journal, backend Backend) *Hub {
+	h := &Hub{
 		rooms:      make(map[int32]map[*Client]bool),
+		users:      make(map[int32]map[*Client]bool),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		broadcast:  make(chan broadcastRequest),
+		personal:   make(chan userMessage),
+		revoke:     make(chan revokeRequest),
+		direct:     make(chan directMessage),
+		commands:   make(map[string]CommandFunc),
+		journal:    journal,
+		backend:    backend,
+		seq:        make(map[int32]int64),
+		history:    make(map[int32]*ring),
 	}
+	if journal != nil {
+		h.reorder = newReorderBuffer(func(b broadcastRequest) { h.broadcast <- b })
+	}
+	return h
 }
 
 // Run launches the hub’s event loop (call in goroutine).
 func (h *Hub) Run() {
 	logger.Info("WebSocket hub started")
+	if h.backend != nil {
+		go h.backend.Subscribe(context.Background(), Receiver{
+			Board: h.receive, User: h.receiveUser, Revoke: h.receiveRevoke,
+		})
+	}
 	for {
 		select {
 		case c := <-h.register:
+			if c.personal {
+				h.registerPersonal(c)
+				continue
+			}
 			h.mu.Lock()
+			// Runs on the hub goroutine, so no live event can slip in
+			// between the replayed ones.
+			if c.replay && !h.replay(c) {
+				close(c.send)
+				h.mu.Unlock()
+				continue
+			}
 			if h.rooms[c.boardID] == nil {
 				h.rooms[c.boardID] = make(map[*Client]bool)
 			}
+			firstTab := !hasUser(h.rooms[c.boardID], c.userID)
 			h.rooms[c.boardID][c] = true
 			clientCount := len(h.rooms[c.boardID])
+			h.sendTo(c, "presence_snapshot", h.viewersLocked(c.boardID))
+			if firstTab {
+				h.notifyPresence(c.boardID, "presence_joined", c.viewer(), c)
+			}
 			h.mu.Unlock()
 
 			logger.Debug("WebSocket client registered",
@@ -52,12 +122,17 @@ func (h *Hub) Run() {
 			)
 
 		case c := <-h.unregister:
+			if c.personal {
+				h.unregisterPersonal(c)
+				continue
+			}
 			h.mu.Lock()
 			if clients, ok := h.rooms[c.boardID]; ok {
 				if _, ok := clients[c]; ok {
 					delete(clients, c)
 					close(c.send)
 					clientCount := len(clients)
+					h.leftIfLastTab(c)
 					if clientCount == 0 {
 						delete(h.rooms, c.boardID)
 					}
@@ -75,11 +150,38 @@ func (h *Hub) Run() {
 				h.mu.Unlock()
 			}
 
+		case d := <-h.direct:
+			h.mu.Lock()
+			if h.connected(d.client) {
+				select {
+				case d.client.send <- d.message:
+				default:
+					logger.Warn("WebSocket reply dropped due to full buffer",
+						"board_id", d.client.boardID,
+						"user_id", d.client.userID,
+					)
+				}
+			}
+			h.mu.Unlock()
+
+		case m := <-h.personal:
+			h.deliverUser(m)
+
+		case r := <-h.revoke:
+			h.closeRevoked(r)
+
 		case b := <-h.broadcast:
-			h.mu.RLock()
+			h.mu.Lock()
+			if b.revision > 0 {
+				if h.history[b.boardID] == nil {
+					h.history[b.boardID] = &ring{}
+				}
+				h.history[b.boardID].push(b.revision, b.message)
+			}
 			if clients, ok := h.rooms[b.boardID]; ok {
 				clientCount := len(clients)
 				successCount := 0
+				var dropped []*Client
 				for c := range clients {
 					select {
 					case c.send <- b.message:
@@ -88,36 +190,196 @@ func (h *Hub) Run() {
 						// client buffer full; disconnect
 						close(c.send)
 						delete(clients, c)
+						dropped = append(dropped, c)
 						logger.Warn("WebSocket client disconnected due to full buffer",
 							"board_id", c.boardID,
 							"user_id", c.userID,
 						)
 					}
 				}
+				for _, c := range dropped {
+					h.leftIfLastTab(c)
+				}
 				logger.Debug("WebSocket message broadcasted",
 					"board_id", b.boardID,
 					"total_clients", clientCount,
 					"successful_sends", successCount,
 				)
 			}
-			h.mu.RUnlock()
+			h.mu.Unlock()
 		}
 	}
 }
 
 // Broadcast encodes msg to JSON and sends to all clients of boardID.
+// The event gets the next board revision: from the journal if there is one,
+// otherwise from an in-memory counter.
 func (h *Hub) Broadcast(boardID int32, msg EventMessage) {
-	if data, err := json.Marshal(msg); err == nil {
-		h.broadcast <- broadcastRequest{boardID: boardID, message: data}
-		logger.Debug("WebSocket broadcast queued",
+	data, ok := h.enqueue(boardID, &msg)
+	if !ok {
+		return
+	}
+	// Relayed after the board's lock is released: receivers put events back
+	// in revision order (see reorderBuffer), so a slow relay only delays
+	// this caller.
+	if h.backend != nil {
+		h.publish(boardID, msg, data)
+	}
+	logger.Debug("WebSocket broadcast queued",
+		"board_id", boardID,
+		"event", msg.Event,
+	)
+}
+
+// enqueue numbers msg and queues it for local clients. Both happen under the
+// board's lock so local clients receive events in revision order.
+func (h *Hub) enqueue(boardID int32, msg *EventMessage) ([]byte, bool) {
+	mu := &h.order[uint32(boardID)%uint32(len(h.order))]
+	mu.Lock()
+	defer mu.Unlock()
+	if h.journal != nil {
+		msg.Revision = h.record(boardID, *msg)
+	} else {
+		msg.Revision = h.nextSeq(boardID)
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		logger.Error("Failed to marshal WebSocket message",
 			"board_id", boardID,
 			"event", msg.Event,
+			"error", err,
 		)
-	} else {
+		return nil, false
+	}
+	h.queue(broadcastRequest{boardID: boardID, revision: msg.Revision, message: data})
+	return data, true
+}
+
+// Notify sends a transient event to the clients of a board on every
+// instance. Unlike Broadcast it gets no revision and is neither journaled
+// nor replayed, which suits state that only matters right now.
+func (h *Hub) Notify(boardID int32, msg EventMessage) {
+	data, err := json.Marshal(msg)
+	if err != nil {
 		logger.Error("Failed to marshal WebSocket message",
 			"board_id", boardID,
 			"event", msg.Event,
 			"error", err,
 		)
+		return
+	}
+	h.broadcast <- broadcastRequest{boardID: boardID, message: data}
+	if h.backend != nil {
+		h.publish(boardID, msg, data)
+	}
+}
+
+// record appends msg to the journal and returns its revision, or 0 if it
+// could not be recorded. The event is still delivered live in that case.
+func (h *Hub) record(boardID int32, msg EventMessage) int64 {
+	data, err := json.Marshal(msg.Data)
+	if err != nil {
+		return 0
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	rev, err := h.journal.Append(ctx, boardID, msg.Event, data)
+	if err != nil {
+		logger.Error("Failed to record board event",
+			"board_id", boardID,
+			"event", msg.Event,
+			"error", err,
+		)
+		return 0
+	}
+	return rev
+}
+
+func (h *Hub) nextSeq(boardID int32) int64 {
+	h.seqMu.Lock()
+	defer h.seqMu.Unlock()
+	h.seq[boardID]++
+	return h.seq[boardID]
+}
+
+// replay queues the events a reconnecting client missed, or a
+// resync_required event if they are no longer buffered. It returns false if
+// the client's buffer filled up, in which case the client must be dropped.
+// Caller holds mu.
+func (h *Hub) replay(c *Client) bool {
+	r := h.history[c.boardID]
+	if r == nil {
+		r = &ring{}
+	}
+	missed, ok := r.since(c.since, c.journaled)
+	if !ok {
+		data, _ := json.Marshal(EventMessage{
+			Event: "resync_required",
+			Data:  map[string]int64{"since": c.since, "revision": max(r.last(), c.journaled)},
+		})
+		missed = [][]byte{data}
+		logger.Debug("WebSocket replay not possible, resync required",
+			"board_id", c.boardID,
+			"user_id", c.userID,
+			"since", c.since,
+		)
+	}
+	for _, data := range missed {
+		select {
+		case c.send <- data:
+		default:
+			logger.Warn("WebSocket client disconnected due to full buffer during replay",
+				"board_id", c.boardID,
+				"user_id", c.userID,
+			)
+			return false
+		}
+	}
+	if ok {
+		logger.Debug("WebSocket events replayed",
+			"board_id", c.boardID,
+			"user_id", c.userID,
+			"since", c.since,
+			"count", len(missed),
+		)
+	}
+	return true
+}
+
+// revision returns the newest revision given to an event of the board.
+func (h *Hub) revision(ctx context.Context, boardID int32) (int64, error) {
+	if h.journal != nil {
+		return h.journal.Revision(ctx, boardID)
+	}
+	h.seqMu.Lock()
+	defer h.seqMu.Unlock()
+	return h.seq[boardID], nil
+}
+
+// publish relays a local broadcast to the other instances.
+func (h *Hub) publish(boardID int32, msg EventMessage, data []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := h.backend.Publish(ctx, boardID, msg.Revision, data); err != nil {
+		logger.Error("Failed to relay WebSocket message",
+			"board_id", boardID,
+			"event", msg.Event,
+			"error", err,
+		)
+	}
+}
+
+// receive delivers a message relayed from another instance to local clients.
+func (h *Hub) receive(boardID int32, revision int64, message []byte) {
+	h.queue(broadcastRequest{boardID: boardID, revision: revision, message: message})
+}
+
+// queue hands an event to the event loop, in revision order if it has a
+// journaled revision.
+func (h *Hub) queue(b broadcastRequest) {
+	if h.reorder == nil || b.revision == 0 {
+		h.broadcast <- b
+		return
 	}
+	h.reorder.add(b)
 }
@@ -2,15 +2,40 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// recv returns the next non-presence message queued for c, or false after
+// the timeout. Presence events are tested separately.
+func recv(c *Client, timeout time.Duration) ([]byte, bool) {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case raw := <-c.send:
+			var m EventMessage
+			if json.Unmarshal(raw, &m) == nil && strings.HasPrefix(m.Event, "presence_") {
+				continue
+			}
+			return raw, true
+		case <-deadline:
+			return nil, false
+		}
+	}
+}
+
 func TestHub_Broadcast(t *testing.T) {
-	h := NewHub()
+	h := NewHub(nil, nil)
 	go h.Run()
 	defer func() {
 		// allow hub goroutine to exit cleanly
@@ -18,9 +43,9 @@ func TestHub_Broadcast(t *testing.T) {
 	}()
 
 	boardID := int32(1)
-	c1 := &Client{hub: h, send: make(chan []byte, 1), boardID: boardID}
-	c2 := &Client{hub: h, send: make(chan []byte, 1), boardID: boardID}
-	c3 := &Client{hub: h, send: make(chan []byte, 1), boardID: 2}
+	c1 := &Client{hub: h, send: make(chan []byte, 4), boardID: boardID}
+	c2 := &Client{hub: h, send: make(chan []byte, 4), boardID: boardID}
+	c3 := &Client{hub: h, send: make(chan []byte, 4), boardID: 2}
 
 	h.register <- c1
 	h.register <- c2
@@ -31,25 +56,488 @@ func TestHub_Broadcast(t *testing.T) {
 	h.Broadcast(boardID, msg)
 
 	expect := EventMessage{}
-	select {
-	case raw := <-c1.send:
-		_ = json.Unmarshal(raw, &expect)
-	case <-time.After(time.Second):
+	raw, ok := recv(c1, time.Second)
+	if !ok {
 		t.Fatal("timeout waiting for message on client1")
 	}
+	_ = json.Unmarshal(raw, &expect)
 	assert.Equal(t, msg.Event, expect.Event)
 	assert.Equal(t, msg.Data, expect.Data)
 
+	if _, ok := recv(c2, time.Second); !ok {
+		t.Fatal("client2 did not receive broadcast")
+	}
+
+	if _, ok := recv(c3, 50*time.Millisecond); ok {
+		t.Fatal("client3 should not receive message for different board")
+	}
+}
+
+type fakeJournal struct {
+	rev    int64
+	events []string
+}
+
+func (j *fakeJournal) Append(_ context.Context, _ int32, event string, _ []byte) (int64, error) {
+	j.rev++
+	j.events = append(j.events, event)
+	return j.rev, nil
+}
+
+func (j *fakeJournal) Revision(context.Context, int32) (int64, error) {
+	return j.rev, nil
+}
+
+func TestHub_BroadcastRevision(t *testing.T) {
+	j := &fakeJournal{}
+	h := NewHub(j, nil)
+	go h.Run()
+
+	c := &Client{hub: h, send: make(chan []byte, 4), boardID: 1}
+	h.register <- c
+
+	h.Broadcast(1, EventMessage{Event: "card_created", Data: map[string]int{"id": 1}})
+	h.Broadcast(1, EventMessage{Event: "card_moved", Data: map[string]int{"id": 1}})
+
+	for want := int64(1); want <= 2; want++ {
+		raw, ok := recv(c, time.Second)
+		if !ok {
+			t.Fatal("timeout waiting for message")
+		}
+		var got EventMessage
+		_ = json.Unmarshal(raw, &got)
+		assert.Equal(t, want, got.Revision)
+	}
+	assert.Equal(t, []string{"card_created", "card_moved"}, j.events)
+}
+
+func TestHub_ReplayOnReconnect(t *testing.T) {
+	h := NewHub(nil, nil)
+	go h.Run()
+
+	live := &Client{hub: h, send: make(chan []byte, 8), boardID: 1}
+	h.register <- live
+	for i := 0; i < 3; i++ {
+		h.Broadcast(1, EventMessage{Event: "card_updated", Data: i})
+	}
+	for i := 0; i < 3; i++ {
+		recv(live, time.Second)
+	}
+
+	// A client that saw revision 1 gets 2 and 3 replayed in order.
+	back := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, since: 1, replay: true}
+	h.register <- back
+	for want := int64(2); want <= 3; want++ {
+		var got EventMessage
+		raw, _ := recv(back, time.Second)
+		_ = json.Unmarshal(raw, &got)
+		assert.Equal(t, want, got.Revision)
+	}
+
+	// A client ahead of the buffer is told to resync.
+	lost := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, since: 10, replay: true}
+	h.register <- lost
+	var got EventMessage
+	raw, _ := recv(lost, time.Second)
+	_ = json.Unmarshal(raw, &got)
+	assert.Equal(t, "resync_required", got.Event)
+
+	// A client whose buffer cannot take the backlog is dropped, not waited for.
+	full := &Client{hub: h, send: make(chan []byte, 1), boardID: 1, since: 0, replay: true}
+	h.register <- full
+	for range full.send {
+	}
+}
+
+func TestHub_ReplayEmptyBuffer(t *testing.T) {
+	// After a restart the buffer is empty but the journal is at revision 5.
+	h := NewHub(&fakeJournal{rev: 5}, nil)
+	go h.Run()
+
+	upToDate := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, since: 5, replay: true, journaled: 5}
+	h.register <- upToDate
+	if raw, ok := recv(upToDate, 50*time.Millisecond); ok {
+		t.Fatalf("up-to-date client got %s", raw)
+	}
+
+	behind := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, since: 3, replay: true, journaled: 5}
+	h.register <- behind
+	var got EventMessage
+	raw, _ := recv(behind, time.Second)
+	_ = json.Unmarshal(raw, &got)
+	assert.Equal(t, "resync_required", got.Event)
+}
+
+func TestHub_FanOut(t *testing.T) {
+	type relayed struct {
+		boardID  int32
+		revision int64
+		message  []byte
+	}
+	var received []relayed
+	local := NewHub(&fakeJournal{}, backendFunc(func(boardID int32, revision int64, message []byte) {
+		received = append(received, relayed{boardID, revision, message})
+	}))
+	go local.Run()
+
+	c := &Client{hub: local, send: make(chan []byte, 4), boardID: 1}
+	local.register <- c
+	local.Broadcast(1, EventMessage{Event: "card_created", Data: 1})
+	recv(c, time.Second)
+
+	// The local broadcast went out to the backend once.
+	assert.Len(t, received, 1)
+	assert.Equal(t, int64(1), received[0].revision)
+
+	// A message from another instance reaches local clients and the replay buffer.
+	local.receive(1, 2, []byte(`{"event":"card_moved","data":1,"revision":2}`))
+	raw, ok := recv(c, time.Second)
+	if !ok {
+		t.Fatal("relayed message was not delivered")
+	}
+	assert.Contains(t, string(raw), "card_moved")
+}
+
+func TestHub_FanOutInterleaved(t *testing.T) {
+	// Two instances number the events of a board with the same journal
+	j := &fakeJournal{}
+	var relayed [][]byte
+	a := NewHub(j, backendFunc(func(_ int32, _ int64, message []byte) {
+		relayed = append(relayed, message)
+	}))
+	b := NewHub(j, nil)
+	go a.Run()
+	go b.Run()
+
+	c := &Client{hub: b, send: make(chan []byte, 8), boardID: 1}
+	b.register <- c
+
+	a.Broadcast(1, EventMessage{Event: "card_created", Data: 1})
+	b.receive(1, 1, relayed[0])
+	a.Broadcast(1, EventMessage{Event: "card_moved", Data: 1})
+	b.Broadcast(1, EventMessage{Event: "card_updated", Data: 1})
+	a.Broadcast(1, EventMessage{Event: "card_deleted", Data: 1})
+
+	// The relays of 2 and 4 overtake each other and b's own 3
+	b.receive(1, 4, relayed[2])
+	b.receive(1, 2, relayed[1])
+
+	for want := int64(1); want <= 4; want++ {
+		raw, ok := recv(c, time.Second)
+		require.True(t, ok, "revision %d not delivered", want)
+		var got EventMessage
+		require.NoError(t, json.Unmarshal(raw, &got))
+		assert.Equal(t, want, got.Revision)
+	}
+}
+
+func TestHub_SlowRelay(t *testing.T) {
+	release := make(chan struct{})
+	h := NewHub(nil, backendFunc(func(int32, int64, []byte) { <-release }))
+	go h.Run()
+	defer close(release)
+
+	c := &Client{hub: h, send: make(chan []byte, 4), boardID: 1}
+	h.register <- c
+
+	// A relay stuck on the first event does not hold up the next one.
+	go h.Broadcast(1, EventMessage{Event: "card_created", Data: 1})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		h.Broadcast(1, EventMessage{Event: "card_moved", Data: 1})
+	}()
+	for i := 0; i < 2; i++ {
+		if _, ok := recv(c, time.Second); !ok {
+			t.Fatal("local delivery waited for the relay")
+		}
+	}
+}
+
+// backendFunc is a Backend that records publishes and never delivers.
+type backendFunc func(boardID int32, revision int64, message []byte)
+
+func (f backendFunc) Publish(_ context.Context, boardID int32, revision int64, message []byte) error {
+	f(boardID, revision, message)
+	return nil
+}
+
+func (f backendFunc) PublishUser(context.Context, int32, []byte) error {
+	return nil
+}
+
+func (f backendFunc) PublishRevoke(context.Context, int32, int32) error {
+	return nil
+}
+
+func (f backendFunc) Subscribe(ctx context.Context, _ Receiver) {
+	<-ctx.Done()
+}
+
+func TestHub_Presence(t *testing.T) {
+	h := NewHub(nil, nil)
+	go h.Run()
+
+	next := func(c *Client) EventMessage {
+		var m EventMessage
+		select {
+		case raw := <-c.send:
+			_ = json.Unmarshal(raw, &m)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for presence event")
+		}
+		return m
+	}
+
+	alice := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, userID: 1, name: "Alice"}
+	h.register <- alice
+	assert.Equal(t, "presence_snapshot", next(alice).Event)
+
+	bob := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, userID: 2, name: "Bob"}
+	h.register <- bob
+	snap := next(bob)
+	assert.Equal(t, "presence_snapshot", snap.Event)
+	assert.Len(t, snap.Data, 2)
+	assert.Equal(t, "presence_joined", next(alice).Event)
+
+	// A second tab of Bob is not announced again and is listed once.
+	bob2 := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, userID: 2, name: "Bob"}
+	h.register <- bob2
+	next(bob2)
+	assert.Equal(t, []Viewer{{UserID: 1, Name: "Alice"}, {UserID: 2, Name: "Bob"}}, h.Viewers(1))
+
+	// Closing one of Bob's tabs keeps him present; closing the last one does not.
+	h.unregister <- bob
+	h.unregister <- bob2
+	left := next(alice)
+	assert.Equal(t, "presence_left", left.Event)
+	assert.Equal(t, map[string]interface{}{"userId": float64(2), "name": "Bob"}, left.Data)
+	assert.Equal(t, []Viewer{{UserID: 1, Name: "Alice"}}, h.Viewers(1))
+}
+
+func TestHub_Commands(t *testing.T) {
+	h := NewHub(nil, nil)
+	h.Handle("echo", func(_ context.Context, userID, boardID int32, data json.RawMessage) (interface{}, error) {
+		h.Broadcast(boardID, EventMessage{Event: "echoed", Data: userID})
+		return data, nil
+	})
+	h.Handle("fail", func(context.Context, int32, int32, json.RawMessage) (interface{}, error) {
+		return nil, assert.AnError
+	})
+	go h.Run()
+
+	c := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, userID: 5}
+	h.register <- c
+
+	next := func() EventMessage {
+		raw, ok := recv(c, time.Second)
+		if !ok {
+			t.Fatal("timeout waiting for reply")
+		}
+		var m EventMessage
+		_ = json.Unmarshal(raw, &m)
+		return m
+	}
+
+	c.handleCommand([]byte(`{"id":"1","command":"echo","data":{"x":1}}`))
+	assert.Equal(t, "echoed", next().Event, "broadcast comes before the ack")
+	ack := next()
+	assert.Equal(t, "ack", ack.Event)
+	assert.Equal(t, map[string]interface{}{"id": "1", "result": map[string]interface{}{"x": float64(1)}}, ack.Data)
+
+	c.handleCommand([]byte(`{"id":"2","command":"fail"}`))
+	assert.Equal(t, map[string]interface{}{"id": "2", "error": assert.AnError.Error()}, next().Data)
+
+	c.handleCommand([]byte(`{"id":"3","command":"nope"}`))
+	assert.Equal(t, map[string]interface{}{"id": "3", "error": ErrUnknownCommand.Error()}, next().Data)
+
+	c.handleCommand([]byte(`not json`))
+	assert.Equal(t, "error", next().Event)
+}
+
+func TestHub_OnLeaveAndNotify(t *testing.T) {
+	h := NewHub(nil, nil)
+	left := make(chan [2]int32, 1)
+	h.OnLeave(func(boardID, userID int32) { left <- [2]int32{boardID, userID} })
+	go h.Run()
+
+	a := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, userID: 1}
+	b := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, userID: 2}
+	h.register <- a
+	h.register <- b
+
+	// Notify reaches clients without a revision.
+	h.Notify(1, EventMessage{Event: "card_typing", Data: 1})
+	raw, ok := recv(a, time.Second)
+	if !ok {
+		t.Fatal("timeout waiting for notification")
+	}
+	var got EventMessage
+	_ = json.Unmarshal(raw, &got)
+	assert.Equal(t, "card_typing", got.Event)
+	assert.Zero(t, got.Revision)
+
+	h.unregister <- b
 	select {
-	case <-c2.send:
-		// ok
+	case ids := <-left:
+		assert.Equal(t, [2]int32{1, 2}, ids)
 	case <-time.After(time.Second):
-		t.Fatal("client2 did not receive broadcast")
+		t.Fatal("leave hook was not called")
 	}
+}
+
+func TestHub_NotifyUser(t *testing.T) {
+	h := NewHub(nil, nil)
+	go h.Run()
 
+	mine := &Client{hub: h, send: make(chan []byte, 4), userID: 1, personal: true}
+	other := &Client{hub: h, send: make(chan []byte, 4), userID: 2, personal: true}
+	board := &Client{hub: h, send: make(chan []byte, 4), boardID: 1, userID: 1}
+	h.register <- mine
+	h.register <- other
+	h.register <- board
+
+	h.NotifyUser(1, EventMessage{Event: "board_invited", Data: 1})
+	raw, ok := recv(mine, time.Second)
+	if !ok {
+		t.Fatal("personal client did not receive the event")
+	}
+	assert.Contains(t, string(raw), "board_invited")
+	if _, ok := recv(other, 50*time.Millisecond); ok {
+		t.Fatal("event reached another user")
+	}
+	if _, ok := recv(board, 50*time.Millisecond); ok {
+		t.Fatal("event reached a board connection")
+	}
+
+	// Board broadcasts do not reach personal connections.
+	h.Broadcast(1, EventMessage{Event: "card_created", Data: 1})
+	if _, ok := recv(mine, 50*time.Millisecond); ok {
+		t.Fatal("board event reached a personal connection")
+	}
+
+	// Personal connections run no commands.
+	mine.handleCommand([]byte(`{"id":"1","command":"card.move"}`))
+	raw, ok = recv(mine, time.Second)
+	if !ok {
+		t.Fatal("timeout waiting for command error")
+	}
+	assert.Contains(t, string(raw), ErrUnknownCommand.Error())
+}
+
+func TestHub_Revoke(t *testing.T) {
+	h := NewHub(nil, nil)
+	left := make(chan [2]int32, 2)
+	h.OnLeave(func(boardID, userID int32) { left <- [2]int32{boardID, userID} })
+	go h.Run()
+
+	gone := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, userID: 1}
+	stays := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, userID: 2}
+	h.register <- gone
+	h.register <- stays
+
+	h.Revoke(1, 1)
+	var last []byte
+	for raw := range gone.send {
+		last = raw
+	}
+	assert.Contains(t, string(last), "access_revoked")
 	select {
-	case <-c3.send:
-		t.Fatal("client3 should not receive message for different board")
-	case <-time.After(50 * time.Millisecond):
+	case ids := <-left:
+		assert.Equal(t, [2]int32{1, 1}, ids)
+	case <-time.After(time.Second):
+		t.Fatal("leave hook was not called")
+	}
+
+	// The other member still gets events; revoking the whole board closes it.
+	h.Broadcast(1, EventMessage{Event: "card_created", Data: 1})
+	if _, ok := recv(stays, time.Second); !ok {
+		t.Fatal("remaining client lost its connection")
+	}
+	h.Revoke(1, 0)
+	for range stays.send {
+	}
+	assert.Empty(t, h.Viewers(1))
+}
+
+func TestHub_RevokeUser(t *testing.T) {
+	h := NewHub(nil, nil)
+	go h.Run()
+
+	board1 := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, userID: 1}
+	board2 := &Client{hub: h, send: make(chan []byte, 8), boardID: 2, userID: 1}
+	personal := &Client{hub: h, send: make(chan []byte, 8), userID: 1, personal: true}
+	other := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, userID: 2}
+	for _, c := range []*Client{board1, board2, personal, other} {
+		h.register <- c
 	}
+
+	h.RevokeUser(1)
+	for _, c := range []*Client{board1, board2, personal} {
+		var last []byte
+		for raw := range c.send {
+			last = raw
+		}
+		assert.Contains(t, string(last), "session_revoked")
+	}
+
+	// Other users keep their connections
+	h.Broadcast(1, EventMessage{Event: "card_created", Data: 1})
+	raw, ok := recv(other, time.Second)
+	require.True(t, ok, "other user lost the connection")
+	assert.Contains(t, string(raw), "card_created")
+	assert.Len(t, h.Viewers(1), 1)
+}
+
+func TestCheckOrigin(t *testing.T) {
+	defer AllowOrigins(nil)
+	req := func(origin string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "http://api.example.com/ws/board/1", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		return r
+	}
+
+	AllowOrigins([]string{"https://app.example.com"})
+	assert.True(t, checkOrigin(req("https://app.example.com")))
+	assert.True(t, checkOrigin(req("http://api.example.com")))
+	assert.False(t, checkOrigin(req("https://evil.example.com")))
+
+	AllowOrigins([]string{"*"})
+	assert.True(t, checkOrigin(req("https://evil.example.com")))
+}
+
+// Non-browser clients send no Origin header and are let through whatever
+// the allowed origins are; they still need a ticket or a JWT.
+func TestCheckOrigin_Empty(t *testing.T) {
+	defer AllowOrigins(nil)
+	r := httptest.NewRequest(http.MethodGet, "http://api.example.com/ws/board/1", nil)
+	require.Empty(t, r.Header.Get("Origin"))
+
+	for _, allowed := range [][]string{nil, {"https://app.example.com"}} {
+		AllowOrigins(allowed)
+		assert.True(t, checkOrigin(r), "allowed origins %v", allowed)
+	}
+}
+
+// A JWT is only read from the Authorization header, never from the URL.
+func TestAuthenticate_JWT(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "7"}).SignedString([]byte("s3cret"))
+	require.NoError(t, err)
+	ctx := func(target, auth string) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		if auth != "" {
+			c.Request.Header.Set("Authorization", auth)
+		}
+		return c
+	}
+
+	uid, err := authenticate(ctx("/ws/board/1", "Bearer "+tok), nil, "s3cret", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), uid)
+
+	_, err = authenticate(ctx("/ws/board/1?token="+tok, ""), nil, "s3cret", 1)
+	assert.ErrorIs(t, err, errMissingToken)
 }
@@ -3,4 +3,8 @@ package websocket
 type EventMessage struct {
 	Event string      `json:"event"`
 	Data  interface{} `json:"data"`
+	// Revision is the board revision the event produced. It is set by the
+	// hub when a journal is configured and lets clients catch up through
+	// GET /api/boards/:boardId/changes after a reconnect.
+	Revision int64 `json:"revision,omitempty"`
 }
@@ -0,0 +1,104 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"backend/internal/logger"
+)
+
+type userMessage struct {
+	userID  int32
+	message []byte
+}
+
+// NotifyUser sends an event to the personal connections of a user on every
+// instance, for things that happen outside the boards the user has open,
+// such as being invited to a board. Like Notify, it gets no revision and is
+// not replayed; the REST API remains the source of truth.
+func (h *Hub) NotifyUser(userID int32, msg EventMessage) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		logger.Error("Failed to marshal WebSocket message",
+			"user_id", userID,
+			"event", msg.Event,
+			"error", err,
+		)
+		return
+	}
+	h.personal <- userMessage{userID: userID, message: data}
+	if h.backend == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := h.backend.PublishUser(ctx, userID, data); err != nil {
+		logger.Error("Failed to relay WebSocket message",
+			"user_id", userID,
+			"event", msg.Event,
+			"error", err,
+		)
+	}
+}
+
+// receiveUser delivers a personal message relayed from another instance.
+func (h *Hub) receiveUser(userID int32, message []byte) {
+	h.personal <- userMessage{userID: userID, message: message}
+}
+
+// connected reports whether c is still registered. Caller holds mu.
+func (h *Hub) connected(c *Client) bool {
+	if c.personal {
+		return h.users[c.userID][c]
+	}
+	return h.rooms[c.boardID][c]
+}
+
+func (h *Hub) registerPersonal(c *Client) {
+	h.mu.Lock()
+	if h.users[c.userID] == nil {
+		h.users[c.userID] = make(map[*Client]bool)
+	}
+	h.users[c.userID][c] = true
+	h.mu.Unlock()
+
+	logger.Debug("WebSocket personal client registered", "user_id", c.userID)
+}
+
+func (h *Hub) unregisterPersonal(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	clients := h.users[c.userID]
+	if !clients[c] {
+		return
+	}
+	delete(clients, c)
+	close(c.send)
+	if len(clients) == 0 {
+		delete(h.users, c.userID)
+	}
+	logger.Debug("WebSocket personal client unregistered", "user_id", c.userID)
+}
+
+// deliverUser queues a personal message for every connection of its user.
+// A client with a full buffer is disconnected, as for board broadcasts.
+func (h *Hub) deliverUser(m userMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	clients := h.users[m.userID]
+	for c := range clients {
+		select {
+		case c.send <- m.message:
+		default:
+			close(c.send)
+			delete(clients, c)
+			logger.Warn("WebSocket personal client disconnected due to full buffer",
+				"user_id", c.userID,
+			)
+		}
+	}
+	if len(clients) == 0 {
+		delete(h.users, m.userID)
+	}
+}
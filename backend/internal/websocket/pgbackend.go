@@ -0,0 +1,177 @@
+package websocket
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+)
+
+const (
+	// NotifyChannel is the Postgres channel board events are relayed on.
+	NotifyChannel = "board_events"
+	// maxNotifyPayload keeps NOTIFY payloads under the 8000 byte limit.
+	// Larger messages are sent as a reference and loaded from the journal.
+	maxNotifyPayload = 7500
+	maxListenBackoff = 30 * time.Second
+)
+
+// notification is the NOTIFY payload. Message is omitted for large events,
+// which receivers read from board_events by revision instead. UserID is set
+// instead of BoardID for personal messages; Revoke marks a request to close
+// connections, which carries no message.
+type notification struct {
+	Origin   string          `json:"o"`
+	BoardID  int32           `json:"b,omitempty"`
+	UserID   int32           `json:"u,omitempty"`
+	Revision int64           `json:"r,omitempty"`
+	Message  json.RawMessage `json:"m,omitempty"`
+	Revoke   bool            `json:"x,omitempty"`
+}
+
+// PGBackend relays broadcasts through Postgres LISTEN/NOTIFY.
+type PGBackend struct {
+	pool   *pgxpool.Pool
+	q      *db.Queries
+	origin string // identifies this instance so its own messages are skipped
+}
+
+func NewPGBackend(pool *pgxpool.Pool) (*PGBackend, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return &PGBackend{pool: pool, q: db.New(pool), origin: hex.EncodeToString(b)}, nil
+}
+
+func (b *PGBackend) Publish(ctx context.Context, boardID int32, revision int64, message []byte) error {
+	n := notification{Origin: b.origin, BoardID: boardID, Revision: revision, Message: message}
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		if revision == 0 {
+			return errors.New("event is too large to relay without a journal revision")
+		}
+		n.Message = nil
+		if payload, err = json.Marshal(n); err != nil {
+			return err
+		}
+	}
+	_, err = b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", NotifyChannel, string(payload))
+	return err
+}
+
+func (b *PGBackend) PublishUser(ctx context.Context, userID int32, message []byte) error {
+	payload, err := json.Marshal(notification{Origin: b.origin, UserID: userID, Message: message})
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		return errors.New("personal event is too large to relay")
+	}
+	_, err = b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", NotifyChannel, string(payload))
+	return err
+}
+
+func (b *PGBackend) PublishRevoke(ctx context.Context, boardID, userID int32) error {
+	payload, err := json.Marshal(notification{Origin: b.origin, BoardID: boardID, UserID: userID, Revoke: true})
+	if err != nil {
+		return err
+	}
+	_, err = b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", NotifyChannel, string(payload))
+	return err
+}
+
+// Subscribe listens on a dedicated connection and reconnects with backoff
+// when it drops. Events published while reconnecting are not delivered;
+// clients catch up through replay or the changes feed.
+func (b *PGBackend) Subscribe(ctx context.Context, r Receiver) {
+	backoff := time.Second
+	for ctx.Err() == nil {
+		start := time.Now()
+		err := b.listen(ctx, r)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(start) > maxListenBackoff {
+			backoff = time.Second
+		}
+		logger.Error("WebSocket fan-out listener stopped, reconnecting",
+			"error", err,
+			"retry_in", backoff,
+		)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff = min(backoff*2, maxListenBackoff)
+	}
+}
+
+func (b *PGBackend) listen(ctx context.Context, r Receiver) error {
+	conn, err := pgx.ConnectConfig(ctx, b.pool.Config().ConnConfig.Copy())
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{NotifyChannel}.Sanitize()); err != nil {
+		return err
+	}
+	logger.Info("WebSocket fan-out listener started", "channel", NotifyChannel, "origin", b.origin)
+
+	for {
+		pn, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var n notification
+		if err := json.Unmarshal([]byte(pn.Payload), &n); err != nil {
+			logger.Warn("Ignoring malformed fan-out notification", "error", err)
+			continue
+		}
+		if n.Origin == b.origin {
+			continue
+		}
+		if n.Revoke {
+			r.Revoke(n.BoardID, n.UserID)
+			continue
+		}
+		if n.UserID != 0 {
+			r.User(n.UserID, n.Message)
+			continue
+		}
+		msg := []byte(n.Message)
+		if len(msg) == 0 {
+			if msg, err = b.load(ctx, n.BoardID, n.Revision); err != nil {
+				logger.Error("Failed to load relayed board event",
+					"board_id", n.BoardID,
+					"revision", n.Revision,
+					"error", err,
+				)
+				continue
+			}
+		}
+		r.Board(n.BoardID, n.Revision, msg)
+	}
+}
+
+// load rebuilds a large event from the journal.
+func (b *PGBackend) load(ctx context.Context, boardID int32, revision int64) ([]byte, error) {
+	e, err := b.q.GetBoardEvent(ctx, db.GetBoardEventParams{BoardID: boardID, Revision: revision})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(EventMessage{Event: e.Event, Data: json.RawMessage(e.Payload), Revision: e.Revision})
+}
@@ -0,0 +1,139 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	db "backend/internal/db/sqlc"
+)
+
+// Viewer is a user who has the board open, however many tabs they use.
+type Viewer struct {
+	UserID int32  `json:"userId"`
+	Name   string `json:"name"`
+}
+
+func (c *Client) viewer() Viewer {
+	return Viewer{UserID: c.userID, Name: c.name}
+}
+
+func hasUser(clients map[*Client]bool, userID int32) bool {
+	for c := range clients {
+		if c.userID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// viewersLocked lists the distinct users connected to a board, by name.
+// Caller holds mu.
+func (h *Hub) viewersLocked(boardID int32) []Viewer {
+	seen := make(map[int32]bool)
+	out := []Viewer{}
+	for c := range h.rooms[boardID] {
+		if !seen[c.userID] {
+			seen[c.userID] = true
+			out = append(out, c.viewer())
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].UserID < out[j].UserID
+	})
+	return out
+}
+
+// Viewers returns who is viewing a board on this instance right now.
+func (h *Hub) Viewers(boardID int32) []Viewer {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.viewersLocked(boardID)
+}
+
+// sendTo queues an event for one client. Presence events are best effort:
+// a full buffer skips them rather than dropping the connection.
+func (h *Hub) sendTo(c *Client, event string, data interface{}) {
+	msg, err := json.Marshal(EventMessage{Event: event, Data: data})
+	if err != nil {
+		return
+	}
+	select {
+	case c.send <- msg:
+	default:
+	}
+}
+
+// notifyPresence sends a presence event to every client of a board except
+// one. Presence is not journaled: it describes this instance's connections
+// and means nothing on replay. Caller holds mu.
+func (h *Hub) notifyPresence(boardID int32, event string, v Viewer, except *Client) {
+	for c := range h.rooms[boardID] {
+		if c != except {
+			h.sendTo(c, event, v)
+		}
+	}
+}
+
+// OnLeave registers fn to run when a user closes their last connection to
+// a board on this instance. fn runs in its own goroutine, so it may use the
+// hub. Register callbacks before the server starts accepting connections.
+func (h *Hub) OnLeave(fn func(boardID, userID int32)) {
+	h.onLeave = append(h.onLeave, fn)
+}
+
+// leftIfLastTab announces that the user of a removed client left the board
+// when it was their last connection to it. Caller holds mu.
+func (h *Hub) leftIfLastTab(c *Client) {
+	if hasUser(h.rooms[c.boardID], c.userID) {
+		return
+	}
+	h.notifyPresence(c.boardID, "presence_left", c.viewer(), nil)
+	for _, fn := range h.onLeave {
+		go fn(c.boardID, c.userID)
+	}
+}
+
+func RegisterRoutes(r *gin.RouterGroup, hub *Hub, q *db.Queries) {
+	r.GET("/boards/:boardId/presence", presenceHandler(hub, q))
+	r.POST("/boards/:boardId/ws-ticket", boardTicketHandler(q))
+	r.POST("/me/ws-ticket", userTicketHandler(q))
+}
+
+// presenceHandler lists the users viewing a board
+//
+//	@Summary		Get board viewers
+//	@Description	Get the users who currently have the board open over WebSocket. Several tabs of one user count once
+//	@Tags			Boards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId	path		int					true	"Board ID"
+//	@Success		200		{array}		ViewerResponse		"Current viewers"
+//	@Failure		401		{object}	map[string]string	"Unauthorized"
+//	@Failure		403		{object}	map[string]string	"Forbidden - not a board member"
+//	@Router			/api/boards/{boardId}/presence [get]
+func presenceHandler(hub *Hub, q *db.Queries) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		boardID, _ := strconv.Atoi(c.Param("boardId"))
+		userID := int32(c.GetInt("userID"))
+		if _, err := q.GetBoardMember(c.Request.Context(), db.GetBoardMemberParams{
+			BoardID: int32(boardID), UserID: userID,
+		}); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "not a member"})
+			return
+		}
+		c.JSON(http.StatusOK, hub.Viewers(int32(boardID)))
+	}
+}
+
+// ViewerResponse represents a user viewing a board
+type ViewerResponse struct {
+	UserID int32  `json:"userId" example:"2"`
+	Name   string `json:"name" example:"John Doe"`
+}
@@ -0,0 +1,100 @@
+package websocket
+
+import (
+	"sync"
+	"time"
+)
+
+// reorderWait is how long an event waits for the events numbered before it.
+// After that it is delivered anyway: the missing events were lost on the way
+// or never relayed.
+const reorderWait = 500 * time.Millisecond
+
+// reorderBuffer puts the events of each board back in revision order. The
+// journal numbers the events of a board for every instance, but they reach
+// an instance along different paths, its own broadcasts and relays from the
+// other instances, so revision N+1 can arrive before N.
+type reorderBuffer struct {
+	mu      sync.Mutex
+	deliver func(broadcastRequest)
+	boards  map[int32]*boardOrder
+}
+
+type boardOrder struct {
+	// last is the newest revision delivered.
+	last    int64
+	pending map[int64]broadcastRequest
+	timer   *time.Timer
+}
+
+func newReorderBuffer(deliver func(broadcastRequest)) *reorderBuffer {
+	return &reorderBuffer{deliver: deliver, boards: make(map[int32]*boardOrder)}
+}
+
+// add delivers b once every earlier event of its board has been delivered,
+// or reorderWait has passed.
+func (r *reorderBuffer) add(b broadcastRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o := r.boards[b.boardID]
+	if o == nil {
+		// The first event seen of a board is where its order starts
+		r.boards[b.boardID] = &boardOrder{last: b.revision, pending: make(map[int64]broadcastRequest)}
+		r.deliver(b)
+		return
+	}
+	if b.revision <= o.last {
+		// Too late to be in order, but better late than lost
+		r.deliver(b)
+		return
+	}
+	o.pending[b.revision] = b
+	r.flush(b.boardID, o)
+}
+
+// flush delivers the pending events that are next in line, and makes sure
+// a timer is waiting for the gap before the others. Caller holds mu.
+func (r *reorderBuffer) flush(boardID int32, o *boardOrder) {
+	for {
+		b, ok := o.pending[o.last+1]
+		if !ok {
+			break
+		}
+		delete(o.pending, b.revision)
+		o.last = b.revision
+		r.deliver(b)
+	}
+	if len(o.pending) == 0 {
+		if o.timer != nil {
+			o.timer.Stop()
+			o.timer = nil
+		}
+		return
+	}
+	if o.timer == nil {
+		var t *time.Timer
+		t = time.AfterFunc(reorderWait, func() { r.skipGap(boardID, t) })
+		o.timer = t
+	}
+}
+
+// skipGap gives up waiting for the events missing before the oldest pending
+// one of a board. t is the timer that fired; a timer stopped too late to
+// keep it from firing is ignored.
+func (r *reorderBuffer) skipGap(boardID int32, t *time.Timer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o := r.boards[boardID]
+	if o.timer != t {
+		return
+	}
+	o.timer = nil
+	oldest := int64(-1)
+	for rev := range o.pending {
+		if oldest < 0 || rev < oldest {
+			oldest = rev
+		}
+	}
+	o.last = oldest - 1
+	r.flush(boardID, o)
+}
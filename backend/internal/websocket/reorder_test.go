@@ -0,0 +1,62 @@
+// internal/websocket/reorder_test.go
+package websocket
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// delivered records the revisions a reorderBuffer hands on.
+type delivered struct {
+	mu   sync.Mutex
+	revs []int64
+}
+
+func (d *delivered) add(b broadcastRequest) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.revs = append(d.revs, b.revision)
+}
+
+func (d *delivered) get() []int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]int64(nil), d.revs...)
+}
+
+func TestReorderBuffer_Order(t *testing.T) {
+	d := &delivered{}
+	r := newReorderBuffer(d.add)
+
+	for _, rev := range []int64{1, 3, 4, 2, 6, 5} {
+		r.add(broadcastRequest{boardID: 1, revision: rev})
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6}, d.get())
+
+	// Boards are ordered independently
+	r.add(broadcastRequest{boardID: 2, revision: 10})
+	r.add(broadcastRequest{boardID: 1, revision: 7})
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 10, 7}, d.get())
+}
+
+func TestReorderBuffer_SkipsGap(t *testing.T) {
+	d := &delivered{}
+	r := newReorderBuffer(d.add)
+
+	r.add(broadcastRequest{boardID: 1, revision: 1})
+	r.add(broadcastRequest{boardID: 1, revision: 4})
+	r.add(broadcastRequest{boardID: 1, revision: 3})
+	assert.Equal(t, []int64{1}, d.get(), "waits for 2")
+
+	// 2 never comes
+	assert.Eventually(t, func() bool { return len(d.get()) == 3 }, 2*reorderWait, 10*time.Millisecond)
+	assert.Equal(t, []int64{1, 3, 4}, d.get())
+
+	// A straggler is still delivered, only out of order
+	r.add(broadcastRequest{boardID: 1, revision: 2})
+	r.add(broadcastRequest{boardID: 1, revision: 5})
+	assert.Equal(t, []int64{1, 3, 4, 2, 5}, d.get())
+}
@@ -0,0 +1,109 @@
+package websocket
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/logger"
+)
+
+type revokeRequest struct {
+	boardID int32
+	userID  int32
+}
+
+// Revoke closes the connections of userID to boardID on every instance, or
+// those of every user when userID is 0, for example after the user was
+// removed from the board. The clients get an access_revoked event first.
+// Reconnecting fails once the membership or the board is gone.
+func (h *Hub) Revoke(boardID, userID int32) {
+	h.revoke <- revokeRequest{boardID: boardID, userID: userID}
+	if h.backend == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := h.backend.PublishRevoke(ctx, boardID, userID); err != nil {
+		logger.Error("Failed to relay WebSocket revocation",
+			"board_id", boardID,
+			"user_id", userID,
+			"error", err,
+		)
+	}
+}
+
+// RevokeUser closes every board and personal connection of a user on every
+// instance, after the user's sessions were signed out. The clients get a
+// session_revoked event first. Like the REST API, reconnecting works as
+// long as the client still holds a valid access token.
+func (h *Hub) RevokeUser(userID int32) {
+	h.Revoke(0, userID)
+}
+
+// receiveRevoke handles a revocation relayed from another instance.
+func (h *Hub) receiveRevoke(boardID, userID int32) {
+	h.revoke <- revokeRequest{boardID: boardID, userID: userID}
+}
+
+func (h *Hub) closeRevoked(r revokeRequest) {
+	if r.boardID == 0 {
+		h.closeUser(r.userID)
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	closed := h.closeInRoom(r.boardID, r.userID, "access_revoked", map[string]int32{"boardId": r.boardID})
+	if closed > 0 {
+		logger.Info("WebSocket connections revoked",
+			"board_id", r.boardID,
+			"user_id", r.userID,
+			"connections", closed,
+		)
+	}
+}
+
+// closeUser closes every connection of a user.
+func (h *Hub) closeUser(userID int32) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	closed := 0
+	for boardID := range h.rooms {
+		closed += h.closeInRoom(boardID, userID, "session_revoked", nil)
+	}
+	for c := range h.users[userID] {
+		h.sendTo(c, "session_revoked", nil)
+		close(c.send)
+		closed++
+	}
+	delete(h.users, userID)
+	if closed > 0 {
+		logger.Info("WebSocket connections of user revoked",
+			"user_id", userID,
+			"connections", closed,
+		)
+	}
+}
+
+// closeInRoom closes the connections of userID to a board, or all of them
+// when userID is 0, after sending each an event. It returns how many were
+// closed. Caller holds mu.
+func (h *Hub) closeInRoom(boardID, userID int32, event string, data interface{}) int {
+	clients := h.rooms[boardID]
+	var closed []*Client
+	for c := range clients {
+		if userID != 0 && c.userID != userID {
+			continue
+		}
+		h.sendTo(c, event, data)
+		close(c.send)
+		delete(clients, c)
+		closed = append(closed, c)
+	}
+	for _, c := range closed {
+		h.leftIfLastTab(c)
+	}
+	if len(clients) == 0 {
+		delete(h.rooms, boardID)
+	}
+	return len(closed)
+}
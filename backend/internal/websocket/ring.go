@@ -0,0 +1,66 @@
+package websocket
+
+import "sort"
+
+// replayBufferSize is how many recent events the hub keeps per board for
+// clients that reconnect with ?since=. It must stay below the client send
+// buffer so a full replay never blocks the hub.
+const replayBufferSize = 200
+
+type replayEntry struct {
+	seq  int64
+	data []byte
+}
+
+// ring is a bounded buffer of the most recent events of a board, ordered by
+// sequence number. Events relayed from other instances can arrive slightly
+// out of order, so push inserts in place and ignores duplicates.
+type ring struct {
+	buf []replayEntry
+}
+
+func (r *ring) push(seq int64, data []byte) {
+	i := sort.Search(len(r.buf), func(i int) bool { return r.buf[i].seq >= seq })
+	if i < len(r.buf) && r.buf[i].seq == seq {
+		return
+	}
+	if len(r.buf) == replayBufferSize {
+		if i == 0 {
+			return // older than everything we keep
+		}
+		copy(r.buf, r.buf[1:i])
+		r.buf[i-1] = replayEntry{seq: seq, data: data}
+		return
+	}
+	r.buf = append(r.buf, replayEntry{})
+	copy(r.buf[i+1:], r.buf[i:])
+	r.buf[i] = replayEntry{seq: seq, data: data}
+}
+
+// last returns the newest sequence number, or 0 for an empty buffer.
+func (r *ring) last() int64 {
+	if len(r.buf) == 0 {
+		return 0
+	}
+	return r.buf[len(r.buf)-1].seq
+}
+
+// since returns the events after seq. journaled is the newest revision of
+// the board when the client connected; it lets an empty buffer, as after a
+// restart or on a quiet board, vouch for a client that is up to date. ok is
+// false when the events cannot be replayed because some were already
+// dropped or seq is not known yet.
+func (r *ring) since(seq, journaled int64) (out [][]byte, ok bool) {
+	if len(r.buf) == 0 {
+		return nil, seq == journaled
+	}
+	if seq < r.buf[0].seq-1 || seq > r.last() {
+		return nil, false
+	}
+	for _, e := range r.buf {
+		if e.seq > seq {
+			out = append(out, e.data)
+		}
+	}
+	return out, true
+}
@@ -0,0 +1,65 @@
+// internal/websocket/ring_test.go
+package websocket
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRing_Since(t *testing.T) {
+	r := &ring{}
+	out, ok := r.since(4, 4)
+	assert.True(t, ok, "empty buffer, client saw the last journaled revision")
+	assert.Empty(t, out)
+	_, ok = r.since(3, 4)
+	assert.False(t, ok, "empty buffer, client missed a journaled revision")
+	_, ok = r.since(0, -1)
+	assert.False(t, ok, "empty buffer, journal unknown")
+
+	for seq := int64(1); seq <= 3; seq++ {
+		r.push(seq, []byte(strconv.FormatInt(seq, 10)))
+	}
+	out, ok = r.since(1, 3)
+	assert.True(t, ok)
+	assert.Equal(t, [][]byte{[]byte("2"), []byte("3")}, out)
+
+	out, ok = r.since(3, 3)
+	assert.True(t, ok)
+	assert.Empty(t, out)
+
+	_, ok = r.since(4, 3)
+	assert.False(t, ok, "client ahead of the hub")
+}
+
+func TestRing_Wraps(t *testing.T) {
+	r := &ring{}
+	total := int64(replayBufferSize + 50)
+	for seq := int64(1); seq <= total; seq++ {
+		r.push(seq, []byte(strconv.FormatInt(seq, 10)))
+	}
+	assert.Equal(t, total, r.last())
+
+	_, ok := r.since(10, total)
+	assert.False(t, ok, "events after 10 were dropped")
+
+	first := total - replayBufferSize + 1
+	out, ok := r.since(first-1, total)
+	assert.True(t, ok)
+	assert.Len(t, out, replayBufferSize)
+	assert.Equal(t, []byte(strconv.FormatInt(first, 10)), out[0])
+	assert.Equal(t, []byte(strconv.FormatInt(total, 10)), out[len(out)-1])
+}
+
+func TestRing_OutOfOrder(t *testing.T) {
+	r := &ring{}
+	r.push(1, []byte("1"))
+	r.push(3, []byte("3"))
+	r.push(2, []byte("2"))
+	r.push(3, []byte("dup"))
+
+	out, ok := r.since(0, 3)
+	assert.True(t, ok)
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("2"), []byte("3")}, out)
+}
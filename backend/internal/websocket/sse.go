@@ -0,0 +1,112 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+)
+
+// sseKeepAlive is how often an idle stream gets a comment line, so proxies
+// do not close it as inactive.
+const sseKeepAlive = 25 * time.Second
+
+// ServeBoardSSE streams the events of a board as Server-Sent Events, for
+// clients behind proxies that break WebSocket upgrades. The stream carries
+// the same messages as ServeBoardWS, one per `data:` line, with the board
+// revision as the event id. A client that reconnects with the Authorization
+// header sends it back in Last-Event-ID, which resumes like `since` does.
+// Tickets are single-use, so the automatic reconnect of an EventSource gets
+// 401 and the stream closes; the client resumes by opening a new one with a
+// fresh ticket and `since` set to the last event id. Auth, membership and
+// presence work as for the WebSocket; commands go through the REST API.
+func ServeBoardSSE(c *gin.Context, hub *Hub, q *db.Queries, jwtSecret string) {
+	if !checkOrigin(c.Request) {
+		logger.Warn("SSE connection failed: origin not allowed",
+			"origin", c.GetHeader("Origin"),
+			"remote_addr", c.ClientIP(),
+		)
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+	since := c.GetHeader("Last-Event-ID")
+	if since == "" {
+		since = c.Query("since")
+	}
+	client := boardClient(c, hub, q, jwtSecret, "SSE", since)
+	if client == nil {
+		return
+	}
+	stream(c, hub, client)
+}
+
+// stream registers client with the hub and writes its messages to the
+// response until either side goes away.
+func stream(c *gin.Context, hub *Hub, client *Client) {
+	h := c.Writer.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no") // keep nginx from buffering the stream
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	logger.Info("SSE connection established",
+		"board_id", client.boardID,
+		"user_id", client.userID,
+		"remote_addr", c.ClientIP(),
+	)
+
+	hub.register <- client
+	defer func() {
+		hub.unregister <- client
+		logger.Debug("SSE client disconnecting",
+			"board_id", client.boardID,
+			"user_id", client.userID,
+		)
+	}()
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case msg, ok := <-client.send:
+			if !ok {
+				// Dropped by the hub for falling behind.
+				return
+			}
+			if err := writeSSE(c.Writer, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		case <-c.Request.Context().Done():
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeSSE writes one message as an SSE event. Events without a revision,
+// such as presence, get no id so they do not move the resume point.
+func writeSSE(w io.Writer, msg []byte) error {
+	var head struct {
+		Revision int64 `json:"revision"`
+	}
+	_ = json.Unmarshal(msg, &head)
+	if head.Revision > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", head.Revision); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "data: %s\n\n", msg)
+	return err
+}
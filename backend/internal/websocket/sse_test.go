@@ -0,0 +1,224 @@
+// internal/websocket/sse_test.go
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "backend/internal/db/sqlc"
+)
+
+func TestWriteSSE(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeSSE(&buf, []byte(`{"event":"card_created","data":1,"revision":7}`)))
+	assert.Equal(t, "id: 7\ndata: {\"event\":\"card_created\",\"data\":1,\"revision\":7}\n\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeSSE(&buf, []byte(`{"event":"presence_joined","data":{}}`)))
+	assert.Equal(t, "data: {\"event\":\"presence_joined\",\"data\":{}}\n\n", buf.String())
+}
+
+// syncRecorder lets the test read the body while the handler writes it.
+type syncRecorder struct {
+	*httptest.ResponseRecorder
+	mu      sync.Mutex
+	written chan struct{}
+}
+
+func (r *syncRecorder) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ResponseRecorder.Write(b)
+}
+
+func (r *syncRecorder) Flush() {
+	select {
+	case r.written <- struct{}{}:
+	default:
+	}
+}
+
+func (r *syncRecorder) body() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.Body.String()
+}
+
+func TestStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewHub(nil, nil)
+	go h.Run()
+
+	// A board event from before the connection is replayed after since.
+	h.Broadcast(1, EventMessage{Event: "card_created", Data: 1})
+	h.Broadcast(1, EventMessage{Event: "card_updated", Data: 1})
+
+	rec := &syncRecorder{ResponseRecorder: httptest.NewRecorder(), written: make(chan struct{}, 1)}
+	c, _ := gin.CreateTestContext(rec)
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Request = httptest.NewRequest(http.MethodGet, "/sse/board/1", nil).WithContext(ctx)
+
+	client := &Client{hub: h, send: make(chan []byte, 8), boardID: 1, userID: 1, since: 1, replay: true}
+	done := make(chan struct{})
+	go func() {
+		stream(c, h, client)
+		close(done)
+	}()
+
+	deadline := time.After(time.Second)
+	for !strings.Contains(rec.body(), "card_updated") {
+		select {
+		case <-rec.written:
+		case <-deadline:
+			t.Fatal("replayed event was not streamed")
+		}
+	}
+	cancel()
+	<-done
+
+	body := rec.body()
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Contains(t, body, "id: 2\ndata: ")
+	assert.NotContains(t, body, "card_created")
+}
+
+// ticketDB keeps connection tickets in memory; every board exists and
+// every user is a member of it.
+type ticketDB struct {
+	mu      sync.Mutex
+	tickets map[string][]any // hash → user_id, board_id
+}
+
+func sqlName(sql string) string {
+	name, _, _ := strings.Cut(strings.TrimPrefix(sql, "-- name: "), " ")
+	return name
+}
+
+func (f *ticketDB) Exec(_ context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if sqlName(sql) == "CreateWsTicket" {
+		f.tickets[args[0].(string)] = []any{args[1], args[2]}
+	}
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *ticketDB) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query " + sqlName(sql))
+}
+
+func (f *ticketDB) QueryRow(_ context.Context, sql string, args ...interface{}) pgx.Row {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch sqlName(sql) {
+	case "RedeemWsTicket":
+		t, ok := f.tickets[args[0].(string)]
+		if !ok {
+			return fakeRow{err: pgx.ErrNoRows}
+		}
+		delete(f.tickets, args[0].(string))
+		return fakeRow{vals: t}
+	case "GetBoardByID", "GetBoardMember":
+		return fakeRow{}
+	}
+	return fakeRow{err: pgx.ErrNoRows}
+}
+
+// fakeRow scans its values into the destinations in order, and leaves them
+// alone when it has none.
+type fakeRow struct {
+	vals []any
+	err  error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, v := range r.vals {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(v))
+	}
+	return nil
+}
+
+// openSSE serves a board stream request and returns the recorder and a
+// function that closes the connection and waits for the handler.
+func openSSE(h *Hub, q *db.Queries, query string, header http.Header) (*syncRecorder, func()) {
+	rec := &syncRecorder{ResponseRecorder: httptest.NewRecorder(), written: make(chan struct{}, 1)}
+	c, _ := gin.CreateTestContext(rec)
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Request = httptest.NewRequest(http.MethodGet, "/sse/board/1?"+query, nil).WithContext(ctx)
+	for k, v := range header {
+		c.Request.Header[k] = v
+	}
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	done := make(chan struct{})
+	go func() {
+		ServeBoardSSE(c, h, q, "secret")
+		close(done)
+	}()
+	return rec, func() {
+		cancel()
+		<-done
+	}
+}
+
+func waitFor(t *testing.T, rec *syncRecorder, s string) {
+	t.Helper()
+	deadline := time.After(time.Second)
+	for !strings.Contains(rec.body(), s) {
+		select {
+		case <-rec.written:
+		case <-time.After(10 * time.Millisecond):
+		case <-deadline:
+			t.Fatalf("%q was not streamed", s)
+		}
+	}
+}
+
+func TestServeBoardSSE_ReconnectWithTicket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewHub(nil, nil)
+	go h.Run()
+	q := db.New(&ticketDB{tickets: map[string][]any{}})
+	ctx := context.Background()
+
+	first, err := IssueTicket(ctx, q, 1, 1)
+	require.NoError(t, err)
+	rec, disconnect := openSSE(h, q, "ticket="+first.Ticket, nil)
+	waitFor(t, rec, "presence_snapshot")
+	h.Broadcast(1, EventMessage{Event: "card_created", Data: 1})
+	waitFor(t, rec, "id: 1\n")
+	disconnect()
+
+	// Missed while disconnected
+	h.Broadcast(1, EventMessage{Event: "card_updated", Data: 1})
+
+	// The automatic reconnect of an EventSource reuses the spent ticket
+	rec, disconnect = openSSE(h, q, "ticket="+first.Ticket, http.Header{"Last-Event-Id": {"1"}})
+	disconnect()
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// A new EventSource with a fresh ticket resumes from since
+	second, err := IssueTicket(ctx, q, 1, 1)
+	require.NoError(t, err)
+	rec, disconnect = openSSE(h, q, "ticket="+second.Ticket+"&since=1", nil)
+	waitFor(t, rec, "card_updated")
+	disconnect()
+	assert.Contains(t, rec.body(), "id: 2\n")
+	assert.NotContains(t, rec.body(), "card_created")
+}
@@ -0,0 +1,110 @@
+package websocket
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "backend/internal/db/sqlc"
+	"backend/internal/logger"
+)
+
+// TicketTTL is how long a connection ticket stays valid after it is issued.
+const TicketTTL = 30 * time.Second
+
+// TicketResponse is a single-use credential for opening a connection
+type TicketResponse struct {
+	Ticket    string    `json:"ticket" example:"q3Jd1m0tV9tq2S2cS3yX0pUu4kq6m5w8rZr3v1bN9aE"`
+	ExpiresAt time.Time `json:"expiresAt" example:"2024-01-01T00:00:30Z"`
+}
+
+// IssueTicket creates a ticket that lets userID open one connection to
+// boardID, or to the personal channel when boardID is 0, within TicketTTL.
+// Only its hash is stored, and redeeming it deletes it.
+func IssueTicket(ctx context.Context, q *db.Queries, userID, boardID int32) (TicketResponse, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return TicketResponse{}, err
+	}
+	ticket := base64.RawURLEncoding.EncodeToString(b)
+	if err := q.CreateWsTicket(ctx, db.CreateWsTicketParams{
+		TokenHash:  hashTicket(ticket),
+		UserID:     userID,
+		BoardID:    pgtype.Int4{Int32: boardID, Valid: boardID != 0},
+		TtlSeconds: int32(TicketTTL / time.Second),
+	}); err != nil {
+		return TicketResponse{}, err
+	}
+	// Unused tickets are cleaned up as new ones are issued.
+	if err := q.DeleteExpiredWsTickets(ctx); err != nil {
+		logger.Warn("Failed to delete expired WebSocket tickets", "error", err)
+	}
+	return TicketResponse{Ticket: ticket, ExpiresAt: time.Now().UTC().Add(TicketTTL)}, nil
+}
+
+func hashTicket(ticket string) string {
+	sum := sha256.Sum256([]byte(ticket))
+	return hex.EncodeToString(sum[:])
+}
+
+// boardTicketHandler issues a ticket for the board WebSocket or SSE stream
+//
+//	@Summary		Get a board connection ticket
+//	@Description	Issue a single-use ticket, valid for 30 seconds, for opening /ws/board/{boardId} or /sse/board/{boardId} with ?ticket= instead of passing the JWT in the URL
+//	@Tags			Boards
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			boardId	path		int					true	"Board ID"
+//	@Success		201		{object}	TicketResponse		"Ticket issued"
+//	@Failure		401		{object}	map[string]string	"Unauthorized"
+//	@Failure		403		{object}	map[string]string	"Forbidden - not a board member"
+//	@Failure		500		{object}	map[string]string	"Internal server error"
+//	@Router			/api/boards/{boardId}/ws-ticket [post]
+func boardTicketHandler(q *db.Queries) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		boardID, _ := strconv.Atoi(c.Param("boardId"))
+		userID := int32(c.GetInt("userID"))
+		if _, err := q.GetBoardMember(c.Request.Context(), db.GetBoardMemberParams{
+			BoardID: int32(boardID), UserID: userID,
+		}); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "not a member"})
+			return
+		}
+		t, err := IssueTicket(c.Request.Context(), q, userID, int32(boardID))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue ticket"})
+			return
+		}
+		c.JSON(http.StatusCreated, t)
+	}
+}
+
+// userTicketHandler issues a ticket for the personal WebSocket
+//
+//	@Summary		Get a personal connection ticket
+//	@Description	Issue a single-use ticket, valid for 30 seconds, for opening /ws/user with ?ticket= instead of passing the JWT in the URL
+//	@Tags			User
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		201	{object}	TicketResponse		"Ticket issued"
+//	@Failure		401	{object}	map[string]string	"Unauthorized"
+//	@Failure		500	{object}	map[string]string	"Internal server error"
+//	@Router			/api/me/ws-ticket [post]
+func userTicketHandler(q *db.Queries) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t, err := IssueTicket(c.Request.Context(), q, int32(c.GetInt("userID")), 0)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue ticket"})
+			return
+		}
+		c.JSON(http.StatusCreated, t)
+	}
+}
@@ -7,6 +7,9 @@ import RegisterPage  from '@/components/pages/RegisterPage';
 import BoardsPage    from '@/components/pages/BoardsPage';
 import BoardPage     from '@/components/pages/BoardPage';
 import AccountSettingsPage from '@/components/pages/AccountSettingsPage';
+import ResetPasswordPage from '@/components/pages/ResetPasswordPage';
+import VerifyEmailPage from '@/components/pages/VerifyEmailPage';
+import AuthCallbackPage from '@/components/pages/AuthCallbackPage';
 import { useAuthStore } from '@/store/useAuthStore';
 import { useToastStore } from '@/store/useToastStore';
 import { initializeTheme } from '@/store/useThemeStore';
@@ -95,6 +98,9 @@ export default function App() {
           <Route path="/welcome" element={<WelcomePage />} />
           <Route path="/login"    element={<LoginPage />} />
           <Route path="/register" element={<RegisterPage />} />
+          <Route path="/reset-password" element={<ResetPasswordPage />} />
+          <Route path="/verify-email" element={<VerifyEmailPage />} />
+          <Route path="/auth/callback" element={<AuthCallbackPage />} />
           <Route
             path="/"
             element={
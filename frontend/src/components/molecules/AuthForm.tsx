@@ -1,5 +1,5 @@
 import { useState } from 'react';
-import { useNavigate } from 'react-router-dom';
+import { Link, useNavigate } from 'react-router-dom';
 import { z } from 'zod';
 import { Input } from '@/components/atoms/Input';
 import Button from '@/components/atoms/Button';
@@ -7,6 +7,7 @@ import { useAuthStore } from '@/store/useAuthStore';
 import { useToastStore } from '@/store/useToastStore';
 import { emailSchema, passwordSchema, nameSchema } from '@/utils/validate';
 import { handleApiError } from '@/utils/api/errorHandling';
+import { baseServerURL } from '@/services/api';
 
 // Different schemas for login and registration
 const loginSchema = z.object({ email: emailSchema, password: passwordSchema });
@@ -114,6 +115,16 @@ export default function AuthForm({ mode = 'login' }: { mode?: Mode }) {
       <Button type="submit" variant="primary" loading={loading}>
         {mode === 'login' ? 'Войти' : 'Зарегистрироваться'}
       </Button>
+      {mode === 'login' && import.meta.env.VITE_OIDC_ENABLED === 'true' && (
+        <Button type="button" variant="secondary" onClick={() => window.location.assign(`${baseServerURL}/auth/oidc/login`)}>
+          Войти через SSO
+        </Button>
+      )}
+      {mode === 'login' && (
+        <Link to="/reset-password" className="text-center text-sm text-blue-600 dark:text-blue-400 hover:text-blue-800 dark:hover:text-blue-300">
+          Забыли пароль?
+        </Link>
+      )}
     </form>
   );
 }
\ No newline at end of file
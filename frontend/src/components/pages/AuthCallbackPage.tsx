@@ -0,0 +1,60 @@
+import { useEffect, useState } from 'react';
+import { Link, useNavigate } from 'react-router-dom';
+import AuthTemplate from '@/components/templates/AuthTemplate';
+import Spinner from '@/components/atoms/Spinner';
+import { useAuthStore } from '@/store/useAuthStore';
+
+/** Messages for the error codes the server puts in the fragment */
+const errorMessages: Record<string, string> = {
+  invalid_state: 'Сессия входа устарела, попробуйте еще раз',
+  email_not_verified: 'Провайдер не подтвердил ваш email',
+  login_failed: 'Не удалось войти через SSO',
+};
+
+/**
+ * Finishes SSO: the server redirects here with the tokens in the URL fragment
+ * (/auth/callback#token=...&refreshToken=...&expiresIn=...) or with #error=...
+ */
+export default function AuthCallbackPage() {
+  const navigate = useNavigate();
+  const loginWithTokens = useAuthStore((s) => s.loginWithTokens);
+  // read once: the effect below clears the fragment and may run twice in StrictMode
+  const [params] = useState(() => new URLSearchParams(window.location.hash.slice(1)));
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    // the tokens should not stay in the address bar or the history
+    window.history.replaceState(null, '', window.location.pathname);
+
+    const token = params.get('token');
+    const refreshToken = params.get('refreshToken');
+    if (!token || !refreshToken) {
+      const code = params.get('error') ?? 'login_failed';
+      setError(errorMessages[code] ?? `Провайдер отклонил вход: ${code}`);
+      return;
+    }
+    loginWithTokens(token, refreshToken)
+      .then(() => navigate('/', { replace: true }))
+      .catch(() => setError(errorMessages.login_failed));
+  }, [params, loginWithTokens, navigate]);
+
+  return (
+    <AuthTemplate>
+      <div className="flex flex-col items-center gap-4 text-center text-blue-800 dark:text-blue-300">
+        {error ? (
+          <>
+            <p>{error}</p>
+            <Link to="/login" className="text-sm text-blue-600 dark:text-blue-400 hover:text-blue-800 dark:hover:text-blue-300">
+              Вернуться ко входу
+            </Link>
+          </>
+        ) : (
+          <>
+            <Spinner />
+            <p>Выполняем вход…</p>
+          </>
+        )}
+      </div>
+    </AuthTemplate>
+  );
+}
@@ -0,0 +1,123 @@
+import { useState } from 'react';
+import { Link, useNavigate, useSearchParams } from 'react-router-dom';
+import AuthTemplate from '@/components/templates/AuthTemplate';
+import { Input } from '@/components/atoms/Input';
+import Button from '@/components/atoms/Button';
+import { authService } from '@/services/authService';
+import { useToastStore } from '@/store/useToastStore';
+import { emailSchema, passwordSchema } from '@/utils/validate';
+
+/**
+ * Without a token asks for the email to send a reset link to; with the token
+ * from that link (/reset-password?token=...) sets a new password.
+ */
+export default function ResetPasswordPage() {
+  const [params] = useSearchParams();
+  const token = params.get('token');
+
+  return (
+    <AuthTemplate>
+      {token ? <NewPasswordForm token={token} /> : <ForgotPasswordForm />}
+    </AuthTemplate>
+  );
+}
+
+function ForgotPasswordForm() {
+  const { error: showErrorToast } = useToastStore();
+  const [email, setEmail] = useState('');
+  const [err, setErr] = useState<string>();
+  const [loading, setLoading] = useState(false);
+  const [sent, setSent] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    const res = emailSchema.safeParse(email);
+    if (!res.success) {
+      setErr(res.error.errors[0].message);
+      return;
+    }
+    setErr(undefined);
+    setLoading(true);
+    try {
+      await authService.forgotPassword(email);
+      setSent(true);
+    } catch {
+      showErrorToast('Не удалось отправить письмо, попробуйте позже');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  if (sent) {
+    return (
+      <p className="text-center text-blue-800 dark:text-blue-300">
+        Если аккаунт с адресом {email} существует, на него отправлено письмо со ссылкой для сброса пароля.
+      </p>
+    );
+  }
+
+  return (
+    <form onSubmit={handleSubmit} className="flex flex-col gap-3">
+      <h1 className="text-xl font-semibold text-blue-800 dark:text-blue-300">Сброс пароля</h1>
+      <Input
+        placeholder="E‑mail"
+        type="email"
+        value={email}
+        error={err}
+        autoComplete="email"
+        onChange={(e) => setEmail(e.target.value)}
+      />
+      <Button type="submit" variant="primary" loading={loading}>
+        Отправить ссылку
+      </Button>
+    </form>
+  );
+}
+
+function NewPasswordForm({ token }: { token: string }) {
+  const navigate = useNavigate();
+  const { success: showSuccessToast, error: showErrorToast } = useToastStore();
+  const [password, setPassword] = useState('');
+  const [err, setErr] = useState<string>();
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    const res = passwordSchema.safeParse(password);
+    if (!res.success) {
+      setErr(res.error.errors[0].message);
+      return;
+    }
+    setErr(undefined);
+    setLoading(true);
+    try {
+      await authService.resetPassword(token, password);
+      showSuccessToast('Пароль изменен, войдите с новым паролем');
+      navigate('/login');
+    } catch {
+      showErrorToast('Ссылка недействительна или устарела');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <form onSubmit={handleSubmit} className="flex flex-col gap-3">
+      <h1 className="text-xl font-semibold text-blue-800 dark:text-blue-300">Новый пароль</h1>
+      <Input
+        placeholder="Новый пароль"
+        type="password"
+        value={password}
+        error={err}
+        autoComplete="new-password"
+        onChange={(e) => setPassword(e.target.value)}
+      />
+      <Button type="submit" variant="primary" loading={loading}>
+        Сохранить пароль
+      </Button>
+      <Link to="/reset-password" className="text-center text-sm text-blue-600 dark:text-blue-400 hover:text-blue-800 dark:hover:text-blue-300">
+        Запросить новую ссылку
+      </Link>
+    </form>
+  );
+}
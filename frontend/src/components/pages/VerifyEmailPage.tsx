@@ -0,0 +1,70 @@
+import { useEffect, useState } from 'react';
+import { Link, useSearchParams } from 'react-router-dom';
+import AuthTemplate from '@/components/templates/AuthTemplate';
+import Spinner from '@/components/atoms/Spinner';
+import Button from '@/components/atoms/Button';
+import { authService } from '@/services/authService';
+import { useAuthStore } from '@/store/useAuthStore';
+import { useToastStore } from '@/store/useToastStore';
+
+// Tokens are single-use, so a token is sent once even if the effect runs twice
+const verifying = new Map<string, Promise<void>>();
+
+/** Confirms the email address from the link in the verification letter (/verify-email?token=...). */
+export default function VerifyEmailPage() {
+  const [params] = useSearchParams();
+  const token = params.get('token');
+  const isAuth = !!useAuthStore((s) => s.token);
+  const { success: showSuccessToast, error: showErrorToast } = useToastStore();
+  const [status, setStatus] = useState<'pending' | 'done' | 'failed'>(token ? 'pending' : 'failed');
+  const [resending, setResending] = useState(false);
+
+  const resend = async () => {
+    setResending(true);
+    try {
+      await authService.resendVerification();
+      showSuccessToast('Новая ссылка отправлена на вашу почту');
+    } catch {
+      showErrorToast('Не удалось отправить письмо, попробуйте позже');
+    } finally {
+      setResending(false);
+    }
+  };
+
+  useEffect(() => {
+    if (!token) return;
+    if (!verifying.has(token)) verifying.set(token, authService.verifyEmail(token));
+    let active = true;
+    verifying.get(token)!
+      .then(() => active && setStatus('done'))
+      .catch(() => active && setStatus('failed'));
+    return () => {
+      active = false;
+    };
+  }, [token]);
+
+  return (
+    <AuthTemplate>
+      <div className="flex flex-col items-center gap-4 text-center text-blue-800 dark:text-blue-300">
+        {status === 'pending' && (
+          <>
+            <Spinner />
+            <p>Подтверждаем адрес…</p>
+          </>
+        )}
+        {status === 'done' && <p>Адрес электронной почты подтвержден.</p>}
+        {status === 'failed' && <p>Ссылка недействительна или устарела.</p>}
+        {status === 'failed' && isAuth && (
+          <Button variant="secondary" loading={resending} onClick={resend}>
+            Отправить новую ссылку
+          </Button>
+        )}
+        {status !== 'pending' && (
+          <Link to={isAuth ? '/' : '/login'} className="text-sm text-blue-600 dark:text-blue-400 hover:text-blue-800 dark:hover:text-blue-300">
+            {isAuth ? 'Перейти к доскам' : 'Войти'}
+          </Link>
+        )}
+      </div>
+    </AuthTemplate>
+  );
+}
@@ -4,7 +4,7 @@ import { useAuthStore } from '@/store/useAuthStore';
 
 // Extract the base URL without the '/api' suffix for auth endpoints
 const baseURL = import.meta.env.VITE_API_URL || '';
-const baseServerURL = baseURL.endsWith('/api')
+export const baseServerURL = baseURL.endsWith('/api')
   ? baseURL.substring(0, baseURL.length - 4)
   : baseURL;
 
@@ -32,15 +32,50 @@ const addAuthToken = (cfg: import('axios').InternalAxiosRequestConfig) => {
 api.interceptors.request.use(addAuthToken);
 authApi.interceptors.request.use(addAuthToken);
 
+// One refresh at a time: requests that fail together wait for the same new token
+let refreshing: Promise<string> | null = null;
+
+// Exchange the refresh token for a new pair. Called on a plain axios instance so
+// a failed refresh does not come back through the interceptors below.
+const refreshAccessToken = (): Promise<string> => {
+  if (!refreshing) {
+    const { refreshToken } = useAuthStore.getState();
+    refreshing = (async () => {
+      if (!refreshToken) throw new Error('no refresh token');
+      const { data } = await axios.post(`${baseServerURL}/auth/refresh`, { refreshToken }, { timeout: 10_000 });
+      useAuthStore.getState().setTokens(data.token, data.refreshToken);
+      return data.token as string;
+    })().finally(() => {
+      refreshing = null;
+    });
+  }
+  return refreshing;
+};
+
+type RetriableConfig = import('axios').InternalAxiosRequestConfig & { _retried?: boolean };
+
 // Handle authentication errors
-const handleAuthErrors = (err: import('axios').AxiosError) => {
-  // Only handle 401 Unauthorized errors by logging out
+const handleAuthErrors = async (err: import('axios').AxiosError) => {
+  // Only handle 401 Unauthorized errors by refreshing the token or logging out
   // Do NOT handle 403 Forbidden errors here, as they should be handled by the specific API calls
   if (err.response?.status === 401) {
     const requestUrl = err.config?.url || '';
     const isAuthEndpoint = requestUrl.includes('/auth/login') || requestUrl.includes('/auth/register');
     const isCurrentlyAuthenticated = !!useAuthStore.getState().token;
 
+    // The access token is short-lived: get a new one and repeat the request once
+    const config = err.config as RetriableConfig | undefined;
+    if (!isAuthEndpoint && isCurrentlyAuthenticated && config && !config._retried) {
+      config._retried = true;
+      try {
+        const token = await refreshAccessToken();
+        config.headers.Authorization = `Bearer ${token}`;
+        return axios.request(config);
+      } catch {
+        // fall through to logout
+      }
+    }
+
     // Only auto-logout/redirect if:
     // 1. This is NOT an authentication endpoint (login/register)
     // 2. The user is currently authenticated (has a token)
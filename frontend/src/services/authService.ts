@@ -16,5 +16,53 @@ export const authService = {
     } catch (error) {
       throw handleApiError(error);
     }
+  },
+
+  /**
+   * Send a password reset link to the email, if such an account exists
+   * @param email The email of the account
+   */
+  async forgotPassword(email: string): Promise<void> {
+    try {
+      await authApi.post('/auth/forgot-password', { email });
+    } catch (error) {
+      throw handleApiError(error);
+    }
+  },
+
+  /**
+   * Set a new password with the token from a reset link
+   * @param token The token from the link
+   * @param newPassword The new password to set
+   */
+  async resetPassword(token: string, newPassword: string): Promise<void> {
+    try {
+      await authApi.post('/auth/reset-password', { token, newPassword });
+    } catch (error) {
+      throw handleApiError(error);
+    }
+  },
+
+  /**
+   * Confirm the email address with the token from a verification link
+   * @param token The token from the link
+   */
+  async verifyEmail(token: string): Promise<void> {
+    try {
+      await authApi.post('/auth/verify-email', { token });
+    } catch (error) {
+      throw handleApiError(error);
+    }
+  },
+
+  /**
+   * Send a new verification link to the current user's email
+   */
+  async resendVerification(): Promise<void> {
+    try {
+      await authApi.post('/auth/resend-verification');
+    } catch (error) {
+      throw handleApiError(error);
+    }
   }
 };
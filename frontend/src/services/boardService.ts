@@ -22,7 +22,9 @@ export type Card = {
   listId: string;
   title: string;
   description?: string;
-  position: number
+  position: number;
+  /** Версия карточки, на основе которой клиент отправляет изменения */
+  version?: number
 };
 
 export type List = {
@@ -30,6 +32,7 @@ export type List = {
   boardId: string;
   title: string;
   position: number;
+  version?: number;
   cards: Card[]
 };
 
@@ -38,6 +41,7 @@ export type Board = {
   name: string;  // Changed from 'title' to 'name' to match backend
   ownerId?: string; // Added to match backend
   role?: 'owner' | 'member'; // Added for board sharing feature
+  version?: number;
   lists: List[]
 };
 
@@ -186,11 +190,11 @@ export const boardService = {
   },
 
   /** Обновить название доски */
-  async updateBoard(boardId: string, name: string): Promise<Board> {
+  async updateBoard(boardId: string, name: string, version?: number): Promise<Board> {
     try {
       console.log(`Updating board ${boardId} with name: ${name}`);
 
-      const { data } = await api.put<ApiBoard>(BOARD_ENDPOINTS.board(boardId), { name });
+      const { data } = await api.put<ApiBoard>(BOARD_ENDPOINTS.board(boardId), { name, version });
       console.log("Raw updated board data from API:", data);
 
       // Normalize the board data
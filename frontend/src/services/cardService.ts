@@ -63,11 +63,12 @@ export const cardService = {
   },
 
   /** Обновить карточку */
-  async updateCard(cardId: string, listId: string, updates: { title?: string; description?: string }): Promise<Card> {
+  async updateCard(cardId: string, listId: string, updates: { title?: string; description?: string }, version?: number): Promise<Card> {
     try {
       console.log(`Updating card ${cardId} in list ${listId}:`, updates);
 
-      const { data } = await api.put<ApiCard>(CARD_ENDPOINTS.card(listId, cardId), updates);
+      // version — версия, на основе которой сделано изменение; при расхождении сервер ответит 409
+      const { data } = await api.put<ApiCard>(CARD_ENDPOINTS.card(listId, cardId), { ...updates, version });
       console.log("Raw updated card data from API:", data);
 
       // Normalize the card data
@@ -85,7 +86,7 @@ export const cardService = {
   },
 
   /** Переместить карточку (между списками или внутри списка) */
-  async moveCard(cardId: string, toListId: string, toPos: number): Promise<void> {
+  async moveCard(cardId: string, toListId: string, toPos: number, version?: number): Promise<Card> {
     try {
       console.log(`Moving card ${cardId} to list ${toListId} at position ${toPos}`);
 
@@ -93,15 +94,17 @@ export const cardService = {
       const listIdNum = parseInt(toListId);
 
       // Use PUT method as expected by the backend
-      await api.put(CARD_ENDPOINTS.moveCard(toListId, cardId), {
+      const { data } = await api.put<ApiCard>(CARD_ENDPOINTS.moveCard(toListId, cardId), {
         listId: listIdNum,
-        position: toPos
+        position: toPos,
+        version
       });
 
       console.log("Card moved successfully");
 
       // No need to send WebSocket event here
       // The backend will broadcast the event to all clients including this one
+      return normalizeCard(data, toListId);
     } catch (error) {
       console.error("Error moving card:", error);
       throw handleApiError(error);
@@ -143,7 +143,7 @@ export const listService = {
       console.log(`Found list ${listId} in board ${boardId}`);
 
       // Use the correct endpoint format: /boards/:boardId/lists/:id
-      const { data } = await api.put<ApiList>(LIST_ENDPOINTS.list(boardId, listId), { title });
+      const { data } = await api.put<ApiList>(LIST_ENDPOINTS.list(boardId, listId), { title, version: list.version });
 
       // Normalize the list data
       const normalizedList = normalizeList(data, boardId);
@@ -197,7 +197,8 @@ export const listService = {
 
       // Use PUT method as expected by the backend
       const { data } = await api.put<ApiList>(LIST_ENDPOINTS.moveList(boardId, listId), {
-        position: intPosition
+        position: intPosition,
+        version: list.version
       });
 
       console.log("List moved successfully, response:", data);
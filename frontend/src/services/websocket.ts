@@ -1,4 +1,5 @@
-import { useAuthStore } from '@/store/useAuthStore';
+import { api } from '@/services/api';
+import { BOARD_ENDPOINTS } from '@/utils/api/apiEndpoints';
 
 /** Сообщение, которое мы шлём / получаем по сокету */
 export type WSMessage<T = unknown> = {
@@ -57,6 +58,7 @@ class WebSocketClient {
     if (this.socket) {
       console.log(`Closing existing WebSocket connection for board ${this.boardId}`);
       this.socket.close();
+      this.cleanup();
     }
 
     // Set the board ID
@@ -98,11 +100,35 @@ class WebSocketClient {
     };
   }
 
-  private openSocket() {
-    const token = useAuthStore.getState().token;
-    const url = `${import.meta.env.VITE_WS_URL}/${this.boardId}?token=${token}`;
-    console.log(`Opening WebSocket connection to: ${url}`);
-    this.socket = new WebSocket(url);
+  /** Одноразовый билет на подключение: JWT в URL попадал бы в журналы прокси */
+  private async fetchTicket(boardId: string): Promise<string> {
+    const { data } = await api.post<{ ticket: string; expiresAt: string }>(
+      BOARD_ENDPOINTS.wsTicket(boardId),
+    );
+    return data.ticket;
+  }
+
+  private async openSocket() {
+    const boardId = this.boardId;
+    if (!boardId) return;
+
+    let ticket: string;
+    try {
+      ticket = await this.fetchTicket(boardId);
+    } catch (error) {
+      console.error(`Failed to get WebSocket ticket for board ${boardId}:`, error);
+      if (this.boardId === boardId) {
+        this.reconnectId = window.setTimeout(() => this.openSocket(), 3_000);
+      }
+      return;
+    }
+    // пока ждали билет, могли отключиться или перейти на другую доску
+    if (this.boardId !== boardId || this.socket) return;
+
+    const url = `${import.meta.env.VITE_WS_URL}/${boardId}?ticket=${encodeURIComponent(ticket)}`;
+    console.log(`Opening WebSocket connection for board ${boardId}`);
+    const socket = new WebSocket(url);
+    this.socket = socket;
 
     this.socket.onopen = () => {
       console.log(`WebSocket connection established for board ${this.boardId}`);
@@ -132,6 +158,8 @@ class WebSocketClient {
     };
 
     this.socket.onclose = (event) => {
+      // закрыто нами при переходе на другую доску или отключении
+      if (this.socket !== socket) return;
       console.log(`WebSocket connection closed for board ${this.boardId}. Code: ${event.code}, Reason: ${event.reason}`);
       this.cleanup();
       // пытаемся переподключиться раз в 3 сек
@@ -140,7 +168,7 @@ class WebSocketClient {
 
     this.socket.onerror = (error) => {
       console.error(`WebSocket error for board ${this.boardId}:`, error);
-      this.socket?.close();
+      socket.close();
     };
   }
 
@@ -116,6 +116,7 @@ export interface WSCardData {
   title: string;
   description?: string;
   position: number;
+  version?: number;
 }
 
 export interface WSListData {
@@ -123,12 +124,14 @@ export interface WSListData {
   boardId: string;
   title: string;
   position: number;
+  version?: number;
 }
 
 export interface WSBoardData {
   id: string;
   name: string;
   ownerId?: string;
+  version?: number;
 }
 
 export interface WSMemberData {
@@ -5,6 +5,8 @@ import { listService } from '@/services/listService';
 import { memberService } from '@/services/memberService';
 import { wsClient } from '@/services/websocket';
 import { useToastStore } from '@/store/useToastStore';
+import { conflictCurrent } from '@/utils/api/errorHandling';
+import { ApiBoard, normalizeBoard } from '@/utils/api/normalizeEntities';
 import { BoardState } from './types';
 import { normalizeId, extractBoardId } from '@/utils/board/idNormalization';
 import { setupWebSocketSubscriptions } from './useWebSocketStore';
@@ -332,17 +334,26 @@ export const useBoardStore = create<BoardState>()(
 
       try {
         // Call the API to update the board
-        const updatedBoard = await boardService.updateBoard(boardId, name);
+        const updatedBoard = await boardService.updateBoard(boardId, name, board.version);
+        set((s) => {
+          if (s.boards[boardId]) {
+            s.boards[boardId].version = updatedBoard.version;
+          }
+        });
 
         // Show success toast
         useToastStore.getState().success("Название доски обновлено");
       } catch (error) {
         console.error(`Error updating board ${boardId} name:`, error);
 
-        // Revert the optimistic update on error
+        // Revert the optimistic update on error, to the current state if the
+        // board was changed by someone else in the meantime
+        const current = conflictCurrent<ApiBoard>(error);
+        const fresh = current ? normalizeBoard(current, boardId) : undefined;
         set((s) => {
           if (s.boards[boardId]) {
-            s.boards[boardId].name = originalName;
+            s.boards[boardId].name = fresh ? fresh.name : originalName;
+            if (fresh) s.boards[boardId].version = fresh.version;
           }
         });
 
@@ -3,6 +3,8 @@ import { immer } from 'zustand/middleware/immer';
 import { Card } from '@/services/boardService';
 import { cardService } from '@/services/cardService';
 import { useToastStore } from '@/store/useToastStore';
+import { conflictCurrent } from '@/utils/api/errorHandling';
+import { ApiCard, normalizeCard } from '@/utils/api/normalizeEntities';
 import { CardsState } from './types';
 import { sortCards, getNextCardPosition } from '@/utils/board/sorting';
 
@@ -114,10 +116,28 @@ export const useCardsStore = create<CardsState>()(
 
       try {
         // Call API to update the card
-        const updatedCard = await cardService.updateCard(cardId, card.listId, updates);
+        const updatedCard = await cardService.updateCard(cardId, card.listId, updates, card.version);
+        set((s) => {
+          if (s.cards[cardId]) {
+            s.cards[cardId].version = updatedCard.version;
+          }
+        });
       } catch (error) {
         console.error(`Error updating card ${cardId}:`, error);
 
+        // Someone changed the card first: show its current state instead
+        const current = conflictCurrent<ApiCard>(error);
+        if (current) {
+          const fresh = normalizeCard(current, card.listId);
+          set((s) => {
+            if (s.cards[cardId]) {
+              s.cards[cardId] = { ...s.cards[cardId], title: fresh.title, description: fresh.description, version: fresh.version };
+            }
+          });
+          useToastStore.getState().error("Карточку изменил другой пользователь, ваши правки не сохранены");
+          return;
+        }
+
         // Revert optimistic update
         set((s) => {
           if (s.cards[cardId]) {
@@ -232,12 +252,21 @@ export const useCardsStore = create<CardsState>()(
 
       try {
         // Call API to move the card
-        await cardService.moveCard(cardId, toListId, toPos);
+        const moved = await cardService.moveCard(cardId, toListId, toPos, card.version);
+        set((s) => {
+          if (s.cards[cardId]) {
+            s.cards[cardId].version = moved.version;
+          }
+        });
       } catch (error) {
         console.error(`Error moving card ${cardId} to list ${toListId}:`, error);
 
         // Show error toast
-        useToastStore.getState().error("Не удалось переместить карточку");
+        useToastStore.getState().error(
+          conflictCurrent(error)
+            ? "Карточку изменил другой пользователь, доска обновлена"
+            : "Не удалось переместить карточку"
+        );
 
         // Reload the board to get the correct state
         // This is a fallback in case the optimistic update fails
@@ -3,6 +3,8 @@ import { immer } from 'zustand/middleware/immer';
 import { List } from '@/services/boardService';
 import { listService } from '@/services/listService';
 import { useToastStore } from '@/store/useToastStore';
+import { conflictCurrent } from '@/utils/api/errorHandling';
+import { ApiList, normalizeList } from '@/utils/api/normalizeEntities';
 import { ListsState } from './types';
 import { useBoardStore } from './useBoardStore';
 import { sortLists, getNextListPosition } from '@/utils/board/sorting';
@@ -151,9 +153,28 @@ export const useListsStore = create<ListsState>()(
       try {
         // Call API to update the list
         const updatedList = await listService.updateList(listId, title);
+        set((s) => {
+          if (s.lists[listId]) {
+            s.lists[listId].version = updatedList.version;
+          }
+        });
       } catch (error) {
         console.error(`Error updating list ${listId}:`, error);
 
+        // Someone changed the list first: show its current state instead
+        const current = conflictCurrent<ApiList>(error);
+        if (current) {
+          const fresh = normalizeList(current, list.boardId);
+          set((s) => {
+            if (s.lists[listId]) {
+              s.lists[listId].title = fresh.title;
+              s.lists[listId].version = fresh.version;
+            }
+          });
+          useToastStore.getState().error("Список изменил другой пользователь, ваши правки не сохранены");
+          return;
+        }
+
         // Revert optimistic update
         set((s) => {
           if (s.lists[listId]) {
@@ -209,7 +230,12 @@ export const useListsStore = create<ListsState>()(
         });
 
         // Call API to persist the change
-        await listService.moveList(listId, position);
+        const moved = await listService.moveList(listId, position);
+        set((s) => {
+          if (s.lists[listId]) {
+            s.lists[listId].version = moved.version;
+          }
+        });
 
         // Show success toast
         useToastStore.getState().success(`Список перемещен`);
@@ -229,7 +255,17 @@ export const useListsStore = create<ListsState>()(
         // Determine specific error message
         let errorMessage = "Не удалось переместить список. Пожалуйста, попробуйте снова.";
 
-        if (error instanceof Error) {
+        const current = conflictCurrent<ApiList>(error);
+        if (current) {
+          // Keep the version the server has, so a retry is based on it
+          const fresh = normalizeList(current, boardId);
+          set((s) => {
+            if (s.lists[listId]) {
+              s.lists[listId].version = fresh.version;
+            }
+          });
+          errorMessage = "Список изменил другой пользователь. Пожалуйста, попробуйте снова.";
+        } else if (error instanceof Error) {
           if (error.message.includes("not a member")) {
             errorMessage = "У вас нет прав для перемещения этого списка.";
           } else if (error.message.includes("position conflict")) {
@@ -62,7 +62,8 @@ export const useWebSocketStore = create<WebSocketState>()(
         listId: listId,
         title: data.Title || data.title || '',
         description: extractDescription(data),
-        position: data.Position || data.position || 0
+        position: data.Position || data.position || 0,
+        version: data.Version ?? data.version
       };
 
       console.log("Normalized card from WebSocket:", normalizedCard);
@@ -125,7 +126,8 @@ export const useWebSocketStore = create<WebSocketState>()(
         ...card,
         title: data.Title || data.title || card.title,
         description: extractDescription(data) || card.description,
-        position: data.Position || data.position || card.position
+        position: data.Position || data.position || card.position,
+        version: data.Version ?? data.version ?? card.version
       };
 
       // Update the card in the store
@@ -180,7 +182,8 @@ export const useWebSocketStore = create<WebSocketState>()(
         state.cards[cardId] = {
           ...state.cards[cardId],
           listId: toListId,
-          position: toPos
+          position: toPos,
+          version: data.version ?? data.Version ?? state.cards[cardId].version
         };
       });
 
@@ -244,6 +247,7 @@ export const useWebSocketStore = create<WebSocketState>()(
         boardId: boardId,
         title: data.Title || data.title || '',
         position: data.Position || data.position || 0,
+        version: data.Version ?? data.version,
         cards: []
       };
 
@@ -294,7 +298,8 @@ export const useWebSocketStore = create<WebSocketState>()(
       useListsStore.setState(state => {
         state.lists[listId] = {
           ...state.lists[listId],
-          title: data.Title || data.title || list.title
+          title: data.Title || data.title || list.title,
+          version: data.Version ?? data.version ?? list.version
         };
       });
 
@@ -326,6 +331,7 @@ export const useWebSocketStore = create<WebSocketState>()(
       useListsStore.setState(state => {
         // Update the moved list's position
         state.lists[listId].position = position;
+        state.lists[listId].version = data.Version ?? data.version ?? list.version;
 
         // Update positions of other lists in the same board
         const boardLists = listsStore.getListsByBoardId(boardId);
@@ -470,6 +476,7 @@ export const useWebSocketStore = create<WebSocketState>()(
       useBoardStore.setState(state => {
         if (state.boards[boardId]) {
           state.boards[boardId].name = boardName || state.boards[boardId].name;
+          state.boards[boardId].version = data.Version ?? data.version ?? state.boards[boardId].version;
           console.log(`Updated board ${boardId} name to "${boardName}" via WebSocket`);
         } else {
           console.warn(`Board ${boardId} not found in store during WebSocket update`);
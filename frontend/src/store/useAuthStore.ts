@@ -10,22 +10,29 @@ type User = {
 };
 
 interface AuthState {
-  /** JWT access‑токен */
+  /** JWT access‑токен (короткоживущий) */
   token: string | null;
+  /** Одноразовый токен для получения новой пары через /auth/refresh */
+  refreshToken: string | null;
   user: User | null;
 
   /** Авторизация */
   login: (email: string, password: string) => Promise<void>;
   /** Регистрация */
   register: (name: string, email: string, password: string) => Promise<void>;
+  /** Вход по токенам, выданным сервером после SSO */
+  loginWithTokens: (token: string, refreshToken: string) => Promise<void>;
+  /** Сохранить новую пару токенов после /auth/refresh */
+  setTokens: (token: string, refreshToken: string) => void;
   /** Выход из системы */
   logout: () => void;
 }
 
 export const useAuthStore = create<AuthState>()(
   persist(
-    (set) => ({
+    (set, get) => ({
       token: null,
+      refreshToken: null,
       user: null,
 
       async login(email, password) {
@@ -35,7 +42,7 @@ export const useAuthStore = create<AuthState>()(
           ...data.user,
           id: String(data.user.id)
         };
-        set({ token: data.token, user });
+        set({ token: data.token, refreshToken: data.refreshToken, user });
       },
 
       async register(name, email, password) {
@@ -45,17 +52,37 @@ export const useAuthStore = create<AuthState>()(
           ...data.user,
           id: String(data.user.id)
         };
-        set({ token: data.token, user });
+        set({ token: data.token, refreshToken: data.refreshToken, user });
+      },
+
+      async loginWithTokens(token, refreshToken) {
+        set({ token, refreshToken });
+        try {
+          const { data } = await authApi.get('/auth/me');
+          set({ user: { ...data, id: String(data.id) } });
+        } catch (error) {
+          set({ token: null, refreshToken: null, user: null });
+          throw error;
+        }
+      },
+
+      setTokens(token, refreshToken) {
+        set({ token, refreshToken });
       },
 
       logout() {
-        set({ token: null, user: null });
+        const { refreshToken } = get();
+        if (refreshToken) {
+          // завершаем сессию на сервере; ответ не ждём
+          authApi.post('/auth/logout', { refreshToken }).catch(() => {});
+        }
+        set({ token: null, refreshToken: null, user: null });
       },
     }),
     {
       /* храним auth‑данные между перезагрузками */
       name: 'auth',
-      partialize: (s) => ({ token: s.token, user: s.user }),
+      partialize: (s) => ({ token: s.token, refreshToken: s.refreshToken, user: s.user }),
     },
   ),
 );
@@ -10,6 +10,7 @@ export const BOARD_ENDPOINTS = {
   inviteMember: (boardId: string) => `/boards/${boardId}/members/invite`,
   removeMember: (boardId: string, userId: string) => `/boards/${boardId}/members/${userId}`,
   leaveBoard: (boardId: string) => `/boards/${boardId}/members/leave`,
+  wsTicket: (boardId: string) => `/boards/${boardId}/ws-ticket`,
 };
 
 export const LIST_ENDPOINTS = {
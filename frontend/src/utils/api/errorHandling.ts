@@ -136,6 +136,18 @@ export function handleApiError(error: Error | AxiosError | unknown): ApiError {
   }
 }
 
+/**
+ * The current state of an entity from a 409 version conflict, or undefined
+ * for any other error. The server sends it so the client can catch up
+ * without reloading.
+ */
+export function conflictCurrent<T = unknown>(error: unknown): T | undefined {
+  const apiError = error as ApiError | undefined;
+  if (apiError?.statusCode !== 409) return undefined;
+  const axiosError = apiError.originalError as AxiosError<{ current?: T }> | undefined;
+  return axiosError?.response?.data?.current;
+}
+
 /**
  * Get a user-friendly error message for a specific entity operation
  */
@@ -15,6 +15,8 @@ export interface ApiBoard {
   ownerId?: string | number;
   owner_id?: string | number;
   role?: 'owner' | 'member';
+  Version?: number;
+  version?: number;
   lists?: ApiList[];
 }
 
@@ -28,6 +30,8 @@ export interface ApiList {
   title?: string;
   Position?: number;
   position?: number;
+  Version?: number;
+  version?: number;
   cards?: ApiCard[];
 }
 
@@ -43,6 +47,8 @@ export interface ApiCard {
   description?: string;
   Position?: number;
   position?: number;
+  Version?: number;
+  version?: number;
 }
 
 export interface ApiBoardMember {
@@ -97,6 +103,7 @@ export function normalizeBoard(data: ApiBoard, providedBoardId?: string): Board
     name,
     ownerId,
     role: role as 'owner' | 'member',
+    version: data.Version ?? data.version,
     lists: Array.isArray(data.lists) ? data.lists.map(list => normalizeList(list, boardId)) : []
   };
 }
@@ -118,6 +125,7 @@ export function normalizeList(data: ApiList, providedBoardId?: string): List {
     boardId,
     title: data.Title || data.title || '',
     position: data.Position || data.position || 0,
+    version: data.Version ?? data.version,
     cards: Array.isArray(data.cards) ? data.cards.map(card => normalizeCard(card, listId)) : []
   };
 }
@@ -149,7 +157,8 @@ export function normalizeCard(data: ApiCard, providedListId?: string): Card {
     listId,
     title: data.Title || data.title || '',
     description,
-    position: data.Position || data.position || 0
+    position: data.Position || data.position || 0,
+    version: data.Version ?? data.version
   };
 }
 